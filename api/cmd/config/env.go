@@ -4,6 +4,7 @@ import (
 	"foundersignal/internal/domain"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -35,6 +36,9 @@ type Config struct {
 	APP_URL            string
 	SCROLL_DEBOUNCE_MS int
 
+	STRICT_HTML_SANITIZATION bool
+	ALLOWED_IFRAME_HOSTS     []string
+
 	CLOUDFLARE_R2_BUCKET_NAME       string
 	CLOUDFLARE_R2_ACCOUNT_ID        string
 	CLOUDFLARE_R2_ACCESS_KEY_ID     string
@@ -45,6 +49,32 @@ type Config struct {
 
 	STARTER_PLAN_IDEA_CREATION_DAYS int
 
+	REFERRAL_POSITION_BOOST int
+
+	SEMANTIC_SEARCH_BACKEND        string
+	SEMANTIC_SEARCH_VECTOR_WEIGHT  float64
+	SEMANTIC_SEARCH_MIN_SIMILARITY float64
+	SEMANTIC_SEARCH_CANDIDATES     int
+	DUPLICATE_IDEA_SIMILARITY      float64
+	RELATED_IDEA_MIN_SIMILARITY    float64
+
+	TRENDING_REFRESH_INTERVAL_MINUTES int
+	TRENDING_HALF_LIFE_HOURS          float64
+	TRENDING_WINDOW_DAYS              int
+
+	LIFECYCLE_INTERVAL_MINUTES int
+	DIGEST_INTERVAL_HOURS      int
+
+	INVITATION_EXPIRY_DAYS int
+
+	SHARE_TOKEN_SECRET string
+
+	SMTP_HOST     string
+	SMTP_PORT     int
+	SMTP_USERNAME string
+	SMTP_PASSWORD string
+	MAIL_FROM     string
+
 	APP_ENV string
 
 	RATE_LIMITER_RATE  float64
@@ -90,11 +120,40 @@ func initConfig() Config {
 		SAMPLE_REDDIT_VALIDATION_ID:     getEnv("SAMPLE_REDDIT_VALIDATION_ID", "c52af4c0-28f5-45ad-bfa2-eba8e437b3ba"),
 		STARTER_PLAN_IDEA_CREATION_DAYS: getEnvAsInt("STARTER_PLAN_IDEA_CREATION_DAYS", 3),
 
+		REFERRAL_POSITION_BOOST: getEnvAsInt("REFERRAL_POSITION_BOOST", 3),
+
+		SEMANTIC_SEARCH_BACKEND:        getEnv("SEMANTIC_SEARCH_BACKEND", "memory"),
+		SEMANTIC_SEARCH_VECTOR_WEIGHT:  getEnvAsFloat("SEMANTIC_SEARCH_VECTOR_WEIGHT", 0.6),
+		SEMANTIC_SEARCH_MIN_SIMILARITY: getEnvAsFloat("SEMANTIC_SEARCH_MIN_SIMILARITY", 0.55),
+		SEMANTIC_SEARCH_CANDIDATES:     getEnvAsInt("SEMANTIC_SEARCH_CANDIDATES", 200),
+		DUPLICATE_IDEA_SIMILARITY:      getEnvAsFloat("DUPLICATE_IDEA_SIMILARITY", 0.85),
+		RELATED_IDEA_MIN_SIMILARITY:    getEnvAsFloat("RELATED_IDEA_MIN_SIMILARITY", 0.5),
+
+		TRENDING_REFRESH_INTERVAL_MINUTES: getEnvAsInt("TRENDING_REFRESH_INTERVAL_MINUTES", 15),
+		TRENDING_HALF_LIFE_HOURS:          getEnvAsFloat("TRENDING_HALF_LIFE_HOURS", 48),
+		TRENDING_WINDOW_DAYS:              getEnvAsInt("TRENDING_WINDOW_DAYS", 14),
+
+		LIFECYCLE_INTERVAL_MINUTES: getEnvAsInt("LIFECYCLE_INTERVAL_MINUTES", 15),
+		DIGEST_INTERVAL_HOURS:      getEnvAsInt("DIGEST_INTERVAL_HOURS", 24),
+
+		INVITATION_EXPIRY_DAYS: getEnvAsInt("INVITATION_EXPIRY_DAYS", 7),
+
+		SHARE_TOKEN_SECRET: getEnv("SHARE_TOKEN_SECRET", ""),
+
+		SMTP_HOST:     getEnv("SMTP_HOST", ""),
+		SMTP_PORT:     getEnvAsInt("SMTP_PORT", 587),
+		SMTP_USERNAME: getEnv("SMTP_USERNAME", ""),
+		SMTP_PASSWORD: getEnv("SMTP_PASSWORD", ""),
+		MAIL_FROM:     getEnv("MAIL_FROM", "FounderSignal <no-reply@foundersignal.app>"),
+
 		TAILWIND_CSS_URL:   getEnv("TAILWIND_CSS_URL", "https://cdn.jsdelivr.net/npm/tailwindcss@2.2.19/dist/tailwind.min.css"),
 		CTA_BUTTON_ID:      getEnv("CTA_BUTTON_ID", "ctaButton"),
 		SCROLL_DEBOUNCE_MS: getEnvAsInt("SCROLL_DEBOUNCE_MS", 250),
 		APP_URL:            getEnv("APP_URL", "http://localhost:3000"),
 
+		STRICT_HTML_SANITIZATION: getEnvAsBool("STRICT_HTML_SANITIZATION", true),
+		ALLOWED_IFRAME_HOSTS:     getEnvAsSlice("ALLOWED_IFRAME_HOSTS", []string{"www.youtube.com", "www.youtube-nocookie.com", "player.vimeo.com", "www.loom.com"}),
+
 		CLOUDFLARE_R2_BUCKET_NAME:       getEnv("CLOUDFLARE_R2_BUCKET_NAME", "foundersignal"),
 		CLOUDFLARE_R2_ACCOUNT_ID:        getEnv("CLOUDFLARE_R2_ACCOUNT_ID", "your-account-id"),
 		CLOUDFLARE_R2_ACCESS_KEY_ID:     getEnv("CLOUDFLARE_R2_ACCESS_KEY_ID", "your-access-key-id"),
@@ -133,3 +192,28 @@ func getEnvAsFloat(key string, fallback float64) float64 {
 
 	return fallback
 }
+
+func getEnvAsBool(key string, fallback bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+
+	return fallback
+}
+
+func getEnvAsSlice(key string, fallback []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback
+	}
+
+	var values []string
+	for _, v := range strings.Split(valueStr, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -6,6 +6,7 @@ import (
 	"foundersignal/internal/pkg/ai"
 	"foundersignal/internal/pkg/auth"
 	"foundersignal/internal/pkg/cloudflare"
+	"foundersignal/internal/pkg/mailer"
 	"foundersignal/internal/pkg/reddit"
 	"foundersignal/internal/pkg/validation"
 	"foundersignal/internal/repository"
@@ -16,6 +17,7 @@ import (
 	"foundersignal/pkg/database"
 	rate_limiter "foundersignal/pkg/rate-limiter"
 	"log"
+	"time"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
@@ -23,11 +25,25 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// minShareTokenSecretLength keeps the share links of private ideas from being
+// signed with a guessable secret
+const minShareTokenSecretLength = 32
+
 func main() {
+	if len(cfg.Envs.SHARE_TOKEN_SECRET) < minShareTokenSecretLength {
+		log.Fatalf("SHARE_TOKEN_SECRET must be a random secret of at least %d characters", minShareTokenSecretLength)
+	}
+
 	if err := database.Connect(cfg.Envs.DB); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	if cfg.Envs.SEMANTIC_SEARCH_BACKEND == repository.VectorBackendPgvector {
+		if err := database.EnablePgvector(database.GetDB()); err != nil {
+			log.Fatalf("Failed to enable pgvector: %v", err)
+		}
+	}
+
 	http.InitializeLogger(cfg.Envs.APP_ENV)
 	defer http.GetLogger().Sync()
 
@@ -58,6 +74,9 @@ func main() {
 				CTAButtonID:      cfg.Envs.CTA_BUTTON_ID,
 				AppUrl:           cfg.Envs.APP_URL,
 				ScrollDebounceMs: cfg.Envs.SCROLL_DEBOUNCE_MS,
+
+				StrictSanitization: cfg.Envs.STRICT_HTML_SANITIZATION,
+				AllowedIframeHosts: cfg.Envs.ALLOWED_IFRAME_HOSTS,
 			},
 		},
 		Paddle: service.PaddleServiceConfig{
@@ -73,6 +92,38 @@ func main() {
 		},
 		Idea: service.IdeaServiceConfig{
 			StarterPlanIdeaCreationDays: cfg.Envs.STARTER_PLAN_IDEA_CREATION_DAYS,
+			DuplicateSimilarity:         cfg.Envs.DUPLICATE_IDEA_SIMILARITY,
+			RelatedMinSimilarity:        cfg.Envs.RELATED_IDEA_MIN_SIMILARITY,
+		},
+		Waitlist: service.WaitlistConfig{
+			AppUrl:        cfg.Envs.APP_URL,
+			PositionBoost: cfg.Envs.REFERRAL_POSITION_BOOST,
+		},
+		Trending: service.TrendingConfig{
+			RefreshInterval: time.Duration(cfg.Envs.TRENDING_REFRESH_INTERVAL_MINUTES) * time.Minute,
+			HalfLifeHours:   cfg.Envs.TRENDING_HALF_LIFE_HOURS,
+			WindowDays:      cfg.Envs.TRENDING_WINDOW_DAYS,
+		},
+		Lifecycle: service.IdeaLifecycleConfig{
+			Interval: time.Duration(cfg.Envs.LIFECYCLE_INTERVAL_MINUTES) * time.Minute,
+		},
+		Follow: service.FollowConfig{
+			AppUrl:         cfg.Envs.APP_URL,
+			DigestInterval: time.Duration(cfg.Envs.DIGEST_INTERVAL_HOURS) * time.Hour,
+		},
+		Share: service.IdeaShareConfig{
+			AppUrl: cfg.Envs.APP_URL,
+			Secret: cfg.Envs.SHARE_TOKEN_SECRET,
+		},
+		Collaborator: service.CollaboratorConfig{
+			AppUrl:        cfg.Envs.APP_URL,
+			InvitationTTL: time.Duration(cfg.Envs.INVITATION_EXPIRY_DAYS) * 24 * time.Hour,
+		},
+		IdeaSearch: service.IdeaSearchConfig{
+			EmbeddingModel: cfg.Envs.GeminiEmbeddingModelCode,
+			VectorWeight:   cfg.Envs.SEMANTIC_SEARCH_VECTOR_WEIGHT,
+			MinSimilarity:  cfg.Envs.SEMANTIC_SEARCH_MIN_SIMILARITY,
+			CandidateLimit: cfg.Envs.SEMANTIC_SEARCH_CANDIDATES,
 		},
 		CloudflareR2: cloudflare.R2Config{
 			BucketName:        cfg.Envs.CLOUDFLARE_R2_BUCKET_NAME,
@@ -82,6 +133,13 @@ func main() {
 			R2BucketPublicUrl: cfg.Envs.CLOUDFLARE_R2_BUCKET_PUBLIC_URL,
 			Environment:       cfg.Envs.APP_ENV,
 		},
+		Mailer: mailer.Config{
+			SMTPHost:     cfg.Envs.SMTP_HOST,
+			SMTPPort:     cfg.Envs.SMTP_PORT,
+			SMTPUsername: cfg.Envs.SMTP_USERNAME,
+			SMTPPassword: cfg.Envs.SMTP_PASSWORD,
+			From:         cfg.Envs.MAIL_FROM,
+		},
 		SampleRedditValidationID: uuid.MustParse(cfg.Envs.SAMPLE_REDDIT_VALIDATION_ID),
 	}
 
@@ -95,8 +153,27 @@ func main() {
 
 	redditClient := reddit.NewClient()
 
-	repos := repository.NewRepositories(db)
+	repos := repository.NewRepositories(db, repository.RepositoriesConfig{VectorBackend: cfg.Envs.SEMANTIC_SEARCH_BACKEND})
 	services := service.NewServices(repos, activityBroadcaster, aiService, redditClient, servicesCfg)
+
+	if err := services.Category.SeedDefaults(context.Background()); err != nil {
+		log.Printf("WARN: Failed to seed categories: %v", err)
+	}
+
+	go services.Trending.Start(context.Background())
+	go services.Lifecycle.Start(context.Background())
+	go services.Follow.Start(context.Background())
+
+	go func() {
+		if err := services.Search.BackfillMissing(context.Background()); err != nil {
+			log.Printf("WARN: Failed to backfill idea embeddings: %v", err)
+		}
+	}()
+	go func() {
+		if err := services.Feedback.BackfillSentiment(context.Background()); err != nil {
+			log.Printf("WARN: Failed to backfill feedback sentiment: %v", err)
+		}
+	}()
 	handlers := http.NewHandlers(services)
 	webhooks := wh.NewWebhooks(services, wh.Secrets{
 		ClerkWebhookSecret:  cfg.Envs.CLERK_WEBHOOK_SECRET,
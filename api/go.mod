@@ -3,6 +3,7 @@ module foundersignal
 go 1.24.2
 
 require (
+	github.com/HugoSmits86/nativewebp v0.9.3
 	github.com/PaddleHQ/paddle-go-sdk v1.0.0
 	github.com/aws/aws-sdk-go-v2 v1.37.1
 	github.com/aws/aws-sdk-go-v2/config v1.30.2
@@ -21,8 +22,10 @@ require (
 	github.com/svix/svix-webhooks v1.67.0
 	github.com/vartanbeno/go-reddit/v2 v2.0.1
 	go.uber.org/zap v1.27.0
+	golang.org/x/image v0.24.0
 	golang.org/x/net v0.38.0
 	golang.org/x/sync v0.14.0
+	golang.org/x/text v0.24.0
 	golang.org/x/time v0.6.0
 	google.golang.org/genai v1.12.0
 	gorm.io/datatypes v1.2.5
@@ -92,7 +95,6 @@ require (
 	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/oauth2 v0.23.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
-	golang.org/x/text v0.24.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240903143218-8af14fe29dc1 // indirect
 	google.golang.org/grpc v1.66.2 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
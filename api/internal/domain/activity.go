@@ -5,7 +5,9 @@ import "github.com/google/uuid"
 type ActivityType string
 
 const (
-	ActivityTypeContentReported ActivityType = "content_reported"
+	ActivityTypeContentReported   ActivityType = "content_reported"
+	ActivityTypeIdeaStatusChanged ActivityType = "idea_status_changed"
+	ActivityTypeIdeaUpdate        ActivityType = "idea_update" // progress update of a followed idea
 )
 
 // Activity represents a generic activity or notification for a user.
@@ -19,9 +19,26 @@ type AudienceMember struct {
 	LastActive     *time.Time `json:"lastActive,omitempty"`
 	Visits         int        `gorm:"default:0" json:"visits"`
 
+	// Waitlist referrals, ReferredBy holds the user id of the referring member of the same idea
+	ReferralCode      string  `gorm:"type:varchar(16);uniqueIndex" json:"referralCode"`
+	ReferredBy        *string `gorm:"index" json:"referredBy,omitempty"`
+	SignupFingerprint string  `gorm:"type:varchar(64)" json:"-"`
+	SearchRank        float64 `gorm:"->;-:migration" json:"-"` // only selected by searches
+
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	Idea         Idea         `gorm:"foreignKey:IdeaID" json:"idea,omitempty"`
 	MVPSimulator MVPSimulator `gorm:"foreignKey:MVPSimulatorID" json:"-"`
 }
+
+// WaitlistEntry is an audience member ranked on the waitlist of an idea.
+// Every successful referral moves a member up by a fixed number of spots.
+type WaitlistEntry struct {
+	UserID        string    `json:"userId"`
+	UserEmail     string    `json:"userEmail"`
+	ReferralCode  string    `json:"referralCode"`
+	SignupTime    time.Time `json:"signupTime"`
+	ReferralCount int       `json:"referralCount"`
+	Position      int       `json:"position"`
+}
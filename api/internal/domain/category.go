@@ -0,0 +1,33 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Category is a node of the managed category tree ideas are filed under.
+type Category struct {
+	Base
+	Name     string     `gorm:"not null" json:"name"`
+	Slug     string     `gorm:"not null;uniqueIndex" json:"slug"`
+	ParentID *uuid.UUID `gorm:"type:uuid;index" json:"parentId,omitempty"`
+	Position int        `gorm:"not null;default:0" json:"position"`
+}
+
+// CategorySeed describes a top level category and its subcategories.
+type CategorySeed struct {
+	Name     string
+	Children []string
+}
+
+// DefaultCategories is created at startup, existing categories are left untouched
+// so they can be renamed or reorganized in the database.
+var DefaultCategories = []CategorySeed{
+	{Name: "SaaS", Children: []string{"B2B Software", "Developer Tools", "Productivity", "Marketing Tools"}},
+	{Name: "Consumer", Children: []string{"Social", "Entertainment", "Lifestyle", "Travel"}},
+	{Name: "Health", Children: []string{"Fitness", "Mental Health", "Healthcare", "Nutrition"}},
+	{Name: "Finance", Children: []string{"Fintech", "Personal Finance", "Crypto"}},
+	{Name: "Education", Children: []string{"E-learning", "Kids", "Career"}},
+	{Name: "Commerce", Children: []string{"E-commerce", "Marketplaces", "Retail"}},
+	{Name: "AI & Data", Children: []string{"AI Assistants", "Analytics", "Automation"}},
+	{Name: "Sustainability", Children: []string{"Climate", "Food Waste", "Energy"}},
+	{Name: "Hardware", Children: []string{"IoT", "Wearables"}},
+	{Name: "Other"},
+}
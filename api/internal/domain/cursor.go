@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the end of a page for keyset pagination, clients get it
+// encoded as an opaque string and send it back to fetch the next page.
+type Cursor struct {
+	Sort   string   `json:"s,omitempty"` // sort the cursor was created for
+	Value  string   `json:"v,omitempty"` // sort key of the last item
+	ID     string   `json:"i,omitempty"` // id of the last item, breaks ties
+	Rank   *float64 `json:"r,omitempty"` // search rank of the last item, for searched listings
+	Offset int      `json:"o,omitempty"` // used by listings ordered in memory
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
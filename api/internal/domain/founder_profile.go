@@ -0,0 +1,36 @@
+package domain
+
+import "gorm.io/datatypes"
+
+// ProfileLink is a link shown on the public profile of a founder, e.g. their website or X account
+type ProfileLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// FounderProfile holds what a founder shares on their public page, the name
+// and picture come from their User. A founder without one has a hidden
+// profile until they choose to publish it.
+type FounderProfile struct {
+	Base
+	UserID string                           `gorm:"not null;uniqueIndex" json:"userId"`
+	Bio    string                           `gorm:"type:text" json:"bio"`
+	Links  datatypes.JSONSlice[ProfileLink] `gorm:"type:jsonb;not null;default:'[]'" json:"links"`
+
+	// Visibility settings
+	IsPublic  bool `gorm:"not null" json:"isPublic"`  // the whole profile, a hidden profile is not found
+	ShowIdeas bool `gorm:"not null" json:"showIdeas"` // the public active ideas
+	ShowStats bool `gorm:"not null" json:"showStats"` // the reputation metrics and the stats of each idea
+}
+
+// DefaultFounderProfile is the profile of a founder who never edited it, it is
+// hidden and shows no stats so that nothing is published without consent
+func DefaultFounderProfile(userId string) *FounderProfile {
+	return &FounderProfile{
+		UserID:    userId,
+		Links:     datatypes.NewJSONSlice([]ProfileLink{}),
+		IsPublic:  false,
+		ShowIdeas: true,
+		ShowStats: false,
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -8,23 +10,39 @@ import (
 
 type Idea struct {
 	Base
-	UserID             string    `gorm:"not null;index;uniqueIndex:idx_user_idea_title" json:"userId"`
-	Title              string    `gorm:"not null;uniqueIndex:idx_user_idea_title" json:"title"`
-	Slug               string    `gorm:"not null;uniqueIndex;index" json:"slug"`
-	Description        string    `gorm:"type:text;not null" json:"description"`
-	TargetAudience     string    `gorm:"not null" json:"targetAudience"`
-	IsPrivate          *bool     `gorm:"default:false" json:"isPrivate"`
-	Status             string    `gorm:"not null;default:'active';index" json:"status"` // status defined in ./types.go file
-	Stage              string    `gorm:"not null;default:'ideation';index" json:"stage"`
-	TargetSignups      int       `gorm:"default:100" json:"targetSignups"`
-	ImageURL           string    `json:"imageUrl"`
-	Likes              int       `gorm:"default:0" json:"likes"`
-	Dislikes           int       `gorm:"default:0" json:"dislikes"`
-	RedditValidationID uuid.UUID `gorm:"type:uuid;index" json:"redditValidationId,omitempty"` // Optional validation analysis from Reddit
+	UserID             string     `gorm:"not null;index;uniqueIndex:idx_user_idea_title" json:"userId"`
+	WorkspaceID        *uuid.UUID `gorm:"type:uuid;index" json:"workspaceId,omitempty"` // Billed to the workspace instead of the user when set
+	Title              string     `gorm:"not null;uniqueIndex:idx_user_idea_title" json:"title"`
+	Slug               string     `gorm:"not null;uniqueIndex;index" json:"slug"`
+	Description        string     `gorm:"type:text;not null" json:"description"`
+	TargetAudience     string     `gorm:"not null" json:"targetAudience"`
+	IsPrivate          *bool      `gorm:"default:false" json:"isPrivate"`
+	Status             string     `gorm:"not null;default:'active';index" json:"status"` // status defined in ./types.go file
+	Stage              string     `gorm:"not null;default:'ideation';index" json:"stage"`
+	TargetSignups      int        `gorm:"default:100" json:"targetSignups"`
+	ImageURL           string     `json:"imageUrl"`
+	ShareImageURL      string     `json:"shareImageUrl"` // Generated Open Graph card
+	Likes              int        `gorm:"default:0" json:"likes"`
+	Dislikes           int        `gorm:"default:0" json:"dislikes"`
+	RedditValidationID uuid.UUID  `gorm:"type:uuid;index" json:"redditValidationId,omitempty"` // Optional validation analysis from Reddit
+
+	// Taxonomy
+	CategoryID *uuid.UUID                  `gorm:"type:uuid;index" json:"categoryId,omitempty"`
+	Tags       datatypes.JSONSlice[string] `gorm:"type:jsonb;not null;default:'[]';index:idx_idea_tags,type:gin" json:"tags"`
+
+	// Files of the uploaded image, empty when the image is only a URL
+	ImageVariants datatypes.JSONSlice[IdeaImageVariant] `gorm:"type:jsonb;not null;default:'[]'" json:"imageVariants,omitempty"`
 
 	// Search-specific fields for better performance
 	SearchVector string `gorm:"type:tsvector;index:idx_search_vector,type:gin" json:"-"`
 
+	// Time decayed engagement, refreshed periodically for the trending sort
+	TrendingScore float64 `gorm:"not null;default:0;index" json:"-"`
+
+	// Relevance of the idea for a keyword search, only selected by searches.
+	// It has no column, so queries with joins must select ideas.* explicitly.
+	SearchRank float64 `gorm:"->;-:migration" json:"-"`
+
 	Signups        int     `json:"signups"`
 	Views          int     `json:"views"`
 	EngagementRate float64 `json:"engagementRate"`
@@ -33,6 +51,8 @@ type Idea struct {
 
 	// Relationships
 	User            User             `gorm:"foreignKey:UserID" json:"-"`
+	Workspace       *Workspace       `gorm:"foreignKey:WorkspaceID" json:"-"`
+	Category        *Category        `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	MVPs            []MVPSimulator   `gorm:"foreignKey:IdeaID" json:"mvps,omitempty"`
 	Signals         []Signal         `gorm:"foreignKey:IdeaID" json:"signals,omitempty"`
 	Feedback        []Feedback       `gorm:"foreignKey:IdeaID" json:"comments,omitempty"`
@@ -80,20 +100,46 @@ func (i *Idea) AfterFind(tx *gorm.DB) (err error) {
 // MVPSimulator represents the mock landing page for an idea
 type MVPSimulator struct {
 	Base
-	IdeaID        uuid.UUID `gorm:"type:uuid;not null;index" json:"ideaId"`
-	Name          string    `gorm:"not null" json:"name"`
-	IsActive      bool      `gorm:"default:false;not null" json:"isActive"`
-	HTMLContent   *string   `gorm:"type:text" json:"htmlContent"`
-	HTMLURL       string    `gorm:"type:text" json:"htmlUrl"`       // URL to the r2 hosted HTML content
-	AIGenerations int       `gorm:"default:0" json:"aiGenerations"` // Number of AI-generated content pieces
-
-	Views   int `gorm:"-" json:"views"`
-	Signups int `gorm:"-" json:"signups"`
+	IdeaID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"ideaId"`
+	Name          string         `gorm:"not null" json:"name"`
+	IsActive      bool           `gorm:"default:false;not null" json:"isActive"`
+	HTMLContent   *string        `gorm:"type:text" json:"htmlContent"`
+	HTMLURL       string         `gorm:"type:text" json:"htmlUrl"`                 // URL to the r2 hosted HTML content
+	AIGenerations int            `gorm:"default:0" json:"aiGenerations"`           // Number of AI-generated content pieces
+	QualityAudit  datatypes.JSON `gorm:"type:jsonb" json:"qualityAudit,omitempty"` // Latest static quality audit of the landing page
+	Sanitization  datatypes.JSON `gorm:"type:jsonb" json:"sanitization,omitempty"` // What the strict sanitizer removed from the landing page
+	PricingTest   datatypes.JSON `gorm:"type:jsonb" json:"pricingTest,omitempty"`  // Fake-door pricing block configured by the founder
+	Survey        datatypes.JSON `gorm:"type:jsonb" json:"survey,omitempty"`       // Post-signup micro-survey configured by the founder
+	ShareImageURL string         `gorm:"type:text" json:"shareImageUrl"`           // Generated Open Graph card of the landing page
+	Locale        string         `gorm:"not null;default:'en'" json:"locale"`      // Language of the original landing page
+
+	Views            int      `gorm:"-" json:"views"`
+	Signups          int      `gorm:"-" json:"signups"`
+	AvailableLocales []string `gorm:"-" json:"availableLocales,omitempty"`
 
 	// Relationships
 	Idea            Idea             `gorm:"foreignKey:IdeaID" json:"idea,omitempty"`
 	Signals         []Signal         `gorm:"foreignKey:MVPSimulatorID" json:"signals,omitempty"`
 	AudienceMembers []AudienceMember `gorm:"foreignKey:MVPSimulatorID" json:"audience,omitempty"`
+	Locales         []MVPLocale      `gorm:"foreignKey:MVPSimulatorID" json:"locales,omitempty"`
+}
+
+type MVPLocaleStatus string
+
+const (
+	MVPLocaleStatusPending MVPLocaleStatus = "pending"
+	MVPLocaleStatusReady   MVPLocaleStatus = "ready"
+	MVPLocaleStatusFailed  MVPLocaleStatus = "failed"
+)
+
+// MVPLocale is a translated variant of an MVP landing page
+type MVPLocale struct {
+	Base
+	MVPSimulatorID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_mvp_locale" json:"mvpSimulatorId"`
+	IdeaID         uuid.UUID `gorm:"type:uuid;not null;index" json:"ideaId"`
+	Locale         string    `gorm:"not null;uniqueIndex:idx_mvp_locale" json:"locale"` // BCP 47 tag, e.g. "de" or "pt-BR"
+	Status         string    `gorm:"not null;default:'pending'" json:"status"`
+	HTMLURL        string    `gorm:"type:text" json:"htmlUrl"`
 }
 
 func (m *MVPSimulator) AfterFind(tx *gorm.DB) (err error) {
@@ -115,6 +161,7 @@ type Signal struct {
 	MVPSimulatorID uuid.UUID      `gorm:"type:uuid;not null;index" json:"mvpSimulatorId"`
 	UserID         string         `json:"userId,omitempty"`                // Can be null for anonymous users
 	EventType      string         `gorm:"not null;index" json:"eventType"` // click, scroll, pageview, etc.
+	Locale         string         `gorm:"index" json:"locale,omitempty"`   // Locale of the landing page variant that was served
 	IPAddress      string         `json:"-"`
 	UserAgent      string         `json:"-"`
 	Metadata       datatypes.JSON `gorm:"type:jsonb" json:"metadata"`
@@ -135,6 +182,10 @@ type Feedback struct {
 	Likes          int        `gorm:"default:0" json:"likes"`
 	Dislikes       int        `gorm:"default:0" json:"dislikes"`
 
+	// Scoring of the sentiment, nil until the comment is scored in the background
+	SentimentScoredAt *time.Time `gorm:"index" json:"-"`
+	SentimentSource   string     `gorm:"type:varchar(20)" json:"-"`
+
 	// Relationships
 	Idea      Idea               `gorm:"foreignKey:IdeaID" json:"-"`
 	Parent    *Feedback          `gorm:"foreignKey:ParentID" json:"-"`
@@ -142,6 +193,12 @@ type Feedback struct {
 	Reactions []FeedbackReaction `gorm:"foreignKey:FeedbackID"`
 }
 
+// Analyzers the sentiment of a feedback is scored by
+const (
+	SentimentSourceAI      = "ai"
+	SentimentSourceLexicon = "lexicon"
+)
+
 func (f *Feedback) AfterFind(tx *gorm.DB) (err error) {
 	// This hook calculates Likes and Dislikes based on the f.Reactions slice.
 	// It assumes that f.Reactions are preloaded when Feedback is fetched.
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// IdeaEmbedding holds the semantic search vector of an idea, ContentHash is
+// used to skip re-embedding when the searchable text did not change.
+type IdeaEmbedding struct {
+	Base
+	IdeaID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"ideaId"`
+	Model       string         `gorm:"not null;index" json:"model"`
+	ContentHash string         `gorm:"type:varchar(64);not null" json:"-"`
+	Dimensions  int            `gorm:"not null" json:"dimensions"`
+	Vector      datatypes.JSON `gorm:"type:jsonb;not null" json:"-"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaFollower is a user keeping up with the updates of an idea
+type IdeaFollower struct {
+	Base
+	IdeaID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idea_follower" json:"ideaId"`
+	UserID      string    `gorm:"not null;uniqueIndex:idx_idea_follower;index" json:"userId"`
+	EmailDigest bool      `gorm:"not null;default:true" json:"emailDigest"` // receives the updates in the email digest
+	DigestedAt  time.Time `gorm:"not null" json:"-"`                        // updates posted before were sent in a digest already
+
+	// Relationships
+	Idea Idea `gorm:"foreignKey:IdeaID" json:"-"`
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// IdeaUpdate is a short progress update posted by the team of an idea for its followers
+type IdeaUpdate struct {
+	Base
+	IdeaID  uuid.UUID `gorm:"type:uuid;not null;index" json:"ideaId"`
+	UserID  string    `gorm:"not null" json:"userId"` // who posted it
+	Content string    `gorm:"type:text;not null" json:"content"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
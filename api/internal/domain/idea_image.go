@@ -0,0 +1,12 @@
+package domain
+
+// IdeaImageVariant is one of the files stored for an uploaded idea image,
+// the Key is the storage key used to delete it when the image is replaced.
+type IdeaImageVariant struct {
+	Name   string `json:"name"`   // thumb, medium or large
+	Format string `json:"format"` // jpeg, png or webp
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+	Key    string `json:"key"`
+}
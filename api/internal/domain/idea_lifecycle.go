@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaLifecycleRules change the status of an idea without the founder, they
+// are evaluated by the lifecycle scheduler. Every rule is off by default.
+type IdeaLifecycleRules struct {
+	Base
+	IdeaID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"ideaId"`
+
+	AutoComplete       bool       `gorm:"not null;default:false" json:"autoComplete"`   // complete an active idea once its target signups are reached
+	AutoPauseAfterDays int        `gorm:"not null;default:0" json:"autoPauseAfterDays"` // pause an active idea without traffic for that many days, 0 disables it
+	AutoArchive        bool       `gorm:"not null;default:false" json:"autoArchive"`    // archive the idea once a final report is generated
+	ActivateAt         *time.Time `gorm:"index" json:"activateAt,omitempty"`            // activate a draft or paused idea at that time, cleared once done
+}
+
+// Reasons of the automated status changes, shown in the activity feed
+const (
+	LifecycleReasonTargetReached = "reached its target signups"
+	LifecycleReasonNoTraffic     = "had no traffic"
+	LifecycleReasonFinalReport   = "got its final report"
+	LifecycleReasonScheduled     = "reached its scheduled activation"
+)
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaRole is what a user can do on an idea, each role includes the ones below it
+type IdeaRole string
+
+const (
+	IdeaRoleOwner  IdeaRole = "owner"  // the creator of the idea, Idea.UserID
+	IdeaRoleEditor IdeaRole = "editor" // manages the idea and its MVPs
+	IdeaRoleViewer IdeaRole = "viewer" // sees the analytics
+)
+
+var ideaRoleRanks = map[IdeaRole]int{
+	IdeaRoleViewer: 1,
+	IdeaRoleEditor: 2,
+	IdeaRoleOwner:  3,
+}
+
+// Allows reports whether the role grants at least the required one
+func (r IdeaRole) Allows(required IdeaRole) bool {
+	rank, ok := ideaRoleRanks[r]
+	return ok && rank >= ideaRoleRanks[required]
+}
+
+// IsInvitable reports whether collaborators can be given the role, there is a
+// single owner per idea.
+func (r IdeaRole) IsInvitable() bool {
+	return r == IdeaRoleEditor || r == IdeaRoleViewer
+}
+
+// IdeaMember is a collaborator of an idea, the owner is not stored as a member.
+type IdeaMember struct {
+	Base
+	IdeaID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idea_member" json:"ideaId"`
+	UserID    string    `gorm:"not null;uniqueIndex:idx_idea_member;index" json:"userId"`
+	Role      IdeaRole  `gorm:"type:varchar(20);not null" json:"role"`
+	InvitedBy string    `json:"invitedBy"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+	Idea Idea `gorm:"foreignKey:IdeaID" json:"-"`
+}
+
+// IdeaInvitation is sent by email, the invitee becomes a member by accepting it
+// with the token before it expires.
+type IdeaInvitation struct {
+	Base
+	IdeaID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"ideaId"`
+	Email      string     `gorm:"type:varchar(255);not null;index" json:"email"`
+	Role       IdeaRole   `gorm:"type:varchar(20);not null" json:"role"`
+	Token      string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	InvitedBy  string     `gorm:"not null" json:"invitedBy"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+	AcceptedBy string     `json:"acceptedBy,omitempty"`
+
+	// Relationships
+	Idea Idea `gorm:"foreignKey:IdeaID" json:"-"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Idea fields tracked by the edit history
+const (
+	IdeaFieldTitle          = "title"
+	IdeaFieldDescription    = "description"
+	IdeaFieldTargetAudience = "targetAudience"
+	IdeaFieldStatus         = "status"
+	IdeaFieldStage          = "stage"
+	IdeaFieldTargetSignups  = "targetSignups"
+	IdeaFieldImageURL       = "imageUrl"
+	IdeaFieldIsPrivate      = "isPrivate"
+	IdeaFieldCategory       = "category"
+	IdeaFieldTags           = "tags"
+)
+
+// IdeaFieldChange is the value of a field before and after an edit, values
+// are stored as text whatever the type of the field.
+type IdeaFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// IdeaRevision is a version of an idea, one per edit changing at least one
+// field. Versions start at 1 and increase with each edit.
+type IdeaRevision struct {
+	Base
+	IdeaID  uuid.UUID                            `gorm:"type:uuid;not null;uniqueIndex:idx_idea_revision" json:"ideaId"`
+	Version int                                  `gorm:"not null;uniqueIndex:idx_idea_revision" json:"version"`
+	UserID  string                               `gorm:"index" json:"userId,omitempty"` // empty for the changes made by the system
+	Changes datatypes.JSONSlice[IdeaFieldChange] `gorm:"type:jsonb;not null" json:"changes"`
+}
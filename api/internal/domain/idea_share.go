@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaShareLink lets the holders of its signed token view a private idea and
+// its landing page until it expires or is revoked. The token is derived from
+// the link and is never stored.
+type IdeaShareLink struct {
+	Base
+	IdeaID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"ideaId"`
+	Label          string     `gorm:"type:varchar(60)" json:"label"` // who the link was given to, e.g. "Beta testers"
+	CreatedBy      string     `gorm:"not null" json:"createdBy"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	AccessCount    int64      `gorm:"not null;default:0" json:"accessCount"` // times the idea page was opened with the link
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+
+	// Relationships
+	Idea Idea `gorm:"foreignKey:IdeaID" json:"-"`
+}
+
+// IsUsable reports whether the link still grants access
+func (l *IdeaShareLink) IsUsable(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
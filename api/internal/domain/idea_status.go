@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrIllegalStatusTransition = errors.New("illegal idea status transition")
+
+// ideaStatusTransitions are the statuses an idea can move to from each status.
+// An archived idea goes back to draft first, it is activated again from there.
+var ideaStatusTransitions = map[IdeaStatus][]IdeaStatus{
+	IdeaStatusDraft:     {IdeaStatusActive, IdeaStatusArchived},
+	IdeaStatusActive:    {IdeaStatusPaused, IdeaStatusCompleted, IdeaStatusArchived},
+	IdeaStatusPaused:    {IdeaStatusActive, IdeaStatusCompleted, IdeaStatusArchived},
+	IdeaStatusCompleted: {IdeaStatusActive, IdeaStatusArchived},
+	IdeaStatusArchived:  {IdeaStatusDraft},
+}
+
+// IdeaStatusTransitionError is returned for a move the transition graph does not allow
+type IdeaStatusTransitionError struct {
+	From IdeaStatus
+	To   IdeaStatus
+}
+
+func (e *IdeaStatusTransitionError) Error() string {
+	return fmt.Sprintf("an idea cannot go from %s to %s", e.From, e.To)
+}
+
+func (e *IdeaStatusTransitionError) Unwrap() error {
+	return ErrIllegalStatusTransition
+}
+
+func (s IdeaStatus) IsValid() bool {
+	_, ok := ideaStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo checks the move against the transition graph, staying in
+// the same status is always allowed.
+func (s IdeaStatus) CanTransitionTo(to IdeaStatus) error {
+	if s == to && s.IsValid() {
+		return nil
+	}
+
+	for _, next := range ideaStatusTransitions[s] {
+		if next == to {
+			return nil
+		}
+	}
+	return &IdeaStatusTransitionError{From: s, To: to}
+}
@@ -28,6 +28,7 @@ type Report struct {
 	EngagementRate float64    `gorm:"not null;default:0" json:"engagementRate"`
 	Validated      bool       `gorm:"not null;default:false;index" json:"validated"`
 	Sentiment      float64    `gorm:"not null;default:0" json:"sentiment"`
+	SearchRank     float64    `gorm:"->;-:migration" json:"-"` // only selected by searches
 
 	// Relationships
 	Idea Idea `gorm:"foreignKey:IdeaID;references:ID" json:"idea,omitempty"`
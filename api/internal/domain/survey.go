@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// SurveyAnswer is the answer of an audience member to a single question of the
+// post-signup survey, answering again replaces the previous answer.
+type SurveyAnswer struct {
+	Base
+	IdeaID         uuid.UUID `gorm:"type:uuid;not null;index" json:"ideaId"`
+	MVPSimulatorID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_survey_answer" json:"mvpSimulatorId"`
+	AudienceUserID string    `gorm:"not null;uniqueIndex:idx_survey_answer" json:"audienceUserId"` // user id of the AudienceMember
+	QuestionID     string    `gorm:"type:varchar(40);not null;uniqueIndex:idx_survey_answer" json:"questionId"`
+	Choice         string    `gorm:"type:varchar(120)" json:"choice,omitempty"`
+	Text           string    `gorm:"type:text" json:"text,omitempty"`
+}
@@ -28,10 +28,31 @@ type QueryParams struct {
 	FilterBy string
 	Search   string
 
+	SearchMode string // SearchModeKeyword or SearchModeHybrid
+
+	// Taxonomy filters
+	Category string   // category slug, includes its subcategories
+	Tags     []string // ideas must have all of them
+	Stage    string
+
+	// Cursor continues a listing after the last item of the previous page,
+	// Offset is ignored when it is set
+	Cursor *Cursor
+
+	// WorkspaceID is the workspace selected in the dashboard switcher, the
+	// dashboard lists the personal ideas of the user when it is nil
+	WorkspaceID *uuid.UUID
+
 	LastCreatedAt time.Time
 	LastId        uuid.UUID
 }
 
+// Search modes of the idea listings
+const (
+	SearchModeKeyword = "keyword"
+	SearchModeHybrid  = "hybrid"
+)
+
 type EventType string
 
 // Event type constants for tracking user interactions
@@ -40,6 +61,8 @@ const (
 	EventTypeClick      EventType = "cta_click"
 	EventTypeScroll     EventType = "scroll_depth"
 	EventTypeTimeOnPage EventType = "time_on_page"
+	EventTypePricing    EventType = "pricing_click"
+	EventTypeSurvey     EventType = "survey_response"
 )
 
 type IdeaStatus string
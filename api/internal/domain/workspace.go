@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceRole is what a member can do in a workspace, each role includes the ones below it
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"  // the creator, manages the subscription
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"  // manages the members and every idea
+	WorkspaceRoleMember WorkspaceRole = "member" // works on the ideas of the workspace
+)
+
+var workspaceRoleRanks = map[WorkspaceRole]int{
+	WorkspaceRoleMember: 1,
+	WorkspaceRoleAdmin:  2,
+	WorkspaceRoleOwner:  3,
+}
+
+// Allows reports whether the role grants at least the required one
+func (r WorkspaceRole) Allows(required WorkspaceRole) bool {
+	rank, ok := workspaceRoleRanks[r]
+	return ok && rank >= workspaceRoleRanks[required]
+}
+
+// IsAssignable reports whether members can be given the role, there is a
+// single owner per workspace.
+func (r WorkspaceRole) IsAssignable() bool {
+	return r == WorkspaceRoleAdmin || r == WorkspaceRoleMember
+}
+
+// IdeaRole is the role the member has on the ideas of the workspace, admins
+// manage them like their owner.
+func (r WorkspaceRole) IdeaRole() IdeaRole {
+	switch r {
+	case WorkspaceRoleOwner, WorkspaceRoleAdmin:
+		return IdeaRoleOwner
+	case WorkspaceRoleMember:
+		return IdeaRoleEditor
+	default:
+		return ""
+	}
+}
+
+// Workspace owns ideas and a subscription shared by its members, the plan
+// limits apply to the workspace as a whole instead of each member.
+type Workspace struct {
+	Base
+	Name    string   `gorm:"type:varchar(255);not null" json:"name"`
+	OwnerID string   `gorm:"not null;index" json:"ownerId"`
+	Plan    UserPlan `gorm:"default:'starter'" json:"plan"`
+
+	IsPaying bool `gorm:"default:false" json:"isPaying"` // Derived from active subscription
+
+	// paddle / subscription specific fields, same as on User
+	PaddleSubscriptionID    *string    `gorm:"type:varchar(255);index" json:"paddleSubscriptionId,omitempty"`
+	PaddleCustomerID        *string    `gorm:"type:varchar(255);index" json:"paddleCustomerId,omitempty"`
+	PaddlePlanID            *string    `gorm:"type:varchar(255)" json:"paddlePlanId,omitempty"`
+	SubscriptionStatus      string     `gorm:"type:varchar(50)" json:"subscriptionStatus,omitempty"`
+	SubscriptionExpiresAt   *time.Time `json:"subscriptionExpiresAt,omitempty"`
+	SubscriptionCancelledAt *time.Time `json:"subscriptionCancelledAt,omitempty"`
+	SubscriptionPausedAt    *time.Time `json:"subscriptionPausedAt,omitempty"`
+
+	// Relationships
+	Owner   User              `gorm:"foreignKey:OwnerID" json:"-"`
+	Members []WorkspaceMember `gorm:"foreignKey:WorkspaceID" json:"members,omitempty"`
+}
+
+// Limits are the plan limits of the workspace
+func (w *Workspace) Limits() PlanDetails {
+	return GetPlanDetails(w.Plan)
+}
+
+// HasActiveSubscription reports whether a subscription that was not canceled
+// is attached to the workspace.
+func (w *Workspace) HasActiveSubscription() bool {
+	return w.PaddleSubscriptionID != nil && w.SubscriptionStatus != "" && w.SubscriptionStatus != "canceled"
+}
+
+// WorkspaceMember is a user of a workspace, the owner is stored as a member too.
+type WorkspaceMember struct {
+	Base
+	WorkspaceID uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_workspace_member" json:"workspaceId"`
+	UserID      string        `gorm:"not null;uniqueIndex:idx_workspace_member;index" json:"userId"`
+	Role        WorkspaceRole `gorm:"type:varchar(20);not null" json:"role"`
+
+	// Relationships
+	User      User      `gorm:"foreignKey:UserID" json:"-"`
+	Workspace Workspace `gorm:"foreignKey:WorkspaceID" json:"-"`
+}
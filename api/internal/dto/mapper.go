@@ -42,6 +42,9 @@ func ToIdeasListResponse(ideas []*domain.Idea, count int64, stats *response.User
 			CreatedAt:          idea.CreatedAt.Format("2006-01-02"),
 			UpdatedAt:          idea.UpdatedAt.Format("2006-01-02"),
 			RedditValidationID: idea.RedditValidationID,
+			Category:           toCategoryRef(idea.Category),
+			Tags:               ideaTags(idea),
+			ImageVariants:      ToImageVariants(idea.ImageVariants),
 		})
 	}
 
@@ -101,6 +104,9 @@ func ToPublicIdea(idea *domain.Idea, relatedIdeas []*domain.Idea, requestingUser
 		DislikedByUser:     dislikedByUser,
 		Stats:              calculateIdeaStats(idea.Signals, idea.Signups),
 		FeedbackHighlights: feedbackHighlights,
+		Category:           toCategoryRef(idea.Category),
+		Tags:               ideaTags(idea),
+		ImageVariants:      ToImageVariants(idea.ImageVariants),
 	}
 
 	if idea.User.ID != "" {
@@ -463,3 +469,36 @@ func getReportInsights(report *domain.Report, conversionRate float64, thresholds
 
 	return insights
 }
+
+func toCategoryRef(category *domain.Category) *response.CategoryRef {
+	if category == nil {
+		return nil
+	}
+	return &response.CategoryRef{Name: category.Name, Slug: category.Slug}
+}
+
+// ToImageVariants leaves out the storage keys of the files
+func ToImageVariants(variants []domain.IdeaImageVariant) []response.ImageVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	res := make([]response.ImageVariant, 0, len(variants))
+	for _, variant := range variants {
+		res = append(res, response.ImageVariant{
+			Name:   variant.Name,
+			Format: variant.Format,
+			Width:  variant.Width,
+			Height: variant.Height,
+			URL:    variant.URL,
+		})
+	}
+	return res
+}
+
+func ideaTags(idea *domain.Idea) []string {
+	if idea.Tags == nil {
+		return []string{}
+	}
+	return idea.Tags
+}
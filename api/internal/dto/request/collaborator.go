@@ -0,0 +1,10 @@
+package request
+
+type InviteCollaborator struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+	Role  string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type UpdateCollaboratorRole struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
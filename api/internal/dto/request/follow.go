@@ -0,0 +1,9 @@
+package request
+
+type FollowIdea struct {
+	EmailDigest *bool `json:"emailDigest"` // true when omitted
+}
+
+type CreateIdeaUpdate struct {
+	Content string `json:"content" binding:"required,min=3,max=500"`
+}
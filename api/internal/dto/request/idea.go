@@ -1,5 +1,11 @@
 package request
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type CreateIdea struct {
 	Title          string `json:"title" binding:"required,min=6"`
 	Description    string `json:"description" binding:"required,min=30"`
@@ -7,6 +13,11 @@ type CreateIdea struct {
 	CTAButton      string `json:"ctaButtonText"`
 	ForceNew       bool   `json:"forceNew"`
 	HTMLURL        string `json:"htmlUrl"` // URL to the r2 hosted HTML content
+
+	Category string   `json:"category"` // category slug, suggested by AI when empty
+	Tags     []string `json:"tags" binding:"omitempty,max=8,dive,max=30"`
+
+	WorkspaceID *uuid.UUID `json:"workspaceId"` // the current workspace when omitted, billed to it
 }
 
 type UpdateIdea struct {
@@ -18,6 +29,17 @@ type UpdateIdea struct {
 	TargetAudience *string `json:"targetAudience" binding:"omitempty,min=8"`
 	TargetSignups  *int    `json:"targetSignups" binding:"omitempty,min=1"`
 	ImageURL       *string `json:"imageUrl"`
+
+	Category *string   `json:"category"` // category slug, empty to clear
+	Tags     *[]string `json:"tags" binding:"omitempty,max=8,dive,max=30"`
+}
+
+// UpdateLifecycleRules replaces the lifecycle rules of an idea
+type UpdateLifecycleRules struct {
+	AutoComplete       bool       `json:"autoComplete"`
+	AutoPauseAfterDays int        `json:"autoPauseAfterDays" binding:"min=0,max=365"`
+	AutoArchive        bool       `json:"autoArchive"`
+	ActivateAt         *time.Time `json:"activateAt"` // in the future, null to cancel the scheduled activation
 }
 
 type CreateMVP struct {
@@ -26,8 +48,67 @@ type CreateMVP struct {
 	IsActive bool   `json:"isActive"`
 }
 
+// ImportMVP is sent as multipart form data next to the uploaded HTML file or ZIP bundle.
+type ImportMVP struct {
+	Name            string `form:"name" binding:"max=100"`
+	IsActive        bool   `form:"isActive"`
+	MetaTitle       string `form:"metaTitle" binding:"max=120"`
+	MetaDescription string `form:"metaDescription" binding:"max=300"`
+}
+
+// ImportIdeas is sent as multipart form data next to the JSON or CSV file of ideas.
+type ImportIdeas struct {
+	WithMVPs bool `form:"withMvps"` // give each imported idea its initial MVP
+}
+
 type UpdateMVP struct {
 	Name     *string `json:"name"`
 	IsActive *bool   `json:"isActive"`
 	HTMLURL  *string `json:"htmlUrl"` // URL to the r2 hosted HTML content
 }
+
+type AuditLandingPage struct {
+	HTMLContent     string `json:"htmlContent" binding:"required"`
+	MetaTitle       string `json:"metaTitle"`
+	MetaDescription string `json:"metaDescription"`
+}
+
+type PricingTier struct {
+	ID            string   `json:"id" binding:"omitempty,max=40"`
+	Name          string   `json:"name" binding:"required,max=40"`
+	Price         *float64 `json:"price" binding:"required,min=0"`
+	Currency      string   `json:"currency" binding:"required,len=3"`
+	BillingPeriod string   `json:"billingPeriod" binding:"required,oneof=month year once"`
+	Features      []string `json:"features" binding:"omitempty,max=10,dive,max=120"`
+	Highlighted   bool     `json:"highlighted"`
+}
+
+type UpdatePricingTest struct {
+	Enabled    bool          `json:"enabled"`
+	Heading    string        `json:"heading" binding:"max=80"`
+	Subheading string        `json:"subheading" binding:"max=160"`
+	Tiers      []PricingTier `json:"tiers" binding:"omitempty,max=4,dive"`
+}
+
+type SurveyQuestion struct {
+	ID       string   `json:"id" binding:"omitempty,max=40"`
+	Prompt   string   `json:"prompt" binding:"required,max=200"`
+	Type     string   `json:"type" binding:"required,oneof=choice text"`
+	Options  []string `json:"options" binding:"omitempty,max=6,dive,required,max=120"`
+	Required bool     `json:"required"`
+}
+
+type UpdateSurvey struct {
+	Enabled   bool             `json:"enabled"`
+	Title     string           `json:"title" binding:"max=120"`
+	Questions []SurveyQuestion `json:"questions" binding:"omitempty,max=3,dive"`
+}
+
+type CreateLocaleVariant struct {
+	Locale string `json:"locale" binding:"required,max=35"`
+}
+
+type CreateShareLink struct {
+	Label         string `json:"label" binding:"max=60"`
+	ExpiresInDays int    `json:"expiresInDays" binding:"required,min=1,max=90"`
+}
@@ -0,0 +1,14 @@
+package request
+
+type UpdateProfile struct {
+	Bio       string        `json:"bio" binding:"max=500"`
+	Links     []ProfileLink `json:"links" binding:"omitempty,max=5,dive"`
+	IsPublic  bool          `json:"isPublic"`
+	ShowIdeas bool          `json:"showIdeas"`
+	ShowStats bool          `json:"showStats"`
+}
+
+type ProfileLink struct {
+	Label string `json:"label" binding:"required,max=40"`
+	URL   string `json:"url" binding:"required,http_url,max=300"`
+}
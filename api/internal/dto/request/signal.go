@@ -1,6 +1,6 @@
 package request
 
 type RecordSignalRequest struct {
-	EventType string                 `json:"eventType" binding:"required,oneof=pageview cta_click scroll_depth time_on_page"`
+	EventType string                 `json:"eventType" binding:"required,oneof=pageview cta_click scroll_depth time_on_page pricing_click survey_response"`
 	Metadata  map[string]interface{} `json:"metadata"`
 }
@@ -0,0 +1,27 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateWorkspace struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+type UpdateWorkspace struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+// AddWorkspaceMember adds an existing user by the email of their account
+type AddWorkspaceMember struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+	Role  string `json:"role" binding:"required,oneof=admin member"`
+}
+
+type UpdateWorkspaceMemberRole struct {
+	Role string `json:"role" binding:"required,oneof=admin member"`
+}
+
+// MoveIdea moves an idea into a workspace, or back to the personal ideas of
+// its owner when WorkspaceID is nil.
+type MoveIdea struct {
+	WorkspaceID *uuid.UUID `json:"workspaceId"`
+}
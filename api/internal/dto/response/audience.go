@@ -7,6 +7,8 @@ type AudienceResponse struct {
 	Stats     *AudienceStats    `json:"stats"`
 	Total     int64             `json:"total"`
 	Metrics   []AudienceMetrics `json:"metrics"`
+
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type Audience struct {
@@ -30,3 +32,29 @@ type AudienceMetrics struct {
 	IdeaTitle string `json:"name"`
 	Count     int64  `json:"value"`
 }
+
+// WaitlistPosition is shown to a member after signing up and on the public position lookup.
+type WaitlistPosition struct {
+	IdeaID        uuid.UUID `json:"ideaId"`
+	ReferralCode  string    `json:"referralCode"`
+	ReferralLink  string    `json:"referralLink"`
+	Position      int       `json:"position"`
+	WaitlistSize  int64     `json:"waitlistSize"`
+	ReferralCount int       `json:"referralCount"`
+}
+
+type WaitlistLeaderboard struct {
+	IdeaID        uuid.UUID                  `json:"ideaId"`
+	WaitlistSize  int64                      `json:"waitlistSize"`
+	PositionBoost int                        `json:"positionBoost"`
+	Entries       []WaitlistLeaderboardEntry `json:"entries"`
+}
+
+type WaitlistLeaderboardEntry struct {
+	UserID        string `json:"userId"`
+	UserEmail     string `json:"email"`
+	ReferralCode  string `json:"referralCode"`
+	ReferralCount int    `json:"referralCount"`
+	Position      int    `json:"position"`
+	SignupTime    string `json:"signupTime"`
+}
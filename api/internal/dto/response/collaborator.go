@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaCollaborators lists who has access to an idea, pending invitations are
+// only shown to the owner.
+type IdeaCollaborators struct {
+	Role        string           `json:"role"` // role of the requesting user
+	Owner       Collaborator     `json:"owner"`
+	Members     []Collaborator   `json:"members"`
+	Invitations []IdeaInvitation `json:"invitations,omitempty"`
+}
+
+type Collaborator struct {
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	ImageURL  string    `json:"imageUrl,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type IdeaInvitation struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AcceptedInvitation tells the new collaborator where the invitation led them
+type AcceptedInvitation struct {
+	IdeaID    uuid.UUID `json:"ideaId"`
+	IdeaTitle string    `json:"ideaTitle"`
+	Role      string    `json:"role"`
+}
@@ -32,10 +32,11 @@ type Metrics struct {
 
 // AnalyticsData contains time-series data for idea analytics
 type AnalyticsData struct {
-	IdeaID     uuid.UUID   `json:"ideaId"`
-	IdeaTitle  string      `json:"ideaTitle"`
-	DataPoints []DataPoint `json:"dataPoints"`
-	Totals     Totals      `json:"totals"`
+	IdeaID     uuid.UUID      `json:"ideaId"`
+	IdeaTitle  string         `json:"ideaTitle"`
+	DataPoints []DataPoint    `json:"dataPoints"`
+	Totals     Totals         `json:"totals"`
+	Markers    []ChangeMarker `json:"markers"` // edits of the idea in the range
 }
 
 // DataPoint represents a single point in the analytics time series
@@ -69,3 +70,25 @@ type IdeaWithActivity struct {
 	LatestSignupDate *time.Time `gorm:"column:latest_signup"`
 	LatestViewDate   *time.Time `gorm:"column:latest_view"`
 }
+
+// ChangeMarker marks the day an idea was edited on its analytics time series
+type ChangeMarker struct {
+	Date    string   `json:"date"` // same format as the data points
+	Version int      `json:"version"`
+	Fields  []string `json:"fields"`
+}
+
+// IdeaHistory lists the revisions of an idea, newest first
+type IdeaHistory struct {
+	Revisions []IdeaRevision `json:"revisions"`
+	Total     int64          `json:"total"`
+
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type IdeaRevision struct {
+	Version   int                      `json:"version"`
+	UserID    string                   `json:"userId,omitempty"` // empty for the changes made by the system
+	Changes   []domain.IdeaFieldChange `json:"changes"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
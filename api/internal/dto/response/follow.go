@@ -0,0 +1,27 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FollowStatus struct {
+	Following   bool  `json:"following"`
+	EmailDigest bool  `json:"emailDigest"`
+	Followers   int64 `json:"followers"`
+}
+
+type IdeaUpdates struct {
+	Updates []IdeaUpdate `json:"updates"`
+	Total   int64        `json:"total"`
+
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type IdeaUpdate struct {
+	ID         uuid.UUID `json:"id"`
+	Content    string    `json:"content"`
+	AuthorName string    `json:"authorName"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
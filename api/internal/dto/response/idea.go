@@ -5,9 +5,12 @@ import (
 )
 
 type IdeaListResponse struct {
-	Ideas []IdeaList         `json:"ideas"`
-	Total int64              `json:"total"`
-	Stats UserDashboardStats `json:"stats,omitempty"`
+	Ideas  []IdeaList         `json:"ideas"`
+	Total  int64              `json:"total"`
+	Stats  UserDashboardStats `json:"stats,omitempty"`
+	Facets *IdeaFacets        `json:"facets,omitempty"`
+
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type PublicIdeaResponse struct {
@@ -16,21 +19,25 @@ type PublicIdeaResponse struct {
 }
 
 type IdeaList struct {
-	ID                 uuid.UUID `json:"id"`
-	Title              string    `json:"title"`
-	Description        string    `json:"description"`
-	TargetAudience     string    `json:"targetAudience"`
-	IsPrivate          bool      `json:"isPrivate"`
-	CreatedAt          string    `json:"createdAt"`
-	UpdatedAt          string    `json:"updatedAt"`
-	Views              int       `json:"views"`
-	Signups            int       `json:"signups"`
-	EngagementRate     float64   `json:"engagementRate"`
-	Status             string    `json:"status"`
-	Stage              string    `json:"stage"`
-	TargetSignups      int       `json:"targetSignups"`
-	ImageURL           string    `json:"imageUrl"`
-	RedditValidationID uuid.UUID `json:"redditValidationId,omitempty"` // Optional validation analysis from Reddit
+	ID                 uuid.UUID    `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description"`
+	TargetAudience     string       `json:"targetAudience"`
+	IsPrivate          bool         `json:"isPrivate"`
+	CreatedAt          string       `json:"createdAt"`
+	UpdatedAt          string       `json:"updatedAt"`
+	Views              int          `json:"views"`
+	Signups            int          `json:"signups"`
+	EngagementRate     float64      `json:"engagementRate"`
+	Status             string       `json:"status"`
+	Stage              string       `json:"stage"`
+	TargetSignups      int          `json:"targetSignups"`
+	ImageURL           string       `json:"imageUrl"`
+	RedditValidationID uuid.UUID    `json:"redditValidationId,omitempty"` // Optional validation analysis from Reddit
+	Category           *CategoryRef `json:"category"`
+	Tags               []string     `json:"tags"`
+
+	ImageVariants []ImageVariant `json:"imageVariants,omitempty"` // the files of an uploaded image, for responsive images
 }
 
 type PublicIdea struct {
@@ -52,6 +59,10 @@ type PublicIdea struct {
 	DislikedByUser     bool            `json:"dislikedByUser"`
 	Stats              PublicIdeaStats `json:"stats"`
 	FeedbackHighlights []string        `json:"feedbackHighlights"`
+	Category           *CategoryRef    `json:"category"`
+	Tags               []string        `json:"tags"`
+
+	ImageVariants []ImageVariant `json:"imageVariants,omitempty"`
 }
 
 type IdeaFounder struct {
@@ -60,8 +71,9 @@ type IdeaFounder struct {
 }
 
 type IdeaCommentResponse struct {
-	Comments []IdeaComment `json:"comments"`
-	Total    int64         `json:"total"`
+	Comments   []IdeaComment `json:"comments"`
+	Total      int64         `json:"total"`
+	NextCursor string        `json:"nextCursor,omitempty"`
 }
 
 type IdeaComment struct {
@@ -97,6 +109,19 @@ type RelatedIdea struct {
 	ImageUrl       string    `json:"imageUrl"`
 }
 
+type IdeaCreated struct {
+	ID           uuid.UUID     `json:"id"`
+	MVPID        uuid.UUID     `json:"mvpId"`
+	SimilarIdeas []SimilarIdea `json:"similarIdeas"`
+}
+
+// SimilarIdea is an existing public idea close to a newly created one.
+type SimilarIdea struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Similarity float64   `json:"similarity"` // cosine similarity, 1 means identical
+}
+
 type UserDashboardStats struct {
 	ActiveIdeas  int64 `json:"activeIdeas"`
 	TotalSignups int64 `json:"totalSignups"` // total signups across all ideas for this month
@@ -108,3 +133,28 @@ type UserDashboardStats struct {
 	SignupsChange     float64 `json:"signupsChange,omitempty"`
 	ViewsChange       float64 `json:"viewsChange,omitempty"`
 }
+
+type Category struct {
+	ID       uuid.UUID  `json:"id"`
+	Name     string     `json:"name"`
+	Slug     string     `json:"slug"`
+	Children []Category `json:"children"`
+}
+
+type CategoryRef struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// IdeaFacets are the idea counts per filter value of the current listing
+type IdeaFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Tags       []FacetCount `json:"tags"`
+	Stages     []FacetCount `json:"stages"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaComparison puts ideas side by side over the same number of days after
+// their launch, so that an idea launched last week is not measured against
+// the lifetime of an older one.
+type IdeaComparison struct {
+	Days  int            `json:"days"`
+	Ideas []ComparedIdea `json:"ideas"`
+}
+
+type ComparedIdea struct {
+	IdeaID     uuid.UUID         `json:"ideaId"`
+	Title      string            `json:"title"`
+	Status     string            `json:"status"`
+	LaunchedAt time.Time         `json:"launchedAt"`
+	Metrics    ComparisonMetrics `json:"metrics"`
+	Normalized ComparisonMetrics `json:"normalized"` // 0-100, relative to the best of the compared ideas
+	Score      float64           `json:"score"`      // average of the normalized metrics
+	Series     []ComparisonPoint `json:"series"`
+}
+
+// ComparisonMetrics are the totals over the window. Sentiment is nil without
+// feedback and the Reddit score without a completed validation.
+type ComparisonMetrics struct {
+	Views           float64  `json:"views"`
+	Signups         float64  `json:"signups"`
+	ConversionRate  float64  `json:"conversionRate"`
+	EngagementRate  float64  `json:"engagementRate"`
+	Sentiment       *float64 `json:"sentiment"`
+	ValidationScore *float64 `json:"validationScore"`
+}
+
+// ComparisonPoint is a day of the series, Day 0 being the day of the launch
+type ComparisonPoint struct {
+	Day            int     `json:"day"`
+	Date           string  `json:"date"`
+	Views          int     `json:"views"`
+	Signups        int     `json:"signups"`
+	ConversionRate float64 `json:"conversionRate"`
+}
@@ -0,0 +1,15 @@
+package response
+
+// IdeaImage is the uploaded image of an idea, ImageURL is its large variant
+type IdeaImage struct {
+	ImageURL string         `json:"imageUrl"`
+	Variants []ImageVariant `json:"variants"`
+}
+
+type ImageVariant struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
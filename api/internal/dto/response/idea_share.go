@@ -0,0 +1,19 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShareLink struct {
+	ID             uuid.UUID  `json:"id"`
+	Label          string     `json:"label"`
+	URL            string     `json:"url"`
+	Active         bool       `json:"active"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	AccessCount    int64      `json:"accessCount"`
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
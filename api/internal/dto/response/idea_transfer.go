@@ -0,0 +1,84 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdeaImport is the outcome of an import, the rows are numbered from 1 in the
+// order of the file, the header of a CSV file not counted.
+type IdeaImport struct {
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Failed  int              `json:"failed"`
+	Ideas   []ImportedIdea   `json:"ideas"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+type ImportedIdea struct {
+	Row      int        `json:"row"`
+	ID       uuid.UUID  `json:"id"`
+	MVPID    *uuid.UUID `json:"mvpId,omitempty"`
+	Title    string     `json:"title"`
+	Status   string     `json:"status"`
+	Restored bool       `json:"restored"` // a deleted idea with the same title was brought back
+}
+
+// ImportRowError holds the errors of a row by field, "general" for the ones
+// that are not about a field.
+type ImportRowError struct {
+	Row    int               `json:"row"`
+	Title  string            `json:"title"`
+	Errors map[string]string `json:"errors"`
+}
+
+// IdeaExport is a full export of ideas, the ideas can be imported back from it
+type IdeaExport struct {
+	ExportedAt time.Time      `json:"exportedAt"`
+	Ideas      []ExportedIdea `json:"ideas"`
+}
+
+type ExportedIdea struct {
+	ID             uuid.UUID        `json:"id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	TargetAudience string           `json:"targetAudience"`
+	Category       string           `json:"category"` // slug
+	Tags           []string         `json:"tags"`
+	Slug           string           `json:"slug"`
+	Status         string           `json:"status"`
+	Stage          string           `json:"stage"`
+	IsPrivate      bool             `json:"isPrivate"`
+	TargetSignups  int              `json:"targetSignups"`
+	ImageURL       string           `json:"imageUrl"`
+	Views          int              `json:"views"`
+	Signups        int              `json:"signups"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+	MVPs           []ExportedMVP    `json:"mvps"`
+	Reports        []ExportedReport `json:"reports"`
+}
+
+type ExportedMVP struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	IsActive      bool      `json:"isActive"`
+	HTMLURL       string    `json:"htmlUrl"`
+	Locale        string    `json:"locale"`
+	AIGenerations int       `json:"aiGenerations"`
+	ShareImageURL string    `json:"shareImageUrl"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type ExportedReport struct {
+	ID             uuid.UUID `json:"id"`
+	Type           string    `json:"type"`
+	Date           time.Time `json:"date"`
+	Views          int64     `json:"views"`
+	Signups        int64     `json:"signups"`
+	EngagementRate float64   `json:"engagementRate"`
+	Sentiment      float64   `json:"sentiment"`
+	Validated      bool      `json:"validated"`
+}
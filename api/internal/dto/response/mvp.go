@@ -0,0 +1,98 @@
+package response
+
+import (
+	"foundersignal/internal/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+type LandingPageAudit struct {
+	Quality      *validation.AuditReport        `json:"quality"`
+	Sanitization *validation.SanitizationReport `json:"sanitization"`
+}
+
+// ImportedMVP summarizes an uploaded landing page bundle.
+type ImportedMVP struct {
+	MVPId         string            `json:"mvpId"`
+	HTMLURL       string            `json:"htmlUrl"`
+	EntryFile     string            `json:"entryFile"`
+	AssetCount    int               `json:"assetCount"`
+	RewrittenURLs int               `json:"rewrittenUrls"`
+	SkippedFiles  []string          `json:"skippedFiles"`
+	Audit         *LandingPageAudit `json:"audit"`
+}
+
+// PricingAnalytics shows how visitors engage with the fake-door pricing block
+// next to the regular CTA conversion of the same MVP.
+type PricingAnalytics struct {
+	MVPID                   uuid.UUID          `json:"mvpId"`
+	Enabled                 bool               `json:"enabled"`
+	Views                   int                `json:"views"`
+	UniqueVisitors          int                `json:"uniqueVisitors"`
+	Signups                 int64              `json:"signups"`
+	CTAConversionRate       float64            `json:"ctaConversionRate"`
+	PricingClicks           int                `json:"pricingClicks"`
+	PricingClickThroughRate float64            `json:"pricingClickThroughRate"`
+	Tiers                   []PricingTierStats `json:"tiers"`
+	PricePoints             []PricePointStats  `json:"pricePoints"`
+}
+
+type PricingTierStats struct {
+	TierID           string  `json:"tierId"`
+	Name             string  `json:"name"`
+	Clicks           int     `json:"clicks"`
+	UniqueClickers   int     `json:"uniqueClickers"`
+	ClickThroughRate float64 `json:"clickThroughRate"`
+}
+
+type PricePointStats struct {
+	Label            string  `json:"label"`
+	Price            float64 `json:"price"`
+	Currency         string  `json:"currency"`
+	BillingPeriod    string  `json:"billingPeriod"`
+	Clicks           int     `json:"clicks"`
+	UniqueClickers   int     `json:"uniqueClickers"`
+	ClickThroughRate float64 `json:"clickThroughRate"`
+}
+
+type LocaleAnalytics struct {
+	Locale         string  `json:"locale"`
+	IsOriginal     bool    `json:"isOriginal"`
+	Views          int     `json:"views"`
+	UniqueVisitors int     `json:"uniqueVisitors"`
+	Signups        int     `json:"signups"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+// SurveyResults aggregates the post-signup survey answers of an MVP.
+type SurveyResults struct {
+	MVPID        uuid.UUID               `json:"mvpId"`
+	Enabled      bool                    `json:"enabled"`
+	Signups      int64                   `json:"signups"`
+	Respondents  int                     `json:"respondents"`
+	ResponseRate float64                 `json:"responseRate"`
+	Questions    []SurveyQuestionResults `json:"questions"`
+}
+
+type SurveyQuestionResults struct {
+	ID            string              `json:"id"`
+	Prompt        string              `json:"prompt"`
+	Type          string              `json:"type"`
+	Answers       int                 `json:"answers"`
+	Distribution  []SurveyChoiceStats `json:"distribution,omitempty"`  // choice questions
+	Themes        []SurveyTheme       `json:"themes,omitempty"`        // free text questions, clustered by AI
+	ThemesPending bool                `json:"themesPending,omitempty"` // the themes are being clustered again for new answers
+	RecentAnswers []string            `json:"recentAnswers,omitempty"` // free text questions
+}
+
+type SurveyChoiceStats struct {
+	Choice     string  `json:"choice"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+type SurveyTheme struct {
+	Theme    string   `json:"theme"`
+	Count    int      `json:"count"`
+	Examples []string `json:"examples"`
+}
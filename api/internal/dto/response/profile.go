@@ -0,0 +1,24 @@
+package response
+
+import (
+	"foundersignal/internal/domain"
+	"time"
+)
+
+// FounderProfile is the public page of a founder, the reputation and the
+// ideas are left out when the founder hides them.
+type FounderProfile struct {
+	Username   string               `json:"username"`
+	Name       string               `json:"name"`
+	ImageURL   string               `json:"imageUrl"`
+	Bio        string               `json:"bio"`
+	Links      []domain.ProfileLink `json:"links"`
+	JoinedAt   time.Time            `json:"joinedAt"`
+	Reputation *FounderReputation   `json:"reputation,omitempty"`
+	Ideas      *IdeaListResponse    `json:"ideas,omitempty"`
+}
+
+type FounderReputation struct {
+	IdeasLaunched int64 `json:"ideasLaunched"`
+	TotalSignups  int64 `json:"totalSignups"`
+}
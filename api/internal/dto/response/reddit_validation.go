@@ -31,4 +31,5 @@ type RedditValidationResponse struct {
 type RedditValidationListResponse struct {
 	Validations []RedditValidationResponse `json:"validations"`
 	Total       int64                      `json:"total"`
+	NextCursor  string                     `json:"nextCursor,omitempty"`
 }
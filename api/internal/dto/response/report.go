@@ -14,6 +14,7 @@ type ReportListResponse struct {
 	SuccessData    []NameValueData         `json:"successData"`
 	RecentInsights []ReportResponse        `json:"recentInsights"`
 	Total          int64                   `json:"total"`
+	NextCursor     string                  `json:"nextCursor,omitempty"`
 }
 
 type ReportPageResponse struct {
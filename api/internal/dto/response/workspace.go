@@ -0,0 +1,42 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Workspace is listed in the workspace switcher with the role of the user
+type Workspace struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	Role      string         `json:"role"`
+	Plan      string         `json:"plan"`
+	IsPaying  bool           `json:"isPaying"`
+	Usage     WorkspaceUsage `json:"usage"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// WorkspaceUsage is the usage of the plan limits shared by the members
+type WorkspaceUsage struct {
+	ActiveIdeas int64 `json:"activeIdeas"`
+	IdeaLimit   int   `json:"ideaLimit"`
+	MVPLimit    int   `json:"mvpLimit"`   // per idea
+	AIGenLimit  int   `json:"aiGenLimit"` // per MVP
+}
+
+type WorkspaceDetails struct {
+	Workspace
+	SubscriptionStatus    string            `json:"subscriptionStatus,omitempty"`
+	SubscriptionExpiresAt *time.Time        `json:"subscriptionExpiresAt,omitempty"`
+	Members               []WorkspaceMember `json:"members"`
+}
+
+type WorkspaceMember struct {
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	ImageURL  string    `json:"imageUrl,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,201 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Limits applied to uploaded bundles, they keep a malicious archive from
+// exhausting memory or storage.
+const (
+	MaxUploadBytes       = 10 << 20
+	maxUncompressedBytes = 50 << 20
+	maxFiles             = 200
+)
+
+var (
+	ErrUnsupportedFile = errors.New("unsupported file, upload a single .html file or a .zip bundle")
+	ErrNoHTMLEntry     = errors.New("the bundle does not contain an index.html or any other .html file")
+	ErrBundleTooLarge  = errors.New("the bundle exceeds the allowed size")
+	ErrInvalidPath     = errors.New("the bundle contains an invalid file path")
+)
+
+// assetContentTypes lists the asset types that are extracted from a bundle,
+// scripts are left out on purpose since the sanitizer strips them anyway. SVG
+// files are left out too, they can carry scripts and are served from the same
+// origin as the landing pages.
+var assetContentTypes = map[string]string{
+	".css":   "text/css",
+	".png":   "image/png",
+	".jpg":   "image/jpeg",
+	".jpeg":  "image/jpeg",
+	".gif":   "image/gif",
+	".webp":  "image/webp",
+	".ico":   "image/x-icon",
+	".avif":  "image/avif",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ttf":   "font/ttf",
+	".otf":   "font/otf",
+	".mp4":   "video/mp4",
+	".webm":  "video/webm",
+}
+
+// Bundle is an uploaded landing page with its assets keyed by their clean path inside the archive.
+type Bundle struct {
+	EntryPath string
+	HTML      string
+	Assets    map[string][]byte
+	Skipped   []string // files that were ignored because of their type
+}
+
+// ContentType returns the content type of an asset path.
+func ContentType(assetPath string) string {
+	return assetContentTypes[strings.ToLower(path.Ext(assetPath))]
+}
+
+// Read accepts either a single HTML document or a ZIP archive.
+func Read(filename string, data []byte) (*Bundle, error) {
+	if len(data) > MaxUploadBytes {
+		return nil, ErrBundleTooLarge
+	}
+
+	ext := strings.ToLower(path.Ext(filename))
+	switch {
+	case ext == ".zip" || bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return readZip(data)
+	case ext == ".html" || ext == ".htm":
+		return &Bundle{EntryPath: "index.html", HTML: string(data), Assets: map[string][]byte{}}, nil
+	default:
+		return nil, ErrUnsupportedFile
+	}
+}
+
+func readZip(data []byte) (*Bundle, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFile, err)
+	}
+
+	if len(reader.File) > maxFiles {
+		return nil, fmt.Errorf("%w: more than %d files", ErrBundleTooLarge, maxFiles)
+	}
+
+	b := &Bundle{Assets: make(map[string][]byte)}
+	htmlFiles := make(map[string][]byte)
+	var total int64
+
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		cleanPath, err := cleanArchivePath(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if isMetadataFile(cleanPath) {
+			continue
+		}
+
+		ext := strings.ToLower(path.Ext(cleanPath))
+		_, isAsset := assetContentTypes[ext]
+		isHTML := ext == ".html" || ext == ".htm"
+		if !isAsset && !isHTML {
+			b.Skipped = append(b.Skipped, cleanPath)
+			continue
+		}
+
+		content, err := readZipFile(f, maxUncompressedBytes-total)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(content))
+
+		if isHTML {
+			htmlFiles[cleanPath] = content
+		} else {
+			b.Assets[cleanPath] = content
+		}
+	}
+
+	entry, ok := pickEntry(htmlFiles)
+	if !ok {
+		return nil, ErrNoHTMLEntry
+	}
+
+	b.EntryPath = entry
+	b.HTML = string(htmlFiles[entry])
+	sort.Strings(b.Skipped)
+
+	return b, nil
+}
+
+// readZipFile reads at most limit bytes, the declared size of an entry is not trusted.
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	if int64(len(content)) > limit {
+		return nil, ErrBundleTooLarge
+	}
+
+	return content, nil
+}
+
+// pickEntry prefers the shallowest index.html, then the shallowest html file.
+func pickEntry(htmlFiles map[string][]byte) (string, bool) {
+	var candidates []string
+	for p := range htmlFiles {
+		candidates = append(candidates, p)
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		aIndex, bIndex := path.Base(a) == "index.html", path.Base(b) == "index.html"
+		if aIndex != bIndex {
+			return aIndex
+		}
+		if da, db := strings.Count(a, "/"), strings.Count(b, "/"); da != db {
+			return da < db
+		}
+		return a < b
+	})
+
+	return candidates[0], true
+}
+
+// cleanArchivePath rejects absolute paths and paths escaping the archive root (zip slip).
+func cleanArchivePath(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") {
+		return "", ErrInvalidPath
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidPath
+	}
+
+	return cleaned, nil
+}
+
+func isMetadataFile(p string) bool {
+	return strings.HasPrefix(p, "__MACOSX/") || path.Base(p) == ".DS_Store"
+}
@@ -0,0 +1,207 @@
+package bundle
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Resolver maps a clean asset path inside the bundle to its public URL.
+type Resolver func(assetPath string) (string, bool)
+
+// Page is the entry document reduced to what the validator needs: the body
+// content plus the head metadata of the original page.
+type Page struct {
+	Body        string
+	Title       string
+	Description string
+	// InlineCSS holds the content of the <style> blocks, the sanitizer drops
+	// them so they have to be hosted as a stylesheet of their own.
+	InlineCSS string
+	Rewritten int // number of relative URLs pointing to bundle assets
+}
+
+// urlAttrs are the attributes holding a single URL.
+var urlAttrs = map[string]bool{
+	"src": true, "href": true, "poster": true,
+}
+
+var cssURLPattern = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+)(['"]?)\s*\)`)
+
+// ParsePage parses the entry document, rewrites relative asset URLs through
+// resolve and moves head stylesheet links into the body so they survive the
+// rebuild of the document around the tracking script.
+func ParsePage(document, entryPath string, resolve Resolver) (*Page, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	page := &Page{}
+	baseDir := path.Dir(entryPath)
+
+	var head, body *html.Node
+	var styles []*html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "head":
+				head = n
+			case "body":
+				body = n
+			case "title":
+				if page.Title == "" && n.FirstChild != nil {
+					page.Title = strings.TrimSpace(n.FirstChild.Data)
+				}
+			case "meta":
+				if strings.EqualFold(attrVal(n, "name"), "description") {
+					page.Description = strings.TrimSpace(attrVal(n, "content"))
+				}
+			case "style":
+				styles = append(styles, n)
+			}
+			page.rewriteAttrs(n, baseDir, resolve)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if body == nil {
+		return nil, fmt.Errorf("failed to parse html: missing body")
+	}
+
+	var css []string
+	for _, n := range styles {
+		if n.FirstChild != nil {
+			css = append(css, page.rewriteCSS(n.FirstChild.Data, baseDir, resolve))
+		}
+		n.Parent.RemoveChild(n)
+	}
+	page.InlineCSS = strings.TrimSpace(strings.Join(css, "\n"))
+
+	var sb strings.Builder
+	if head != nil {
+		for c := head.FirstChild; c != nil; c = c.NextSibling {
+			if isStylesheet(c) {
+				if err := html.Render(&sb, c); err != nil {
+					return nil, fmt.Errorf("failed to render stylesheet: %w", err)
+				}
+			}
+		}
+	}
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&sb, c); err != nil {
+			return nil, fmt.Errorf("failed to render body: %w", err)
+		}
+	}
+
+	page.Body = sb.String()
+	return page, nil
+}
+
+// RewriteCSS rewrites the url() references of a stylesheet stored at cssPath.
+func RewriteCSS(css, cssPath string, resolve Resolver) string {
+	p := &Page{}
+	return p.rewriteCSS(css, path.Dir(cssPath), resolve)
+}
+
+func (p *Page) rewriteAttrs(n *html.Node, baseDir string, resolve Resolver) {
+	for i, attr := range n.Attr {
+		switch {
+		case urlAttrs[attr.Key]:
+			// links to other pages of the bundle are not hosted, only assets are
+			if n.Data == "a" {
+				continue
+			}
+			if rewritten, ok := p.resolveRef(attr.Val, baseDir, resolve); ok {
+				n.Attr[i].Val = rewritten
+			}
+		case attr.Key == "srcset":
+			n.Attr[i].Val = p.rewriteSrcset(attr.Val, baseDir, resolve)
+		case attr.Key == "style":
+			n.Attr[i].Val = p.rewriteCSS(attr.Val, baseDir, resolve)
+		}
+	}
+}
+
+func (p *Page) rewriteSrcset(srcset, baseDir string, resolve Resolver) string {
+	candidates := strings.Split(srcset, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		if rewritten, ok := p.resolveRef(fields[0], baseDir, resolve); ok {
+			fields[0] = rewritten
+		}
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func (p *Page) rewriteCSS(css, baseDir string, resolve Resolver) string {
+	return cssURLPattern.ReplaceAllStringFunc(css, func(match string) string {
+		parts := cssURLPattern.FindStringSubmatch(match)
+		rewritten, ok := p.resolveRef(parts[2], baseDir, resolve)
+		if !ok {
+			return match
+		}
+		return fmt.Sprintf("url(%q)", rewritten)
+	})
+}
+
+// resolveRef resolves a relative reference against baseDir, absolute URLs,
+// fragments and data URIs are left untouched.
+func (p *Page) resolveRef(ref, baseDir string, resolve Resolver) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") {
+		return "", false
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false
+	}
+
+	assetPath := u.Path
+	if strings.HasPrefix(assetPath, "/") {
+		assetPath = strings.TrimPrefix(assetPath, "/")
+	} else {
+		assetPath = path.Join(baseDir, assetPath)
+	}
+	assetPath = path.Clean(assetPath)
+
+	publicURL, ok := resolve(assetPath)
+	if !ok {
+		return "", false
+	}
+
+	p.Rewritten++
+	if u.Fragment != "" {
+		publicURL += "#" + u.Fragment
+	}
+	return publicURL, true
+}
+
+func isStylesheet(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	return n.Data == "link" && strings.EqualFold(attrVal(n, "rel"), "stylesheet")
+}
+
+func attrVal(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -1,9 +1,15 @@
 package cloudflare
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -14,8 +20,17 @@ import (
 
 type R2Bucket interface {
 	GetSignedURL(ctx context.Context, key, contentType string) (string, string, error)
+	Upload(ctx context.Context, key, contentType string, body []byte) (string, error)
+	Delete(ctx context.Context, key string) error
+	// Download reads back an object from its public URL, only URLs under the
+	// public URL of the bucket are accepted
+	Download(ctx context.Context, publicUrl string) ([]byte, error)
+	// Owns tells if the public URL is served by the bucket
+	Owns(publicUrl string) bool
 }
 
+var ErrForeignURL = errors.New("the URL is not served by the bucket")
+
 type R2Config struct {
 	BucketName      string
 	AccountId       string
@@ -55,9 +70,7 @@ func NewR2Bucket(cfg R2Config) R2Bucket {
 func (b *r2Bucket) GetSignedURL(ctx context.Context, key, contentType string) (string, string, error) {
 	presignClient := s3.NewPresignClient(b.client)
 
-	if b.cfg.Environment != "production" {
-		key = fmt.Sprintf("dev/%s", key)
-	}
+	key = b.objectKey(key)
 
 	presignResult, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(b.cfg.BucketName),
@@ -69,7 +82,98 @@ func (b *r2Bucket) GetSignedURL(ctx context.Context, key, contentType string) (s
 		return "", "", fmt.Errorf("failed to presign put object: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/%s", b.cfg.R2BucketPublicUrl, key)
+	return presignResult.URL, b.publicURL(key), nil
+}
+
+// Upload stores the object server side and returns its public URL.
+func (b *r2Bucket) Upload(ctx context.Context, key, contentType string, body []byte) (string, error) {
+	key = b.objectKey(key)
+
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.cfg.BucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to put object: %w", err)
+	}
+
+	return b.publicURL(key), nil
+}
+
+func (b *r2Bucket) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.cfg.BucketName),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+func (b *r2Bucket) Download(ctx context.Context, publicUrl string) ([]byte, error) {
+	key, err := b.keyFromURL(publicUrl)
+	if err != nil {
+		return nil, err
+	}
 
-	return presignResult.URL, url, nil
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.cfg.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+func (b *r2Bucket) Owns(publicUrl string) bool {
+	_, err := b.keyFromURL(publicUrl)
+	return err == nil
+}
+
+// keyFromURL is the object key of a public URL, the URL must be on the host
+// of the bucket and under its base path.
+func (b *r2Bucket) keyFromURL(publicUrl string) (string, error) {
+	base, err := url.Parse(b.cfg.R2BucketPublicUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid bucket public URL: %w", err)
+	}
+	u, err := url.Parse(publicUrl)
+	if err != nil || u.User != nil || u.Scheme != base.Scheme || u.Host != base.Host {
+		return "", ErrForeignURL
+	}
+
+	prefix := strings.TrimSuffix(base.Path, "/") + "/"
+	if !strings.HasPrefix(u.Path, prefix) {
+		return "", ErrForeignURL
+	}
+	key := strings.TrimPrefix(u.Path, prefix)
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return "", ErrForeignURL
+	}
+
+	return key, nil
+}
+
+// publicURL escapes every segment of the key, "a b.png" is served as "a%20b.png".
+func (b *r2Bucket) publicURL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf("%s/%s", b.cfg.R2BucketPublicUrl, strings.Join(segments, "/"))
+}
+
+// objectKey prefixes keys outside production so environments share a bucket safely.
+func (b *r2Bucket) objectKey(key string) string {
+	if b.cfg.Environment != "production" {
+		return fmt.Sprintf("dev/%s", key)
+	}
+	return key
 }
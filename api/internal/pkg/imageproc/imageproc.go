@@ -0,0 +1,193 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Limits applied to uploaded images, the pixel limit keeps a small file that
+// decodes to a huge image from exhausting memory.
+const (
+	MaxUploadBytes = 8 << 20
+	maxPixels      = 40_000_000
+	minDimension   = 16
+	jpegQuality    = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image, upload a JPEG, PNG or WebP file")
+	ErrImageTooLarge    = errors.New("the image exceeds the allowed size")
+	ErrImageDimensions  = errors.New("the image is too small or has too many pixels")
+)
+
+// Size is a variant produced for every upload, an image narrower than the
+// width is never upscaled.
+type Size struct {
+	Name  string
+	Width int
+}
+
+// Sizes are ordered from the smallest, the WebP version is made from WebPSize.
+var Sizes = []Size{
+	{Name: "thumb", Width: 320},
+	{Name: "medium", Width: 800},
+	{Name: "large", Width: 1600},
+}
+
+const WebPSize = "medium"
+
+// allowedTypes are the sniffed content types accepted for an upload
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type Variant struct {
+	Name        string
+	Format      string // jpeg, png or webp
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Ext returns the file extension of the variant
+func (v Variant) Ext() string {
+	if v.Format == "jpeg" {
+		return "jpg"
+	}
+	return v.Format
+}
+
+// Process validates the uploaded image and encodes its variants. The image is
+// decoded and encoded again from its pixels alone, so EXIF data and any other
+// metadata are dropped, the EXIF orientation of a JPEG is applied first.
+func Process(data []byte) ([]Variant, error) {
+	if len(data) > MaxUploadBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !allowedTypes[contentType] {
+		return nil, ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width < minDimension || cfg.Height < minDimension || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageDimensions
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	if contentType == "image/jpeg" {
+		src = orient(src, exifOrientation(data))
+	}
+
+	opaque := isOpaque(src)
+	variants := make([]Variant, 0, len(Sizes)+1)
+	for _, size := range Sizes {
+		resized := resize(src, size.Width)
+
+		variant, err := encode(size.Name, resized, opaque)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+
+		if size.Name == WebPSize {
+			var buf bytes.Buffer
+			if err := nativewebp.Encode(&buf, resized, nil); err != nil {
+				return nil, fmt.Errorf("failed to encode webp: %w", err)
+			}
+			variants = append(variants, Variant{
+				Name:        size.Name,
+				Format:      "webp",
+				ContentType: "image/webp",
+				Width:       resized.Bounds().Dx(),
+				Height:      resized.Bounds().Dy(),
+				Data:        buf.Bytes(),
+			})
+		}
+	}
+
+	return variants, nil
+}
+
+// encode writes a JPEG, or a PNG to keep the transparency of the image
+func encode(name string, img image.Image, opaque bool) (Variant, error) {
+	variant := Variant{
+		Name:   name,
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+
+	var buf bytes.Buffer
+	if opaque {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return variant, fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+		variant.Format, variant.ContentType = "jpeg", "image/jpeg"
+	} else {
+		if err := png.Encode(&buf, img); err != nil {
+			return variant, fmt.Errorf("failed to encode png: %w", err)
+		}
+		variant.Format, variant.ContentType = "png", "image/png"
+	}
+
+	variant.Data = buf.Bytes()
+	return variant, nil
+}
+
+// resize scales the image down to the width, keeping its aspect ratio
+func resize(src image.Image, width int) *image.NRGBA {
+	bounds := src.Bounds()
+	if bounds.Dx() <= width {
+		width = bounds.Dx()
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if width == bounds.Dx() {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+		return dst
+	}
+
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string // optional, sent as an alternative to Text
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type Config struct {
+	SMTPHost     string // emails are only logged when empty
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+}
+
+// NewMailer returns an SMTP mailer, or one logging the emails when no SMTP
+// host is configured so that local environments work without a mail server.
+func NewMailer(cfg Config) Mailer {
+	if cfg.SMTPHost == "" {
+		return &logMailer{}
+	}
+
+	return &smtpMailer{cfg: cfg}
+}
+
+type smtpMailer struct {
+	cfg Config
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	body, err := m.build(msg)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", m.cfg.SMTPUsername, m.cfg.SMTPPassword, m.cfg.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(m.cfg.SMTPHost, fmt.Sprint(m.cfg.SMTPPort))
+
+	// smtp.SendMail does not take a context, the send is abandoned when the
+	// context is done but may still complete in the background
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, m.cfg.From, []string{msg.To}, body)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *smtpMailer) build(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", encodeSubject(msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.HTML == "" {
+		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+		buf.WriteString(msg.Text)
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+
+	parts := []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	}
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeSubject folds the line breaks of a subject so it cannot start another
+// header, and encodes it as a MIME word when it is not plain ASCII.
+func encodeSubject(subject string) string {
+	subject = strings.Join(strings.FieldsFunc(subject, func(r rune) bool { return r == '\r' || r == '\n' }), " ")
+	return mime.QEncoding.Encode("utf-8", subject)
+}
+
+type logMailer struct{}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("Email to %s: %s\n%s", msg.To, msg.Subject, msg.Text)
+	return nil
+}
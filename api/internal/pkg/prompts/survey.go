@@ -0,0 +1,24 @@
+package prompts
+
+import "fmt"
+
+// BuildSurveyThemesPrompt asks for the recurring themes of free text survey answers.
+// answersJSON is a JSON array of strings.
+func BuildSurveyThemesPrompt(question, answersJSON string) string {
+	return fmt.Sprintf(`
+You are a product researcher analyzing the answers of a short survey shown to people who just joined the waitlist of a new product.
+
+**Survey question:** %q
+
+**Instructions:**
+1. Group the answers into at most 6 themes, each answer belongs to exactly one theme.
+2. Name every theme with a short label of at most 5 words, in the language of most answers.
+3. Count how many answers belong to each theme and pick up to 3 representative answers, copied verbatim.
+4. Sort the themes by count, highest first.
+5. Return ONLY a JSON array in this format, without Markdown or explanations:
+[{"theme": "string", "count": 0, "examples": ["string"]}]
+
+**Answers:**
+%s
+`, question, answersJSON)
+}
@@ -0,0 +1,24 @@
+package prompts
+
+import "fmt"
+
+// BuildTaxonomyPrompt asks for the category and tags of an idea. categories
+// lists one "slug: Name" line per category the model may pick from.
+func BuildTaxonomyPrompt(title, description, targetAudience, categories string) string {
+	return fmt.Sprintf(`
+You are curating a directory of startup ideas. Classify the idea below.
+
+**Instructions:**
+1. Pick the single most specific category that fits the idea from the list, use its slug exactly as written.
+2. Suggest up to 5 short tags (1 to 3 words, lowercase, in English) describing the market, the audience and the technology. Do not repeat the category name.
+3. Return ONLY a JSON object in this format, without Markdown or explanations:
+{"category": "slug", "tags": ["string"]}
+
+**Categories:**
+%s
+
+**Idea title:** %q
+**Description:** %q
+**Target audience:** %q
+`, categories, title, description, targetAudience)
+}
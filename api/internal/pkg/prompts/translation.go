@@ -0,0 +1,21 @@
+package prompts
+
+import "fmt"
+
+// BuildTranslationPrompt asks for a translation of landing page text segments.
+// textsJSON is a JSON array of strings and the answer must be an array of the same length.
+func BuildTranslationPrompt(locale, textsJSON string) string {
+	return fmt.Sprintf(`
+You are a professional marketing translator. Translate the text segments of a landing page into the language identified by the BCP 47 tag "%s".
+
+**Instructions:**
+1. The input is a JSON array of strings. Return ONLY a JSON array of strings with exactly the same number of elements, in the same order.
+2. Translate every element independently, do not merge or split elements.
+3. Keep the tone persuasive and natural for native speakers, adapt idioms instead of translating them literally.
+4. Keep brand names, product names, numbers, prices, URLs and email addresses unchanged.
+5. Do not add HTML, Markdown, explanations or quotes around the array.
+
+**Text segments:**
+%s
+`, locale, textsJSON)
+}
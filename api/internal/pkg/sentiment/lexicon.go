@@ -0,0 +1,104 @@
+// Package sentiment scores the sentiment of short texts without any service,
+// it is the fallback when the AI cannot be reached.
+package sentiment
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Neutral is the score of a text without any sentiment
+const Neutral = 0.5
+
+// normalization flattens the sum of the word weights into -1 to 1, the
+// higher it is the more words it takes to get close to the ends
+const normalization = 15
+
+// negationReach is how many words before a sentiment word a negation flips it
+const negationReach = 3
+
+// lexicon weighs the words of product feedback from -3, very negative, to 3
+var lexicon = map[string]float64{
+	"love": 3, "loved": 3, "loving": 2.5, "great": 3, "excellent": 3, "amazing": 3, "awesome": 3,
+	"fantastic": 3, "brilliant": 3, "perfect": 3, "best": 3, "beautiful": 3, "wonderful": 3,
+	"impressive": 2.5, "excited": 2.5, "exciting": 2.5, "good": 2, "nice": 2, "cool": 2,
+	"useful": 2, "helpful": 2, "clever": 2, "smart": 2, "easy": 2, "intuitive": 2, "promising": 2,
+	"valuable": 2, "innovative": 2, "polished": 2, "elegant": 2, "enjoy": 2, "enjoyed": 2,
+	"fun": 2, "favorite": 2, "happy": 2, "glad": 2, "recommend": 2, "wow": 2, "interesting": 1.5,
+	"better": 1.5, "fast": 1.5, "solid": 1.5, "smooth": 1.5, "worth": 1.5, "needed": 1.5,
+	"thanks": 1.5, "thank": 1.5, "like": 1, "liked": 1.5, "simple": 1, "clean": 1, "want": 1, "yes": 1,
+
+	"hate": -3, "hated": -3, "terrible": -3, "awful": -3, "horrible": -3, "worst": -3, "useless": -3,
+	"scam": -3, "bad": -2.5, "broken": -2.5, "disappointing": -2.5, "disappointed": -2.5,
+	"pointless": -2.5, "ugly": -2.5, "spam": -2.5, "waste": -2.5, "frustrating": -2.5,
+	"poor": -2, "boring": -2, "confusing": -2, "buggy": -2, "crash": -2, "crashes": -2,
+	"annoying": -2, "overpriced": -2, "clunky": -2, "worse": -2, "fail": -2, "failed": -2,
+	"fails": -2, "frustrated": -2, "mediocre": -2, "confused": -1.5, "difficult": -1.5,
+	"slow": -1.5, "expensive": -1.5, "bug": -1.5, "unnecessary": -1.5, "complicated": -1.5,
+	"wrong": -1.5, "unclear": -1.5, "doubt": -1.5, "skeptical": -1.5, "sceptical": -1.5,
+	"risky": -1.5, "saturated": -1.5, "unfortunately": -1.5, "sadly": -1.5, "lacking": -1.5,
+	"lacks": -1.5, "weak": -1.5, "hard": -1, "pricey": -1, "problem": -1, "problems": -1,
+	"issue": -1, "issues": -1, "missing": -1, "crowded": -1, "meh": -1,
+}
+
+var negations = map[string]bool{
+	"not": true, "no": true, "never": true, "nothing": true, "nobody": true, "none": true,
+	"neither": true, "nor": true, "without": true, "cannot": true, "dont": true, "doesnt": true,
+	"isnt": true, "wasnt": true, "wont": true, "cant": true, "didnt": true, "arent": true,
+}
+
+// modifiers scale the weight of the word that follows them
+var modifiers = map[string]float64{
+	"extremely": 2, "incredibly": 2, "absolutely": 1.8, "very": 1.5, "really": 1.5, "super": 1.5,
+	"totally": 1.5, "highly": 1.5, "truly": 1.5, "so": 1.3, "pretty": 1.2, "fairly": 0.8,
+	"somewhat": 0.6, "kinda": 0.6, "bit": 0.6, "slightly": 0.5,
+}
+
+// Score rates the sentiment of a text from 0, negative, to 1, positive. The
+// words of the lexicon are summed, flipped after a negation and scaled by the
+// word before them, the same text always getting the same score.
+func Score(text string) float64 {
+	words := tokenize(text)
+
+	var total float64
+	for i, word := range words {
+		weight, ok := lexicon[word]
+		if !ok {
+			continue
+		}
+
+		if i > 0 {
+			if modifier, ok := modifiers[words[i-1]]; ok {
+				weight *= modifier
+			}
+		}
+		for j := i - 1; j >= 0 && j >= i-negationReach; j-- {
+			if isNegation(words[j]) {
+				weight *= -0.75 // "not bad" is not as good as "good"
+				break
+			}
+		}
+
+		total += weight
+	}
+
+	if total == 0 {
+		return Neutral
+	}
+	normalized := total / math.Sqrt(total*total+normalization)
+	return (normalized + 1) / 2
+}
+
+func isNegation(word string) bool {
+	return negations[word] || strings.HasSuffix(word, "n't")
+}
+
+// tokenize lowercases the words of the text, apostrophes are kept so that the
+// contracted negations are recognized
+func tokenize(text string) []string {
+	text = strings.ToLower(strings.ReplaceAll(text, "’", "'"))
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
@@ -0,0 +1,249 @@
+package sharecard
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Width and Height follow the Open Graph recommended 1.91:1 ratio.
+const (
+	Width   = 1200
+	Height  = 630
+	padding = 80
+
+	titleSize   = 64
+	taglineSize = 32
+	footerSize  = 28
+
+	maxTitleLines   = 3
+	maxTaglineLines = 3
+)
+
+var (
+	backgroundTop    = color.RGBA{R: 30, G: 27, B: 75, A: 255}
+	backgroundBottom = color.RGBA{R: 67, G: 56, B: 202, A: 255}
+	titleColor       = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	taglineColor     = color.RGBA{R: 224, G: 231, B: 255, A: 255}
+	badgeColor       = color.NRGBA{R: 255, G: 255, B: 255, A: 40}
+)
+
+// Card holds the content rendered on a share image.
+type Card struct {
+	Title   string
+	Tagline string
+	Signups int
+	Label   string // optional, e.g. the MVP name
+	Brand   string
+}
+
+type faces struct {
+	title   font.Face
+	tagline font.Face
+	footer  font.Face
+}
+
+func (f *faces) Close() {
+	f.title.Close()
+	f.tagline.Close()
+	f.footer.Close()
+}
+
+// The parsed fonts are shared, faces keep a glyph cache and are not safe for
+// concurrent use so every render creates its own.
+var (
+	loadFontsOnce sync.Once
+	boldFont      *opentype.Font
+	regularFont   *opentype.Font
+	loadFontsErr  error
+)
+
+func loadFonts() error {
+	loadFontsOnce.Do(func() {
+		if boldFont, loadFontsErr = opentype.Parse(gobold.TTF); loadFontsErr != nil {
+			loadFontsErr = fmt.Errorf("failed to parse bold font: %w", loadFontsErr)
+			return
+		}
+		if regularFont, loadFontsErr = opentype.Parse(goregular.TTF); loadFontsErr != nil {
+			loadFontsErr = fmt.Errorf("failed to parse regular font: %w", loadFontsErr)
+		}
+	})
+	return loadFontsErr
+}
+
+func newFaces() (*faces, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	newFace := func(f *opentype.Font, size float64) (font.Face, error) {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		return face, nil
+	}
+
+	title, err := newFace(boldFont, titleSize)
+	if err != nil {
+		return nil, err
+	}
+	tagline, err := newFace(regularFont, taglineSize)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := newFace(boldFont, footerSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &faces{title: title, tagline: tagline, footer: footer}, nil
+}
+
+// Render draws the card and encodes it as PNG.
+func Render(card Card) ([]byte, error) {
+	f, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	drawGradient(img, backgroundTop, backgroundBottom)
+
+	contentWidth := Width - 2*padding
+	y := padding + titleSize
+
+	if card.Label != "" {
+		drawText(img, f.footer, taglineColor, padding, padding+footerSize, strings.ToUpper(card.Label))
+		y += footerSize + 24
+	}
+
+	for _, line := range wrapText(f.title, card.Title, contentWidth, maxTitleLines) {
+		drawText(img, f.title, titleColor, padding, y, line)
+		y += titleSize + 12
+	}
+
+	// the tagline gets whatever room is left above the footer
+	y += 16
+	footerY := Height - padding
+	taglineLines := min(maxTaglineLines, (footerY-footerSize-40-y)/(taglineSize+10)+1)
+	for _, line := range wrapText(f.tagline, card.Tagline, contentWidth, taglineLines) {
+		drawText(img, f.tagline, taglineColor, padding, y, line)
+		y += taglineSize + 10
+	}
+
+	signupsText := fmt.Sprintf("%d signed up", card.Signups)
+	badgeWidth := font.MeasureString(f.footer, signupsText).Ceil() + 40
+	fillRect(img, image.Rect(padding-20, footerY-footerSize-12, padding-20+badgeWidth, footerY+16), badgeColor)
+	drawText(img, f.footer, titleColor, padding, footerY, signupsText)
+
+	if card.Brand != "" {
+		brandWidth := font.MeasureString(f.footer, card.Brand).Ceil()
+		drawText(img, f.footer, taglineColor, Width-padding-brandWidth, footerY, card.Brand)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode share card: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func drawGradient(img *image.RGBA, top, bottom color.RGBA) {
+	bounds := img.Bounds()
+	height := bounds.Dy()
+	for y := 0; y < height; y++ {
+		t := float64(y) / float64(height-1)
+		c := color.RGBA{
+			R: lerp(top.R, bottom.R, t),
+			G: lerp(top.G, bottom.G, t),
+			B: lerp(top.B, bottom.B, t),
+			A: 255,
+		}
+		draw.Draw(img, image.Rect(bounds.Min.X, y, bounds.Max.X, y+1), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+func drawText(img *image.RGBA, face font.Face, c color.Color, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// wrapText splits text into at most maxLines lines that fit within width,
+// the last line is truncated with an ellipsis when the text does not fit.
+func wrapText(face font.Face, text string, width, maxLines int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 || maxLines <= 0 {
+		return nil
+	}
+
+	var lines []string
+	current := ""
+	for i, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+
+		if len(lines) == maxLines-1 {
+			current = strings.Join(words[i:], " ")
+			break
+		}
+	}
+	lines = append(lines, current)
+
+	last := lines[len(lines)-1]
+	if font.MeasureString(face, last).Ceil() > width {
+		for len(last) > 0 && font.MeasureString(face, last+"…").Ceil() > width {
+			_, size := lastRune(last)
+			last = last[:len(last)-size]
+		}
+		lines[len(lines)-1] = strings.TrimSpace(last) + "…"
+	}
+
+	return lines
+}
+
+func lastRune(s string) (rune, int) {
+	r := []rune(s)
+	if len(r) == 0 {
+		return 0, 0
+	}
+	return r[len(r)-1], len(string(r[len(r)-1]))
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
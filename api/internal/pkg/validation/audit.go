@@ -0,0 +1,648 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+type AuditCategory string
+
+const (
+	AuditCategoryAccessibility AuditCategory = "accessibility"
+	AuditCategorySEO           AuditCategory = "seo"
+	AuditCategoryPerformance   AuditCategory = "performance"
+	AuditCategoryConversion    AuditCategory = "conversion"
+)
+
+type AuditSeverity string
+
+const (
+	AuditSeverityError   AuditSeverity = "error"
+	AuditSeverityWarning AuditSeverity = "warning"
+	AuditSeverityInfo    AuditSeverity = "info"
+)
+
+// Thresholds used by the audit. They are intentionally conservative, the
+// audit is meant to nudge founders towards better pages, not to block them.
+const (
+	minTitleLength       = 30
+	maxTitleLength       = 60
+	minDescriptionLength = 70
+	maxDescriptionLength = 160
+
+	pageWeightWarnBytes  = 150 * 1024
+	pageWeightErrorBytes = 500 * 1024
+	maxImageCount        = 12
+	inlineDataURIWarn    = 100 * 1024
+
+	// foldTextBudget is the amount of visible text (in characters) that can
+	// precede the CTA before we consider it to be below the fold.
+	foldTextBudget   = 600
+	maxCompetingCTAs = 4
+
+	// minShadeDistance is the minimum distance on the Tailwind shade scale
+	// (white=0, 50..900, black=1000) between text and background colours.
+	minShadeDistance = 500
+)
+
+var severityPenalty = map[AuditSeverity]int{
+	AuditSeverityError:   20,
+	AuditSeverityWarning: 8,
+	AuditSeverityInfo:    2,
+}
+
+var tailwindColorClass = regexp.MustCompile(`^(text|bg)-(white|black|[a-z]+-(50|100|200|300|400|500|600|700|800|900))$`)
+
+var genericCTATexts = map[string]bool{
+	"click here": true, "submit": true, "click": true, "here": true, "button": true, "ok": true,
+}
+
+// AuditFinding is a single actionable issue discovered while auditing a page.
+type AuditFinding struct {
+	Category   AuditCategory `json:"category"`
+	Severity   AuditSeverity `json:"severity"`
+	Rule       string        `json:"rule"`
+	Message    string        `json:"message"`
+	Suggestion string        `json:"suggestion"`
+	Count      int           `json:"count,omitempty"`
+}
+
+// AuditReport contains the per category scores (0-100) and the findings behind them.
+type AuditReport struct {
+	Score      int                   `json:"score"`
+	Categories map[AuditCategory]int `json:"categories"`
+	Findings   []AuditFinding        `json:"findings"`
+	PageWeight int                   `json:"pageWeight"`
+	AuditedAt  time.Time             `json:"auditedAt"`
+}
+
+type pageStats struct {
+	title           string
+	description     string
+	hasLang         bool
+	headingLevels   []int
+	h1Count         int
+	imageCount      int
+	imagesNoAlt     int
+	imagesNoSize    int
+	dataURIBytes    int
+	unlabeledInputs int
+	labelFor        map[string]bool
+	inputIDs        []string
+	emptyButtons    int
+	emptyLinks      int
+	lowContrast     map[string]bool
+	ctaCount        int
+	ctaText         string
+	textBeforeCTA   int
+	ctaSeen         bool
+	buttonLikeCount int
+}
+
+// AuditHTML runs a static quality audit on a full landing page document. It
+// scores accessibility, SEO, performance and conversion hygiene.
+func AuditHTML(fullHTML, ctaBtnID string) (*AuditReport, error) {
+	doc, err := html.Parse(strings.NewReader(fullHTML))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html for audit: %w", err)
+	}
+
+	stats := &pageStats{
+		labelFor:    make(map[string]bool),
+		lowContrast: make(map[string]bool),
+	}
+	collectPageStats(doc, stats, ctaBtnID, colorContext{}, false)
+
+	var findings []AuditFinding
+	findings = append(findings, auditAccessibility(stats)...)
+	findings = append(findings, auditSEO(stats)...)
+	findings = append(findings, auditPerformance(stats, len(fullHTML))...)
+	findings = append(findings, auditConversion(stats)...)
+
+	categories := map[AuditCategory]int{
+		AuditCategoryAccessibility: 100,
+		AuditCategorySEO:           100,
+		AuditCategoryPerformance:   100,
+		AuditCategoryConversion:    100,
+	}
+	for _, f := range findings {
+		categories[f.Category] -= severityPenalty[f.Severity]
+	}
+
+	total := 0
+	for category, score := range categories {
+		if score < 0 {
+			categories[category] = 0
+			score = 0
+		}
+		total += score
+	}
+
+	if findings == nil {
+		findings = []AuditFinding{}
+	}
+	// the most severe findings come first, the order is the same on every run
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return severityPenalty[a.Severity] > severityPenalty[b.Severity]
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return a.Rule < b.Rule
+	})
+
+	return &AuditReport{
+		Score:      total / len(categories),
+		Categories: categories,
+		Findings:   findings,
+		PageWeight: len(fullHTML),
+		AuditedAt:  time.Now(),
+	}, nil
+}
+
+// colorContext tracks the closest Tailwind text and background colours
+// applied by ancestors, so contrast can be checked where text is rendered.
+type colorContext struct {
+	text      string
+	textShade int
+	bg        string
+	bgShade   int
+}
+
+func collectPageStats(n *html.Node, stats *pageStats, ctaBtnID string, colors colorContext, inLabel bool) {
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "script", "style", "noscript":
+			return
+		case "html":
+			stats.hasLang = getAttr(n, "lang") != ""
+		case "title":
+			stats.title = strings.TrimSpace(textContent(n))
+			return
+		case "meta":
+			if strings.EqualFold(getAttr(n, "name"), "description") {
+				stats.description = strings.TrimSpace(getAttr(n, "content"))
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			level, _ := strconv.Atoi(n.Data[1:])
+			stats.headingLevels = append(stats.headingLevels, level)
+			if level == 1 {
+				stats.h1Count++
+			}
+		case "img":
+			stats.imageCount++
+			if !hasAttr(n, "alt") {
+				stats.imagesNoAlt++
+			}
+			if !hasAttr(n, "width") || !hasAttr(n, "height") {
+				stats.imagesNoSize++
+			}
+			if src := getAttr(n, "src"); strings.HasPrefix(src, "data:") {
+				stats.dataURIBytes += len(src)
+			}
+		case "label":
+			if forID := getAttr(n, "for"); forID != "" {
+				stats.labelFor[forID] = true
+			}
+			inLabel = true
+		case "input", "textarea", "select":
+			inputType := strings.ToLower(getAttr(n, "type"))
+			if inputType != "hidden" && inputType != "submit" && inputType != "button" && inputType != "image" {
+				if !inLabel && getAttr(n, "aria-label") == "" && getAttr(n, "aria-labelledby") == "" {
+					if id := getAttr(n, "id"); id != "" {
+						stats.inputIDs = append(stats.inputIDs, id)
+					} else {
+						stats.unlabeledInputs++
+					}
+				}
+			}
+		case "button":
+			stats.buttonLikeCount++
+			if strings.TrimSpace(textContent(n)) == "" && getAttr(n, "aria-label") == "" {
+				stats.emptyButtons++
+			}
+		case "a":
+			if strings.TrimSpace(textContent(n)) == "" && getAttr(n, "aria-label") == "" && !containsElement(n, "img") {
+				stats.emptyLinks++
+			}
+			if hasBackgroundClass(n) {
+				stats.buttonLikeCount++
+			}
+		}
+
+		if getAttr(n, "id") == ctaBtnID && ctaBtnID != "" {
+			stats.ctaCount++
+			if !stats.ctaSeen {
+				stats.ctaSeen = true
+				stats.ctaText = strings.TrimSpace(textContent(n))
+			}
+		}
+
+		colors = applyColorClasses(n, colors, stats)
+	}
+
+	if n.Type == html.TextNode && !stats.ctaSeen {
+		stats.textBeforeCTA += len(strings.TrimSpace(n.Data))
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectPageStats(c, stats, ctaBtnID, colors, inLabel)
+	}
+}
+
+func applyColorClasses(n *html.Node, colors colorContext, stats *pageStats) colorContext {
+	classAttr := getAttr(n, "class")
+	if classAttr == "" {
+		return colors
+	}
+
+	changed := false
+	for _, class := range strings.Fields(classAttr) {
+		match := tailwindColorClass.FindStringSubmatch(class)
+		if match == nil {
+			continue
+		}
+
+		shade := shadeFromClass(match[2])
+		if match[1] == "text" {
+			colors.text, colors.textShade = class, shade
+		} else {
+			colors.bg, colors.bgShade = class, shade
+		}
+		changed = true
+	}
+
+	if changed && colors.text != "" && colors.bg != "" && hasDirectText(n) {
+		distance := colors.textShade - colors.bgShade
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < minShadeDistance {
+			stats.lowContrast[fmt.Sprintf("%s on %s", colors.text, colors.bg)] = true
+		}
+	}
+
+	return colors
+}
+
+func auditAccessibility(stats *pageStats) []AuditFinding {
+	var findings []AuditFinding
+
+	if stats.imagesNoAlt > 0 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityError,
+			Rule:       "img-alt",
+			Message:    fmt.Sprintf("%d image(s) have no alt attribute.", stats.imagesNoAlt),
+			Suggestion: "Describe what each image shows in its alt attribute, or use alt=\"\" for purely decorative images.",
+			Count:      stats.imagesNoAlt,
+		})
+	}
+
+	if skipped := countSkippedHeadings(stats.headingLevels); skipped > 0 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityWarning,
+			Rule:       "heading-order",
+			Message:    fmt.Sprintf("Heading levels are skipped %d time(s) (e.g. an h2 followed by an h4).", skipped),
+			Suggestion: "Only increase heading levels one step at a time so screen reader users can follow the page outline.",
+			Count:      skipped,
+		})
+	}
+
+	unlabeled := stats.unlabeledInputs
+	for _, id := range stats.inputIDs {
+		if !stats.labelFor[id] {
+			unlabeled++
+		}
+	}
+	if unlabeled > 0 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityError,
+			Rule:       "form-labels",
+			Message:    fmt.Sprintf("%d form field(s) have no associated label.", unlabeled),
+			Suggestion: "Add a <label for=\"...\"> for each field or an aria-label. Placeholders are not a replacement for labels.",
+			Count:      unlabeled,
+		})
+	}
+
+	if stats.emptyButtons > 0 || stats.emptyLinks > 0 {
+		count := stats.emptyButtons + stats.emptyLinks
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityWarning,
+			Rule:       "empty-interactive",
+			Message:    fmt.Sprintf("%d button(s) or link(s) have no accessible text.", count),
+			Suggestion: "Give icon-only buttons and links an aria-label describing their action.",
+			Count:      count,
+		})
+	}
+
+	if len(stats.lowContrast) > 0 {
+		pairs := make([]string, 0, len(stats.lowContrast))
+		for pair := range stats.lowContrast {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityWarning,
+			Rule:       "color-contrast",
+			Message:    fmt.Sprintf("Possible low contrast colour combinations: %s.", strings.Join(pairs, ", ")),
+			Suggestion: "Use a darker text shade on light backgrounds (or a lighter one on dark backgrounds), at least 500 apart on the Tailwind scale.",
+			Count:      len(pairs),
+		})
+	}
+
+	if !stats.hasLang {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryAccessibility,
+			Severity:   AuditSeverityInfo,
+			Rule:       "html-lang",
+			Message:    "The document does not declare its language.",
+			Suggestion: "Set the lang attribute on the <html> element.",
+		})
+	}
+
+	return findings
+}
+
+func auditSEO(stats *pageStats) []AuditFinding {
+	var findings []AuditFinding
+
+	switch titleLength := len([]rune(stats.title)); {
+	case titleLength == 0:
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityError,
+			Rule:       "title-length",
+			Message:    "The page has no title.",
+			Suggestion: "Add a meta title that names the product and its main benefit.",
+		})
+	case titleLength < minTitleLength || titleLength > maxTitleLength:
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityWarning,
+			Rule:       "title-length",
+			Message:    fmt.Sprintf("The title is %d characters long.", titleLength),
+			Suggestion: fmt.Sprintf("Keep the title between %d and %d characters so it is not truncated in search results.", minTitleLength, maxTitleLength),
+		})
+	}
+
+	switch descriptionLength := len([]rune(stats.description)); {
+	case descriptionLength == 0:
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityError,
+			Rule:       "description-length",
+			Message:    "The page has no meta description.",
+			Suggestion: "Add a meta description summarising who the product is for and what it does.",
+		})
+	case descriptionLength < minDescriptionLength || descriptionLength > maxDescriptionLength:
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityWarning,
+			Rule:       "description-length",
+			Message:    fmt.Sprintf("The meta description is %d characters long.", descriptionLength),
+			Suggestion: fmt.Sprintf("Keep the meta description between %d and %d characters.", minDescriptionLength, maxDescriptionLength),
+		})
+	}
+
+	if stats.h1Count == 0 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityError,
+			Rule:       "single-h1",
+			Message:    "The page has no h1 heading.",
+			Suggestion: "Use exactly one h1 for the main headline of the hero section.",
+		})
+	} else if stats.h1Count > 1 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategorySEO,
+			Severity:   AuditSeverityWarning,
+			Rule:       "single-h1",
+			Message:    fmt.Sprintf("The page has %d h1 headings.", stats.h1Count),
+			Suggestion: "Keep a single h1 for the main headline and use h2 for section titles.",
+			Count:      stats.h1Count,
+		})
+	}
+
+	return findings
+}
+
+func auditPerformance(stats *pageStats, pageWeight int) []AuditFinding {
+	var findings []AuditFinding
+
+	if pageWeight > pageWeightErrorBytes {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryPerformance,
+			Severity:   AuditSeverityError,
+			Rule:       "page-weight",
+			Message:    fmt.Sprintf("The HTML document weighs %d KB.", pageWeight/1024),
+			Suggestion: "Move inline images to hosted files and remove unused markup to get the page under 150 KB.",
+		})
+	} else if pageWeight > pageWeightWarnBytes {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryPerformance,
+			Severity:   AuditSeverityWarning,
+			Rule:       "page-weight",
+			Message:    fmt.Sprintf("The HTML document weighs %d KB.", pageWeight/1024),
+			Suggestion: "Trim unused sections and inline assets to keep the page under 150 KB.",
+		})
+	}
+
+	if stats.imageCount > maxImageCount {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryPerformance,
+			Severity:   AuditSeverityWarning,
+			Rule:       "image-count",
+			Message:    fmt.Sprintf("The page loads %d images.", stats.imageCount),
+			Suggestion: fmt.Sprintf("Keep the page to %d images or fewer and lazy load the ones below the fold.", maxImageCount),
+			Count:      stats.imageCount,
+		})
+	}
+
+	if stats.dataURIBytes > inlineDataURIWarn {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryPerformance,
+			Severity:   AuditSeverityWarning,
+			Rule:       "inline-data-uri",
+			Message:    fmt.Sprintf("Inline data URI images add %d KB to the document.", stats.dataURIBytes/1024),
+			Suggestion: "Upload large images and reference them by URL so they can be cached separately.",
+		})
+	}
+
+	if stats.imagesNoSize > 0 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryPerformance,
+			Severity:   AuditSeverityInfo,
+			Rule:       "image-dimensions",
+			Message:    fmt.Sprintf("%d image(s) have no explicit width and height.", stats.imagesNoSize),
+			Suggestion: "Set width and height on images to avoid layout shifts while they load.",
+			Count:      stats.imagesNoSize,
+		})
+	}
+
+	return findings
+}
+
+func auditConversion(stats *pageStats) []AuditFinding {
+	var findings []AuditFinding
+
+	if stats.ctaCount == 0 {
+		return append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityError,
+			Rule:       "cta-present",
+			Message:    "The call-to-action button is missing.",
+			Suggestion: "Add the main call-to-action button so signups can be tracked.",
+		})
+	}
+
+	if stats.ctaCount > 1 {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityError,
+			Rule:       "cta-count",
+			Message:    fmt.Sprintf("The call-to-action id is used by %d elements.", stats.ctaCount),
+			Suggestion: "Element ids must be unique. Only the first CTA is tracked, link the other buttons to it instead.",
+			Count:      stats.ctaCount,
+		})
+	}
+
+	if stats.buttonLikeCount > maxCompetingCTAs {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityWarning,
+			Rule:       "competing-ctas",
+			Message:    fmt.Sprintf("The page has %d buttons competing for attention.", stats.buttonLikeCount),
+			Suggestion: "Focus visitors on a single primary action and demote secondary buttons to plain links.",
+			Count:      stats.buttonLikeCount,
+		})
+	}
+
+	if stats.textBeforeCTA > foldTextBudget {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityWarning,
+			Rule:       "cta-above-fold",
+			Message:    fmt.Sprintf("The call-to-action appears after %d characters of text and is likely below the fold.", stats.textBeforeCTA),
+			Suggestion: "Place the call-to-action in the hero section, right after the headline and value proposition.",
+		})
+	}
+
+	if stats.ctaText == "" {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityError,
+			Rule:       "cta-text",
+			Message:    "The call-to-action button has no text.",
+			Suggestion: "Use an action oriented label such as \"Join the waitlist\".",
+		})
+	} else if genericCTATexts[strings.ToLower(stats.ctaText)] {
+		findings = append(findings, AuditFinding{
+			Category:   AuditCategoryConversion,
+			Severity:   AuditSeverityWarning,
+			Rule:       "cta-text",
+			Message:    fmt.Sprintf("The call-to-action text \"%s\" is generic.", stats.ctaText),
+			Suggestion: "Describe what visitors get, e.g. \"Get early access\" instead of \"Submit\".",
+		})
+	}
+
+	return findings
+}
+
+func countSkippedHeadings(levels []int) int {
+	skipped := 0
+	for i := 1; i < len(levels); i++ {
+		if levels[i] > levels[i-1]+1 {
+			skipped++
+		}
+	}
+	return skipped
+}
+
+func shadeFromClass(color string) int {
+	switch color {
+	case "white":
+		return 0
+	case "black":
+		return 1000
+	}
+
+	parts := strings.Split(color, "-")
+	shade, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 500
+	}
+	return shade
+}
+
+func hasBackgroundClass(n *html.Node) bool {
+	for _, class := range strings.Fields(getAttr(n, "class")) {
+		if match := tailwindColorClass.FindStringSubmatch(class); match != nil && match[1] == "bg" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDirectText(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode && strings.TrimSpace(c.Data) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func containsElement(n *html.Node, tag string) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (c.Data == tag || containsElement(c, tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	f(n)
+	return sb.String()
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/microcosm-cc/bluemonday"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 type HTMLValidatorConfig struct {
@@ -13,29 +14,61 @@ type HTMLValidatorConfig struct {
 	CTAButtonID      string
 	AppUrl           string
 	ScrollDebounceMs int
+
+	// StrictSanitization strips every script and inline handler (except our
+	// tracking script) and only allows iframes from AllowedIframeHosts.
+	StrictSanitization bool
+	AllowedIframeHosts []string
+
+	// ShareImageURL and Locale are set per page, they become the og:image and
+	// the lang attribute of the built document.
+	ShareImageURL string
+	Locale        string
+
+	// Survey is shown by the tracking script after the CTA click, nil disables it.
+	Survey *Survey
 }
 
 func GetValidatedHTML(
 	bodyContent, metaTitle, metaDescription, ideaID, mvpID string, cfg HTMLValidatorConfig,
 ) (string, error) {
+	fullHTML, _, err := GetValidatedHTMLWithReport(bodyContent, metaTitle, metaDescription, ideaID, mvpID, cfg)
+	return fullHTML, err
+}
+
+// GetValidatedHTMLWithReport works like GetValidatedHTML but also returns what
+// the strict policy removed. The report is empty when strict mode is disabled.
+func GetValidatedHTMLWithReport(
+	bodyContent, metaTitle, metaDescription, ideaID, mvpID string, cfg HTMLValidatorConfig,
+) (string, *SanitizationReport, error) {
+	report := &SanitizationReport{Removals: []SanitizationRemoval{}}
+
 	// 1. Sanitize the HTML.
-	sanitizedHTML, err := sanitizeHTML(bodyContent)
+	if cfg.StrictSanitization {
+		strictHTML, strictReport, err := strictSanitize(bodyContent, cfg.AllowedIframeHosts)
+		if err != nil {
+			return "", nil, fmt.Errorf("html sanitization failed: %w", err)
+		}
+		bodyContent, report = strictHTML, strictReport
+	}
+
+	sanitizedHTML, err := sanitizeHTML(bodyContent, cfg.StrictSanitization)
 	if err != nil {
-		return "", fmt.Errorf("html sanitization failed: %w", err)
+		return "", nil, fmt.Errorf("html sanitization failed: %w", err)
 	}
 
 	// 2. Validate the HTML (e.g., check for CTA button).
 	if err := validateHTML(sanitizedHTML, cfg.CTAButtonID); err != nil {
-		return "", fmt.Errorf("html validation failed: %w", err)
+		return "", nil, fmt.Errorf("html validation failed: %w", err)
 	}
 
 	// 3. Build the full HTML document.
-	fullHTML := buildFullHTML(sanitizedHTML, metaTitle, metaDescription, ideaID, mvpID, cfg.CTAButtonID, cfg.TailwindCSSUrl, cfg.AppUrl, cfg.ScrollDebounceMs)
+	fullHTML := buildFullHTML(sanitizedHTML, metaTitle, metaDescription, ideaID, mvpID, cfg.CTAButtonID, cfg.TailwindCSSUrl, cfg.AppUrl, cfg.ScrollDebounceMs, cfg.ShareImageURL, cfg.Locale, cfg.Survey)
 
-	return fullHTML, nil
+	return fullHTML, report, nil
 }
 
-func sanitizeHTML(bodyContent string) (string, error) {
+func sanitizeHTML(bodyContent string, strict bool) (string, error) {
 	p := bluemonday.NewPolicy()
 
 	p.AllowElements(
@@ -43,8 +76,11 @@ func sanitizeHTML(bodyContent string) (string, error) {
 		"article", "aside", "footer", "nav", "h1", "h2", "h3", "h4", "h5", "h6",
 		"p", "span", "div", "strong", "em", "ul", "ol", "li", "img", "a", "button",
 		"form", "input", "label", "textarea", "iframe", "br", "hr", "figure",
-		"figcaption", "blockquote", "style", "script",
+		"figcaption", "blockquote", "style",
 	)
+	if !strict {
+		p.AllowElements("script")
+	}
 
 	p.AllowElements("svg", "path", "rect", "line", "circle", "text", "g", "source", "picture")
 
@@ -54,13 +90,103 @@ func sanitizeHTML(bodyContent string) (string, error) {
 		"role", "aria-labelledby", "aria-label",
 	).Globally()
 
-	p.AllowAttrs("onclick").OnElements("button")
+	if !strict {
+		p.AllowAttrs("onclick").OnElements("button")
+	}
+
+	// "data-*" above is matched literally by bluemonday, data attributes have to be enabled explicitly.
+	// The pricing block relies on them, the tracking script marker is stripped afterwards.
+	p.AllowDataAttributes()
 
 	p.AllowStandardURLs()
 	p.AllowDataURIImages()
 
 	sanitized := p.Sanitize(bodyContent)
-	return sanitized, nil
+	return stripTrackingMarker(sanitized)
+}
+
+const trackingScriptAttr = "data-founder-signal-script"
+
+// stripTrackingMarker removes the marker of the tracking script from the body
+// content. Only buildFullHTML adds the tracking script, a copy found in the
+// content is dropped and the marker is removed from any other element so that
+// the page cannot turn off the tracking.
+func stripTrackingMarker(bodyContent string) (string, error) {
+	if !strings.Contains(strings.ToLower(bodyContent), trackingScriptAttr) {
+		return bodyContent, nil
+	}
+
+	bodyCtx := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(bodyContent), bodyCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse body content: %w", err)
+	}
+
+	isTrackingScript := func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "script" && hasAttr(n, trackingScriptAttr)
+	}
+	var strip func(*html.Node)
+	strip = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			kept := n.Attr[:0]
+			for _, attr := range n.Attr {
+				if attr.Key != trackingScriptAttr {
+					kept = append(kept, attr)
+				}
+			}
+			n.Attr = kept
+		}
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			if isTrackingScript(c) {
+				n.RemoveChild(c)
+			} else {
+				strip(c)
+			}
+			c = next
+		}
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		if isTrackingScript(n) {
+			continue
+		}
+		strip(n)
+		if err := html.Render(&sb, n); err != nil {
+			return "", fmt.Errorf("failed to render body content: %w", err)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// hasTrackingScript reports whether the body content holds a tracking script element.
+func hasTrackingScript(bodyContent string) bool {
+	bodyCtx := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(bodyContent), bodyCtx)
+	if err != nil {
+		return false
+	}
+
+	var found func(*html.Node) bool
+	found = func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "script" && getAttr(n, trackingScriptAttr) == "true" {
+			return true
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if found(c) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, n := range nodes {
+		if found(n) {
+			return true
+		}
+	}
+	return false
 }
 
 // validateHTML checks for the CTA button's presence using Go's html parser.
@@ -97,35 +223,84 @@ func validateHTML(sanitizedHTML, ctaBtnID string) error {
 }
 
 // buildFullHTML creates the complete HTML document.
-func buildFullHTML(bodyContent, metaTitle, metaDescription, ideaID, mvpID, ctaBtnID, tailwindCssUrl, appUrl string, scrollDebounceMs int) string {
+func buildFullHTML(bodyContent, metaTitle, metaDescription, ideaID, mvpID, ctaBtnID, tailwindCssUrl, appUrl string, scrollDebounceMs int, shareImageURL, locale string, survey *Survey) string {
 	var trackingScript string
 	// Check if the tracking script already exists in the content
-	if !strings.Contains(bodyContent, `data-founder-signal-script="true"`) {
-		trackingScript = getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl, scrollDebounceMs)
+	if !hasTrackingScript(bodyContent) {
+		trackingScript = getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl, scrollDebounceMs, survey)
+	}
+
+	socialMeta := getSocialMeta(metaTitle, metaDescription, shareImageURL)
+
+	if locale == "" {
+		locale = "en"
 	}
 
 	return fmt.Sprintf(`<!DOCTYPE html>
-<html lang="en">
+<html lang="%s">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>%s</title>
     <meta name="description" content="%s">
+    %s
     <link href="%s" rel="stylesheet">
 </head>
 <body>
     %s
     %s
 </body>
-</html>`, metaTitle, metaDescription, tailwindCssUrl, bodyContent, trackingScript)
+</html>`, html.EscapeString(locale), html.EscapeString(metaTitle), html.EscapeString(metaDescription), socialMeta, tailwindCssUrl, bodyContent, trackingScript)
+}
+
+// getSocialMeta builds the Open Graph and Twitter card tags, the large image
+// card is only used when a share image is available.
+func getSocialMeta(metaTitle, metaDescription, shareImageURL string) string {
+	tags := []string{
+		`<meta property="og:type" content="website">`,
+		fmt.Sprintf(`<meta property="og:title" content="%s">`, html.EscapeString(metaTitle)),
+		fmt.Sprintf(`<meta property="og:description" content="%s">`, html.EscapeString(metaDescription)),
+	}
+
+	if shareImageURL == "" {
+		tags = append(tags, `<meta name="twitter:card" content="summary">`)
+		return strings.Join(tags, "\n    ")
+	}
+
+	escapedImageURL := html.EscapeString(shareImageURL)
+	tags = append(tags,
+		fmt.Sprintf(`<meta property="og:image" content="%s">`, escapedImageURL),
+		`<meta property="og:image:width" content="1200">`,
+		`<meta property="og:image:height" content="630">`,
+		`<meta name="twitter:card" content="summary_large_image">`,
+		fmt.Sprintf(`<meta name="twitter:image" content="%s">`, escapedImageURL),
+	)
+
+	return strings.Join(tags, "\n    ")
 }
 
-func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs int) string {
+func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs int, survey *Survey) string {
 	scriptTemplate := `<script data-founder-signal-script="true" data-cfasync="false">(function() {
             const ideaId = "%s";
             const mvpId = "%s";
             const appUrl = "%s";
             const ctaButtonId = "%s";
+            const survey = %s;
+
+            // A per browser id, it tells waitlist referrals apart from self-referrals
+            const getVisitorId = () => {
+                try {
+                    let visitorId = window.localStorage.getItem('fs_visitor_id');
+                    if (!visitorId) {
+                        visitorId = Math.random().toString(36).slice(2) + Date.now().toString(36);
+                        window.localStorage.setItem('fs_visitor_id', visitorId);
+                    }
+                    return visitorId;
+                } catch (e) {
+                    return undefined;
+                }
+            };
+            const visitorId = getVisitorId();
 
             // Helper function to post tracking events
             const postTrackEvent = (eventType, metadata) => {
@@ -135,11 +310,96 @@ func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs
                         eventType: eventType,
                         ideaId: ideaId,
                         mvpId: mvpId,
-                        metadata: metadata
+                        metadata: Object.assign({ locale: document.documentElement.lang || 'en', visitorId: visitorId }, metadata)
                     }, appUrl);
                 }
             };
 
+            // Post-signup survey, the answers are reported as a survey_response event
+            const showSurvey = () => {
+                const overlay = document.createElement('div');
+                overlay.setAttribute('data-fs-survey', 'true');
+                overlay.style.cssText = 'position:fixed;inset:0;background:rgba(17,24,39,0.6);display:flex;align-items:center;justify-content:center;z-index:2147483647;padding:16px;';
+
+                const form = document.createElement('form');
+                form.style.cssText = 'background:#fff;color:#111827;border-radius:12px;max-width:480px;width:100%%;max-height:90vh;overflow:auto;padding:24px;';
+
+                const heading = document.createElement('h2');
+                heading.textContent = survey.title;
+                heading.style.cssText = 'font-size:1.25rem;font-weight:700;margin:0 0 16px;';
+                form.appendChild(heading);
+
+                survey.questions.forEach(function(question) {
+                    const fieldset = document.createElement('fieldset');
+                    fieldset.style.cssText = 'border:0;margin:0 0 16px;padding:0;';
+                    const legend = document.createElement('legend');
+                    legend.textContent = question.prompt + (question.required ? ' *' : '');
+                    legend.style.cssText = 'font-weight:600;margin-bottom:8px;';
+                    fieldset.appendChild(legend);
+
+                    if (question.type === 'choice') {
+                        (question.options || []).forEach(function(option) {
+                            const label = document.createElement('label');
+                            label.style.cssText = 'display:block;margin:4px 0;cursor:pointer;';
+                            const input = document.createElement('input');
+                            input.type = 'radio';
+                            input.name = question.id;
+                            input.value = option;
+                            input.required = !!question.required;
+                            input.style.marginRight = '8px';
+                            label.appendChild(input);
+                            label.appendChild(document.createTextNode(option));
+                            fieldset.appendChild(label);
+                        });
+                    } else {
+                        const textarea = document.createElement('textarea');
+                        textarea.name = question.id;
+                        textarea.rows = 3;
+                        textarea.maxLength = 500;
+                        textarea.required = !!question.required;
+                        textarea.style.cssText = 'width:100%%;border:1px solid #d1d5db;border-radius:8px;padding:8px;';
+                        fieldset.appendChild(textarea);
+                    }
+                    form.appendChild(fieldset);
+                });
+
+                const actions = document.createElement('div');
+                actions.style.cssText = 'display:flex;justify-content:flex-end;gap:8px;';
+                const skip = document.createElement('button');
+                skip.type = 'button';
+                skip.textContent = 'Skip';
+                skip.style.cssText = 'padding:8px 16px;border-radius:8px;background:#f3f4f6;';
+                skip.addEventListener('click', function() { overlay.remove(); });
+                const submit = document.createElement('button');
+                submit.type = 'submit';
+                submit.textContent = 'Send';
+                submit.style.cssText = 'padding:8px 16px;border-radius:8px;background:#4f46e5;color:#fff;font-weight:600;';
+                actions.appendChild(skip);
+                actions.appendChild(submit);
+                form.appendChild(actions);
+
+                form.addEventListener('submit', function(event) {
+                    event.preventDefault();
+                    const data = new FormData(form);
+                    const answers = [];
+                    survey.questions.forEach(function(question) {
+                        const value = (data.get(question.id) || '').toString().trim();
+                        if (!value) return;
+                        answers.push(question.type === 'choice'
+                            ? { questionId: question.id, choice: value }
+                            : { questionId: question.id, text: value });
+                    });
+                    if (answers.length > 0) {
+                        postTrackEvent('survey_response', { answers: answers });
+                    }
+                    overlay.remove();
+                    alert('Thanks for your answers!');
+                });
+
+                overlay.appendChild(form);
+                document.body.appendChild(overlay);
+            };
+
             // 1. Track Page View
             postTrackEvent('pageview', { path: window.location.pathname, title: document.title });
 
@@ -151,11 +411,29 @@ func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs
                         buttonText: $ctaButton.innerText,
                         ctaElementId: $ctaButton.id
                     });
-                    alert('Thanks for your interest!');
+                    if (survey && survey.questions && survey.questions.length > 0) {
+                        showSurvey();
+                    } else {
+                        alert('Thanks for your interest!');
+                    }
                 });
             }
 
-            // 3. Track Scroll Depth
+            // 3. Track Pricing Tier Clicks (fake-door pricing test)
+            document.querySelectorAll('[data-fs-pricing-tier]').forEach(function($tier) {
+                $tier.addEventListener('click', function() {
+                    postTrackEvent('pricing_click', {
+                        tierId: $tier.dataset.fsPricingTier,
+                        tierName: $tier.dataset.fsPricingName,
+                        price: parseFloat($tier.dataset.fsPricingPrice),
+                        currency: $tier.dataset.fsPricingCurrency,
+                        billingPeriod: $tier.dataset.fsPricingPeriod
+                    });
+                    alert('Thanks! This plan is not available yet, we will let you know when it launches.');
+                });
+            });
+
+            // 4. Track Scroll Depth
             let scrollReached = { '25': false, '50': false, '75': false, '100': false };
             let scrollTimeout;
             function handleScroll() {
@@ -186,7 +464,7 @@ func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs
             handleScroll();
             window.addEventListener('scroll', handleScroll, { passive: true });
 
-            // 4. Track Time on Page
+            // 5. Track Time on Page
             let startTime = Date.now();
             const sendTimeOnPage = () => {
                 const durationSeconds = Math.round((Date.now() - startTime) / 1000);
@@ -208,6 +486,7 @@ func getTrackingScript(ideaID, mvpID, ctaBtnID, appUrl string, scrollDebounceMs
 		mvpID,
 		appUrl,
 		ctaBtnID,
+		surveyScriptData(survey),
 		scrollDebounceMs,
 	)
 }
@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const pricingBlockAttr = "data-fs-pricing-block"
+
+// PricingTier is a single fake-door plan shown in the pricing block.
+type PricingTier struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Price         float64  `json:"price"`
+	Currency      string   `json:"currency"`
+	BillingPeriod string   `json:"billingPeriod"` // month, year or once
+	Features      []string `json:"features,omitempty"`
+	Highlighted   bool     `json:"highlighted,omitempty"`
+}
+
+// PricingBlock is the founder configured pricing section inserted into a landing page.
+type PricingBlock struct {
+	Enabled    bool          `json:"enabled"`
+	Heading    string        `json:"heading"`
+	Subheading string        `json:"subheading,omitempty"`
+	Tiers      []PricingTier `json:"tiers"`
+}
+
+// FormatPrice renders a price with its currency and billing period, e.g. "USD 19/month".
+func FormatPrice(price float64, currency, billingPeriod string) string {
+	amount := strconv.FormatFloat(price, 'f', -1, 64)
+	if billingPeriod == "" || billingPeriod == "once" {
+		return fmt.Sprintf("%s %s", currency, amount)
+	}
+	return fmt.Sprintf("%s %s/%s", currency, amount, billingPeriod)
+}
+
+// BuildPricingBlock renders the pricing section. Tier buttons carry data
+// attributes which the tracking script uses to report pricing_click events.
+func BuildPricingBlock(block PricingBlock) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, `<section %s="true" class="py-16 px-4 bg-gray-50" aria-labelledby="fs-pricing-heading">`, pricingBlockAttr)
+	sb.WriteString(`<div class="max-w-5xl mx-auto text-center">`)
+	fmt.Fprintf(&sb, `<h2 id="fs-pricing-heading" class="text-3xl font-bold text-gray-900">%s</h2>`, html.EscapeString(block.Heading))
+	if block.Subheading != "" {
+		fmt.Fprintf(&sb, `<p class="mt-2 text-gray-600">%s</p>`, html.EscapeString(block.Subheading))
+	}
+
+	sb.WriteString(`<div class="mt-10 grid gap-6 md:grid-cols-3">`)
+	for _, tier := range block.Tiers {
+		cardClass := "rounded-lg border border-gray-200 bg-white p-6 flex flex-col"
+		if tier.Highlighted {
+			cardClass = "rounded-lg border-2 border-indigo-600 bg-white p-6 flex flex-col shadow-lg"
+		}
+
+		fmt.Fprintf(&sb, `<div class="%s">`, cardClass)
+		fmt.Fprintf(&sb, `<h3 class="text-xl font-semibold text-gray-900">%s</h3>`, html.EscapeString(tier.Name))
+		fmt.Fprintf(&sb, `<p class="mt-4 text-3xl font-bold text-gray-900">%s</p>`, html.EscapeString(FormatPrice(tier.Price, tier.Currency, tier.BillingPeriod)))
+
+		if len(tier.Features) > 0 {
+			sb.WriteString(`<ul class="mt-6 space-y-2 text-left text-gray-700 flex-1">`)
+			for _, feature := range tier.Features {
+				fmt.Fprintf(&sb, `<li>%s</li>`, html.EscapeString(feature))
+			}
+			sb.WriteString(`</ul>`)
+		}
+
+		fmt.Fprintf(&sb,
+			`<button type="button" class="mt-6 w-full rounded-md bg-indigo-600 px-4 py-2 text-white font-semibold" data-fs-pricing-tier="%s" data-fs-pricing-name="%s" data-fs-pricing-price="%s" data-fs-pricing-currency="%s" data-fs-pricing-period="%s">Choose %s</button>`,
+			html.EscapeString(tier.ID),
+			html.EscapeString(tier.Name),
+			strconv.FormatFloat(tier.Price, 'f', -1, 64),
+			html.EscapeString(tier.Currency),
+			html.EscapeString(tier.BillingPeriod),
+			html.EscapeString(tier.Name),
+		)
+		sb.WriteString(`</div>`)
+	}
+	sb.WriteString(`</div></div></section>`)
+
+	return sb.String()
+}
+
+// InjectPricingBlock replaces any existing pricing block in the body content
+// with the given one. The block goes before the footer when there is one.
+// A disabled block only removes the existing one.
+func InjectPricingBlock(bodyContent string, block PricingBlock) (string, error) {
+	content, err := removeElementsWithAttr(bodyContent, pricingBlockAttr)
+	if err != nil {
+		return "", err
+	}
+
+	if !block.Enabled || len(block.Tiers) == 0 {
+		return content, nil
+	}
+
+	pricingHTML := BuildPricingBlock(block)
+	if idx := strings.LastIndex(strings.ToLower(content), "<footer"); idx != -1 {
+		return content[:idx] + pricingHTML + content[idx:], nil
+	}
+
+	return content + pricingHTML, nil
+}
+
+// ExtractPage splits a full landing page document (as built by buildFullHTML)
+// back into its body content, title and description. The tracking script is
+// dropped since it is re-added whenever the page is rebuilt.
+func ExtractPage(fullHTML string) (bodyContent, metaTitle, metaDescription string, err error) {
+	doc, err := nethtml.Parse(strings.NewReader(fullHTML))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	var body *nethtml.Node
+	var walk func(*nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		if n.Type == nethtml.ElementNode {
+			switch n.Data {
+			case "title":
+				metaTitle = textContent(n)
+			case "meta":
+				if getAttr(n, "name") == "description" {
+					metaDescription = getAttr(n, "content")
+				}
+			case "body":
+				body = n
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if body == nil {
+		return "", "", "", fmt.Errorf("page has no body")
+	}
+
+	var sb strings.Builder
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == nethtml.ElementNode && c.Data == "script" && getAttr(c, "data-founder-signal-script") == "true" {
+			continue
+		}
+		if err := nethtml.Render(&sb, c); err != nil {
+			return "", "", "", fmt.Errorf("failed to render page body: %w", err)
+		}
+	}
+
+	return strings.TrimSpace(sb.String()), strings.TrimSpace(metaTitle), metaDescription, nil
+}
+
+// removeElementsWithAttr drops every element carrying attr from the body content.
+func removeElementsWithAttr(bodyContent, attr string) (string, error) {
+	if !strings.Contains(bodyContent, attr) {
+		return bodyContent, nil
+	}
+
+	bodyCtx := &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(bodyContent), bodyCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse body content: %w", err)
+	}
+
+	var prune func(*nethtml.Node)
+	prune = func(n *nethtml.Node) {
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			if c.Type == nethtml.ElementNode && hasAttr(c, attr) {
+				n.RemoveChild(c)
+			} else {
+				prune(c)
+			}
+			c = next
+		}
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		if n.Type == nethtml.ElementNode && hasAttr(n, attr) {
+			continue
+		}
+		prune(n)
+		if err := nethtml.Render(&sb, n); err != nil {
+			return "", fmt.Errorf("failed to render body content: %w", err)
+		}
+	}
+
+	return sb.String(), nil
+}
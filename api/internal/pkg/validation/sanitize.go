@@ -0,0 +1,234 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type RemovalKind string
+
+const (
+	RemovalKindScript         RemovalKind = "script"
+	RemovalKindEventHandler   RemovalKind = "event_handler"
+	RemovalKindIframe         RemovalKind = "iframe"
+	RemovalKindUnsafeURL      RemovalKind = "unsafe_url"
+	RemovalKindDisallowedNode RemovalKind = "disallowed_element"
+)
+
+// DefaultAllowedIframeHosts is used when strict sanitization is enabled
+// without an explicit iframe allowlist.
+var DefaultAllowedIframeHosts = []string{
+	"www.youtube.com",
+	"www.youtube-nocookie.com",
+	"player.vimeo.com",
+	"www.loom.com",
+}
+
+// disallowedElements are never useful on a landing page and can be used to
+// load or execute foreign content.
+var disallowedElements = map[string]bool{
+	"object": true, "embed": true, "applet": true, "base": true, "frame": true, "frameset": true,
+}
+
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true, "xlink:href": true, "srcset": true,
+}
+
+// SanitizationRemoval describes a group of identical removals made while sanitizing.
+type SanitizationRemoval struct {
+	Kind    RemovalKind `json:"kind"`
+	Element string      `json:"element"`
+	Detail  string      `json:"detail,omitempty"`
+	Reason  string      `json:"reason"`
+	Count   int         `json:"count"`
+}
+
+// SanitizationReport lists everything the strict policy removed from the page.
+type SanitizationReport struct {
+	Strict       bool                  `json:"strict"`
+	TotalRemoved int                   `json:"totalRemoved"`
+	Removals     []SanitizationRemoval `json:"removals"`
+}
+
+type removalKey struct {
+	kind    RemovalKind
+	element string
+	detail  string
+}
+
+type strictSanitizer struct {
+	allowedIframeHosts map[string]bool
+	removals           map[removalKey]*SanitizationRemoval
+}
+
+// strictSanitize removes scripts, inline event handlers, unsafe URLs and non
+// allowlisted iframes from the body content. Our own tracking script is
+// dropped silently since buildFullHTML always injects a fresh copy of it.
+func strictSanitize(bodyContent string, allowedIframeHosts []string) (string, *SanitizationReport, error) {
+	if len(allowedIframeHosts) == 0 {
+		allowedIframeHosts = DefaultAllowedIframeHosts
+	}
+
+	s := &strictSanitizer{
+		allowedIframeHosts: make(map[string]bool, len(allowedIframeHosts)),
+		removals:           make(map[removalKey]*SanitizationRemoval),
+	}
+	for _, host := range allowedIframeHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" {
+			s.allowedIframeHosts[host] = true
+		}
+	}
+
+	bodyCtx := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(bodyContent), bodyCtx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse html for sanitization: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		if s.shouldRemove(n) {
+			continue
+		}
+		s.clean(n)
+		if err := html.Render(&sb, n); err != nil {
+			return "", nil, fmt.Errorf("failed to render sanitized html: %w", err)
+		}
+	}
+
+	return sb.String(), s.report(), nil
+}
+
+// clean walks the children of n removing anything the strict policy rejects.
+func (s *strictSanitizer) clean(n *html.Node) {
+	if n.Type == html.ElementNode {
+		s.cleanAttributes(n)
+	}
+
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if s.shouldRemove(c) {
+			n.RemoveChild(c)
+		} else {
+			s.clean(c)
+		}
+		c = next
+	}
+}
+
+func (s *strictSanitizer) shouldRemove(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	tag := strings.ToLower(n.Data)
+	switch {
+	case tag == "script":
+		if getAttr(n, "data-founder-signal-script") != "true" {
+			s.record(RemovalKindScript, tag, scriptDetail(n), "Custom scripts are not allowed on hosted pages")
+		}
+		return true
+	case tag == "iframe":
+		src := getAttr(n, "src")
+		if host, ok := s.isAllowedIframe(src); !ok {
+			s.record(RemovalKindIframe, tag, host, "Only embeds from allowlisted providers are allowed")
+			return true
+		}
+	case disallowedElements[tag]:
+		s.record(RemovalKindDisallowedNode, tag, "", "This element can load external content and is not allowed")
+		return true
+	}
+
+	return false
+}
+
+func (s *strictSanitizer) cleanAttributes(n *html.Node) {
+	kept := n.Attr[:0]
+	for _, attr := range n.Attr {
+		key := strings.ToLower(attr.Key)
+		switch {
+		case strings.HasPrefix(key, "on"):
+			s.record(RemovalKindEventHandler, n.Data, key, "Inline event handlers can run arbitrary JavaScript")
+			continue
+		case urlAttributes[key] && isUnsafeURL(attr.Val):
+			s.record(RemovalKindUnsafeURL, n.Data, key, "Script URLs are not allowed in links or sources")
+			continue
+		}
+		kept = append(kept, attr)
+	}
+	n.Attr = kept
+}
+
+// isAllowedIframe reports whether src points to an allowlisted https host,
+// it also returns the host so the removal report can name it.
+func (s *strictSanitizer) isAllowedIframe(src string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return host, u.Scheme == "https" && s.allowedIframeHosts[host]
+}
+
+func (s *strictSanitizer) record(kind RemovalKind, element, detail, reason string) {
+	key := removalKey{kind: kind, element: element, detail: detail}
+	if r, ok := s.removals[key]; ok {
+		r.Count++
+		return
+	}
+
+	s.removals[key] = &SanitizationRemoval{
+		Kind:    kind,
+		Element: element,
+		Detail:  detail,
+		Reason:  reason,
+		Count:   1,
+	}
+}
+
+func (s *strictSanitizer) report() *SanitizationReport {
+	report := &SanitizationReport{
+		Strict:   true,
+		Removals: make([]SanitizationRemoval, 0, len(s.removals)),
+	}
+
+	for _, r := range s.removals {
+		report.Removals = append(report.Removals, *r)
+		report.TotalRemoved += r.Count
+	}
+
+	sort.Slice(report.Removals, func(i, j int) bool {
+		a, b := report.Removals[i], report.Removals[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Element != b.Element {
+			return a.Element < b.Element
+		}
+		return a.Detail < b.Detail
+	})
+
+	return report
+}
+
+// scriptDetail describes a removed script by its source, or marks it as inline.
+func scriptDetail(n *html.Node) string {
+	if src := getAttr(n, "src"); src != "" {
+		return src
+	}
+	return "inline"
+}
+
+func isUnsafeURL(val string) bool {
+	v := strings.ToLower(strings.Join(strings.Fields(val), ""))
+	return strings.HasPrefix(v, "javascript:") ||
+		strings.HasPrefix(v, "vbscript:") ||
+		strings.HasPrefix(v, "data:text/html")
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"encoding/json"
+)
+
+type SurveyQuestionType string
+
+const (
+	SurveyQuestionChoice SurveyQuestionType = "choice"
+	SurveyQuestionText   SurveyQuestionType = "text"
+)
+
+// SurveyQuestion is a single question of the post-signup survey.
+type SurveyQuestion struct {
+	ID       string             `json:"id"`
+	Prompt   string             `json:"prompt"`
+	Type     SurveyQuestionType `json:"type"`
+	Options  []string           `json:"options,omitempty"` // choice questions only
+	Required bool               `json:"required,omitempty"`
+}
+
+// Survey is the founder configured micro-survey shown after the CTA click.
+type Survey struct {
+	Enabled   bool             `json:"enabled"`
+	Title     string           `json:"title"`
+	Questions []SurveyQuestion `json:"questions"`
+}
+
+// Question returns the question with the given id.
+func (s *Survey) Question(id string) (*SurveyQuestion, bool) {
+	for i := range s.Questions {
+		if s.Questions[i].ID == id {
+			return &s.Questions[i], true
+		}
+	}
+	return nil, false
+}
+
+// HasOption reports whether the choice is one of the question options.
+func (q *SurveyQuestion) HasOption(choice string) bool {
+	for _, option := range q.Options {
+		if option == choice {
+			return true
+		}
+	}
+	return false
+}
+
+// surveyScriptData renders the survey as a JavaScript literal for the tracking
+// script, json.Marshal escapes <, > and & so the survey cannot close the script tag.
+func surveyScriptData(survey *Survey) string {
+	if survey == nil || !survey.Enabled || len(survey.Questions) == 0 {
+		return "null"
+	}
+
+	data, err := json.Marshal(survey)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
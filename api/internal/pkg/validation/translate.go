@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// translatableAttrs are attributes whose values are shown to visitors.
+var translatableAttrs = map[string]bool{
+	"alt": true, "title": true, "placeholder": true, "aria-label": true,
+}
+
+type textSegment struct {
+	node *html.Node
+	attr int // index into node.Attr, -1 for text nodes
+}
+
+// TextSegments holds the visible text of a landing page body so it can be
+// translated without touching the markup, classes or element ids (the CTA
+// button keeps its id).
+type TextSegments struct {
+	roots    []*html.Node
+	segments []textSegment
+}
+
+// ExtractTextSegments collects the text nodes and visible attributes of the body content.
+func ExtractTextSegments(bodyContent string) (*TextSegments, error) {
+	bodyCtx := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(bodyContent), bodyCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse body content: %w", err)
+	}
+
+	t := &TextSegments{roots: nodes}
+	for _, n := range nodes {
+		t.collect(n)
+	}
+
+	return t, nil
+}
+
+func (t *TextSegments) collect(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if hasLetters(n.Data) {
+			t.segments = append(t.segments, textSegment{node: n, attr: -1})
+		}
+		return
+	case html.ElementNode:
+		if n.Data == "script" || n.Data == "style" || n.Data == "svg" {
+			return
+		}
+		for i, attr := range n.Attr {
+			if translatableAttrs[attr.Key] && hasLetters(attr.Val) {
+				t.segments = append(t.segments, textSegment{node: n, attr: i})
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		t.collect(c)
+	}
+}
+
+// Texts returns the trimmed text of every segment, in document order.
+func (t *TextSegments) Texts() []string {
+	texts := make([]string, len(t.segments))
+	for i, seg := range t.segments {
+		if seg.attr >= 0 {
+			texts[i] = strings.TrimSpace(seg.node.Attr[seg.attr].Val)
+		} else {
+			texts[i] = strings.TrimSpace(seg.node.Data)
+		}
+	}
+	return texts
+}
+
+// Apply replaces every segment with its translation, keeping the surrounding
+// whitespace of text nodes, and renders the body content again.
+func (t *TextSegments) Apply(translations []string) (string, error) {
+	if len(translations) != len(t.segments) {
+		return "", fmt.Errorf("expected %d translations, got %d", len(t.segments), len(translations))
+	}
+
+	for i, seg := range t.segments {
+		if seg.attr >= 0 {
+			seg.node.Attr[seg.attr].Val = translations[i]
+			continue
+		}
+
+		original := seg.node.Data
+		leading := original[:len(original)-len(strings.TrimLeftFunc(original, unicode.IsSpace))]
+		trailing := original[len(strings.TrimRightFunc(original, unicode.IsSpace)):]
+		seg.node.Data = leading + translations[i] + trailing
+	}
+
+	var sb strings.Builder
+	for _, n := range t.roots {
+		if err := html.Render(&sb, n); err != nil {
+			return "", fmt.Errorf("failed to render translated content: %w", err)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func hasLetters(s string) bool {
+	return strings.IndexFunc(s, unicode.IsLetter) != -1
+}
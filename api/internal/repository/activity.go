@@ -3,13 +3,17 @@ package repository
 import (
 	"context"
 	"foundersignal/internal/domain"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type ActivityRepository interface {
 	Create(ctx context.Context, activity *domain.Activity) error
 	GetForUser(ctx context.Context, userID string, limit int) ([]domain.Activity, error)
+	// CountIdeaReports counts the content reports of the idea itself since the time, reports of its comments are left out
+	CountIdeaReports(ctx context.Context, ideaID uuid.UUID, since time.Time) (int64, error)
 }
 
 type activityRepository struct {
@@ -37,3 +41,12 @@ func (r *activityRepository) GetForUser(ctx context.Context, userID string, limi
 	err := query.Find(&activities).Error
 	return activities, err
 }
+
+func (r *activityRepository) CountIdeaReports(ctx context.Context, ideaID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Activity{}).
+		Where("idea_id = ? AND type = ? AND reference_id = ? AND created_at >= ?", ideaID, domain.ActivityTypeContentReported, ideaID.String(), since).
+		Count(&count).Error
+	return count, err
+}
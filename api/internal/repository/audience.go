@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"foundersignal/internal/domain"
 	"strings"
 	"time"
@@ -13,12 +14,50 @@ import (
 
 type AudienceRepository interface {
 	GetForFounder(ctx context.Context, founderId string, queryParams domain.QueryParams) ([]*domain.AudienceMember, int64, error)
-	Upsert(ctx context.Context, ideaID, mvpId uuid.UUID, userID string, userEmail string) (*domain.AudienceMember, error)
+	Upsert(ctx context.Context, member *domain.AudienceMember) (*domain.AudienceMember, error)
+	GetMember(ctx context.Context, mvpId uuid.UUID, userID string) (*domain.AudienceMember, error)
+	GetByReferralCode(ctx context.Context, code string) (*domain.AudienceMember, error)
+	GetLatestByFingerprint(ctx context.Context, mvpId uuid.UUID, fingerprint string) (*domain.AudienceMember, error)
+	SetReferralCode(ctx context.Context, mvpId uuid.UUID, userID, code string) error
+	GetWaitlistEntry(ctx context.Context, ideaId uuid.UUID, userID string, positionBoost int) (*domain.WaitlistEntry, error)
+	GetReferralLeaderboard(ctx context.Context, ideaId uuid.UUID, positionBoost, limit int) ([]domain.WaitlistEntry, error)
+	GetWaitlistSize(ctx context.Context, ideaId uuid.UUID) (int64, error)
 	GetByIdeaId(ctx context.Context, ideaId uuid.UUID) ([]*domain.AudienceMember, error)
 	GetSignupsByIdeaIds(ctx context.Context, ideaIds []uuid.UUID, from, to time.Time) (map[uuid.UUID]map[string]int, error)
 	GetRecentByUserIdeas(ctx context.Context, userID string, limit int) ([]domain.AudienceMember, error)
 	GetCountByIdeaId(ctx context.Context, ideaId uuid.UUID, from, to *time.Time) (int64, error)
-	GetCountForIdeaOwner(ctx context.Context, ideaOwnerId string, start, end *time.Time) (int64, error)
+	GetCountForIdeas(ctx context.Context, spec IdeaQuerySpec, start, end *time.Time) (int64, error)
+	GetCountByMVPId(ctx context.Context, mvpId uuid.UUID) (int64, error)
+}
+
+// a member signs up once per MVP, so the user and the MVP identify the row
+const audienceMemberKey = "audience_members.user_id || ':' || audience_members.mvp_simulator_id::text"
+
+func audienceMemberId(m *domain.AudienceMember) string {
+	return m.UserID + ":" + m.MVPSimulatorID.String()
+}
+
+var audienceKeysets = map[string]keyset[*domain.AudienceMember]{
+	"newest": {column: "audience_members.signup_time", cast: "timestamptz", desc: true, id: audienceMemberKey, textId: true,
+		value: func(m *domain.AudienceMember) string { return timeKey(m.SignupTime) }, rowId: audienceMemberId},
+	"oldest": {column: "audience_members.signup_time", cast: "timestamptz", id: audienceMemberKey, textId: true,
+		value: func(m *domain.AudienceMember) string { return timeKey(m.SignupTime) }, rowId: audienceMemberId},
+}
+
+func audienceSort(sortBy string) string {
+	if _, ok := audienceKeysets[sortBy]; ok {
+		return sortBy
+	}
+	return "newest"
+}
+
+// NextAudienceCursor returns the cursor of the page after members, listed by GetForFounder
+func NextAudienceCursor(queryParams domain.QueryParams, members []*domain.AudienceMember) string {
+	sort := audienceSort(queryParams.SortBy)
+	if isSearched(queryParams) {
+		return audienceKeysets[sort].nextRanked(sort, members, queryParams.Limit, func(m *domain.AudienceMember) float64 { return m.SearchRank })
+	}
+	return audienceKeysets[sort].next(sort, members, queryParams.Limit)
 }
 
 type audienceRepository struct {
@@ -34,8 +73,8 @@ func (r *audienceRepository) GetForFounder(ctx context.Context, founderId string
 
 	query := r.db.WithContext(ctx).
 		Model(&domain.AudienceMember{}).
-		Joins("JOIN ideas ON ideas.id = audience_members.idea_id").
-		Where("ideas.user_id = ?", founderId)
+		Joins("JOIN ideas ON ideas.id = audience_members.idea_id")
+	query = applyIdeaQuerySpec(query, DashboardScope(founderId, queryParams.WorkspaceID))
 
 	var tsQueryString string
 	if queryParams.Search != "" {
@@ -61,22 +100,19 @@ func (r *audienceRepository) GetForFounder(ctx context.Context, founderId string
 		return []*domain.AudienceMember{}, 0, nil
 	}
 
-	orderClause := "audience_members.signup_time DESC"
-	switch queryParams.SortBy {
-	case "newest":
-		orderClause = "audience_members.signup_time DESC"
-	case "oldest":
-		orderClause = "audience_members.signup_time ASC"
-	default:
-		// default already set;
+	var rank *searchRank
+	if tsQueryString != "" {
+		rank = &searchRank{
+			expr: "ts_rank(ideas.search_vector, to_tsquery('english', ?)) + ts_rank(to_tsvector('english', audience_members.user_email), to_tsquery('english', ?))",
+			args: []interface{}{tsQueryString, tsQueryString},
+		}
+		query = query.Select("audience_members.*, "+rank.expr+" AS search_rank", rank.args...)
+	} else {
+		query = query.Select("audience_members.*")
 	}
 
-	query = paginateAndOrder(query, queryParams.Limit, queryParams.Offset, orderClause)
-
-	if queryParams.Search != "" {
-		rankExpression := "ts_rank(ideas.search_vector, to_tsquery('english', ?)) + ts_rank(to_tsvector('english', audience_members.user_email), to_tsquery('english', ?))"
-		query = query.Order(gorm.Expr(rankExpression+" DESC", tsQueryString, tsQueryString))
-	}
+	sort := audienceSort(queryParams.SortBy)
+	query = audienceKeysets[sort].paginateRanked(query, sort, queryParams, rank)
 
 	err = query.Preload("Idea").Find(&audienceMembers).Error
 	if err != nil {
@@ -86,12 +122,14 @@ func (r *audienceRepository) GetForFounder(ctx context.Context, founderId string
 	return audienceMembers, count, nil
 }
 
-func (r *audienceRepository) Upsert(ctx context.Context, ideaID, mvpId uuid.UUID, userID string, userEmail string) (*domain.AudienceMember, error) {
+// Upsert creates the member on the first signup, the referral fields are only
+// stored then so a returning visitor cannot be credited to another referrer.
+func (r *audienceRepository) Upsert(ctx context.Context, m *domain.AudienceMember) (*domain.AudienceMember, error) {
 	member := domain.AudienceMember{
-		IdeaID:         ideaID,
-		MVPSimulatorID: mvpId,
-		UserID:         userID,
-		UserEmail:      userEmail,
+		IdeaID:         m.IdeaID,
+		MVPSimulatorID: m.MVPSimulatorID,
+		UserID:         m.UserID,
+		UserEmail:      m.UserEmail,
 	}
 
 	now := time.Now()
@@ -104,23 +142,127 @@ func (r *audienceRepository) Upsert(ctx context.Context, ideaID, mvpId uuid.UUID
 			"engaged":     true,
 		}),
 	}).FirstOrCreate(&member, domain.AudienceMember{
-		IdeaID:         ideaID,
-		MVPSimulatorID: mvpId,
-		UserID:         userID,
-		UserEmail:      userEmail,
-		SignupTime:     time.Now(),
-		LastActive:     &now,
-		Visits:         1,
-		Engaged:        true,
+		IdeaID:            m.IdeaID,
+		MVPSimulatorID:    m.MVPSimulatorID,
+		UserID:            m.UserID,
+		UserEmail:         m.UserEmail,
+		SignupTime:        time.Now(),
+		LastActive:        &now,
+		Visits:            1,
+		Engaged:           true,
+		ReferralCode:      m.ReferralCode,
+		ReferredBy:        m.ReferredBy,
+		SignupFingerprint: m.SignupFingerprint,
 	}).Error
 
 	if err != nil {
 		return nil, err
 	}
 
+	// reload the stored row, on conflict the generated referral code was not written
+	return r.GetMember(ctx, m.MVPSimulatorID, m.UserID)
+}
+
+func (r *audienceRepository) GetMember(ctx context.Context, mvpId uuid.UUID, userID string) (*domain.AudienceMember, error) {
+	var member domain.AudienceMember
+	err := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ? AND user_id = ?", mvpId, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *audienceRepository) GetByReferralCode(ctx context.Context, code string) (*domain.AudienceMember, error) {
+	var member domain.AudienceMember
+	err := r.db.WithContext(ctx).
+		Where("referral_code = ?", code).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
 	return &member, nil
 }
 
+// GetLatestByFingerprint finds the most recent signup of a browser, anonymous members have no stable user id.
+func (r *audienceRepository) GetLatestByFingerprint(ctx context.Context, mvpId uuid.UUID, fingerprint string) (*domain.AudienceMember, error) {
+	var member domain.AudienceMember
+	err := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ? AND signup_fingerprint = ?", mvpId, fingerprint).
+		Order("signup_time DESC").
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *audienceRepository) SetReferralCode(ctx context.Context, mvpId uuid.UUID, userID, code string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.AudienceMember{}).
+		Where("mvp_simulator_id = ? AND user_id = ? AND (referral_code IS NULL OR referral_code = '')", mvpId, userID).
+		Update("referral_code", code).Error
+}
+
+// waitlistQuery ranks the members of an idea by signup time, a member who signed
+// up through several MVPs is ranked once. Referrals from the same fingerprint
+// are counted once and every referral moves the referrer up by the boost.
+const waitlistQuery = `
+WITH members AS (
+	SELECT DISTINCT ON (user_id) user_id, user_email, referral_code, signup_time
+	FROM audience_members
+	WHERE idea_id = ? AND deleted_at IS NULL
+	ORDER BY user_id, signup_time ASC
+), referrals AS (
+	SELECT referred_by, COUNT(DISTINCT COALESCE(NULLIF(signup_fingerprint, ''), user_id)) AS referral_count
+	FROM audience_members
+	WHERE idea_id = ? AND referred_by IS NOT NULL AND deleted_at IS NULL
+	GROUP BY referred_by
+), ranked AS (
+	SELECT m.user_id, m.user_email, COALESCE(m.referral_code, '') AS referral_code, m.signup_time,
+		COALESCE(r.referral_count, 0) AS referral_count,
+		ROW_NUMBER() OVER (ORDER BY m.signup_time ASC, m.user_id ASC) AS base_rank
+	FROM members m
+	LEFT JOIN referrals r ON r.referred_by = m.user_id
+)
+SELECT user_id, user_email, referral_code, signup_time, referral_count,
+	ROW_NUMBER() OVER (ORDER BY base_rank - referral_count * ? ASC, signup_time ASC, user_id ASC) AS position
+FROM ranked`
+
+func (r *audienceRepository) GetWaitlistEntry(ctx context.Context, ideaId uuid.UUID, userID string, positionBoost int) (*domain.WaitlistEntry, error) {
+	var entries []domain.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Raw("SELECT * FROM ("+waitlistQuery+") waitlist WHERE user_id = ?", ideaId, ideaId, positionBoost, userID).
+		Scan(&entries).Error
+	if err != nil {
+		fmt.Println("Error getting waitlist entry:", err)
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &entries[0], nil
+}
+
+func (r *audienceRepository) GetReferralLeaderboard(ctx context.Context, ideaId uuid.UUID, positionBoost, limit int) ([]domain.WaitlistEntry, error) {
+	var entries []domain.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Raw("SELECT * FROM ("+waitlistQuery+") waitlist WHERE referral_count > 0 ORDER BY referral_count DESC, position ASC LIMIT ?", ideaId, ideaId, positionBoost, limit).
+		Scan(&entries).Error
+	if err != nil {
+		fmt.Println("Error getting referral leaderboard:", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 func (r *audienceRepository) GetByIdeaId(ctx context.Context, ideaId uuid.UUID) ([]*domain.AudienceMember, error) {
 	var audienceMembers []*domain.AudienceMember
 
@@ -171,6 +313,7 @@ func (r *audienceRepository) GetRecentByUserIdeas(ctx context.Context, userID st
 	var members []domain.AudienceMember
 
 	query := r.db.WithContext(ctx).
+		Select("audience_members.*").
 		Joins("JOIN ideas ON audience_members.idea_id = ideas.id").
 		Where("ideas.user_id = ?", userID).
 		Order("audience_members.signup_time DESC").
@@ -209,11 +352,12 @@ func (r *audienceRepository) GetCountByIdeaId(
 	return count, nil
 }
 
-func (r *audienceRepository) GetCountForIdeaOwner(ctx context.Context, ideaOwnerId string, start, end *time.Time) (int64, error) {
+// GetCountForIdeas counts the signups of the ideas matching the spec
+func (r *audienceRepository) GetCountForIdeas(ctx context.Context, spec IdeaQuerySpec, start, end *time.Time) (int64, error) {
 	query := r.db.WithContext(ctx).
 		Model(&domain.AudienceMember{}).
-		Joins("JOIN ideas ON ideas.id = audience_members.idea_id").
-		Where("ideas.user_id = ?", ideaOwnerId)
+		Joins("JOIN ideas ON ideas.id = audience_members.idea_id")
+	query = applyIdeaQuerySpec(query, spec)
 
 	if start != nil && end != nil {
 		query = query.Where("audience_members.signup_time BETWEEN ? AND ?", *start, *end)
@@ -231,3 +375,31 @@ func (r *audienceRepository) GetCountForIdeaOwner(ctx context.Context, ideaOwner
 
 	return count, nil
 }
+
+func (r *audienceRepository) GetCountByMVPId(ctx context.Context, mvpId uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.AudienceMember{}).
+		Where("mvp_simulator_id = ?", mvpId).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetWaitlistSize counts distinct members, a member can sign up through several MVPs of an idea.
+func (r *audienceRepository) GetWaitlistSize(ctx context.Context, ideaId uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.AudienceMember{}).
+		Where("idea_id = ?", ideaId).
+		Distinct("user_id").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CategoryRepository interface {
+	GetAll(ctx context.Context) ([]domain.Category, error)
+	FirstOrCreate(ctx context.Context, category *domain.Category) error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepo(db *gorm.DB) *categoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context) ([]domain.Category, error) {
+	var categories []domain.Category
+	if err := r.db.WithContext(ctx).Order("position ASC, name ASC").Find(&categories).Error; err != nil {
+		fmt.Println("Error finding categories:", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// FirstOrCreate loads the category with the same slug, or creates it when there is none.
+func (r *categoryRepository) FirstOrCreate(ctx context.Context, category *domain.Category) error {
+	return r.db.WithContext(ctx).Where("slug = ?", category.Slug).FirstOrCreate(category).Error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"foundersignal/internal/domain"
+	"foundersignal/internal/pkg/sentiment"
 	"strings"
 	"time"
 
@@ -20,12 +21,43 @@ type FeedbackRepository interface {
 	GetCountByIdeaId(ctx context.Context, ideaId uuid.UUID) (int64, error)
 	GetByIdeaWithTimeRange(ctx context.Context, ideaId uuid.UUID, startDate, endDate time.Time) ([]domain.Feedback, error)
 	Delete(ctx context.Context, feedbackId uuid.UUID) error
+
+	// SetSentiment stores the score of the comment, unless the comment was
+	// edited since it was scored. It reports whether the score was stored.
+	// A lexicon score stands in until the AI scores the comment, the comment
+	// stays unscored so that it is retried.
+	SetSentiment(ctx context.Context, feedbackId uuid.UUID, comment string, score float64, source string) (bool, error)
+	// GetUnscored lists the oldest comments whose sentiment was never scored by the AI
+	GetUnscored(ctx context.Context, limit int) ([]domain.Feedback, error)
 }
 
 type fbRepository struct {
 	db *gorm.DB
 }
 
+func feedbackId(feedback domain.Feedback) string { return feedback.ID.String() }
+
+var feedbackKeysets = map[string]keyset[domain.Feedback]{
+	"newest": {column: "created_at", cast: "timestamptz", desc: true, id: "id",
+		value: func(f domain.Feedback) string { return timeKey(f.CreatedAt) }, rowId: feedbackId},
+	"oldest": {column: "created_at", cast: "timestamptz", id: "id",
+		value: func(f domain.Feedback) string { return timeKey(f.CreatedAt) }, rowId: feedbackId},
+}
+
+// feedbackSort lists the newest comments first unless sortBy ends with "asc"
+func feedbackSort(sortBy string) string {
+	if strings.HasSuffix(strings.ToLower(sortBy), "asc") {
+		return "oldest"
+	}
+	return "newest"
+}
+
+// NextFeedbackCursor returns the cursor of the page after feedbacks, listed by GetByIdea
+func NextFeedbackCursor(queryParams domain.QueryParams, feedbacks []domain.Feedback) string {
+	sort := feedbackSort(queryParams.SortBy)
+	return feedbackKeysets[sort].next(sort, feedbacks, queryParams.Limit)
+}
+
 func NewFeedbackRepo(db *gorm.DB) *fbRepository {
 	return &fbRepository{db: db}
 }
@@ -55,10 +87,17 @@ func (r *fbRepository) Add(ctx context.Context, feedback *domain.Feedback) (*dom
 	return feedback, nil
 }
 
+// Update replaces the comment. Its sentiment is reset to neutral until the
+// edited comment is scored again.
 func (r *fbRepository) Update(ctx context.Context, feedbackId uuid.UUID, feedback *domain.Feedback) error {
 	result := r.db.WithContext(ctx).Model(&domain.Feedback{}).
 		Where("id = ?", feedbackId).
-		Updates(feedback)
+		Updates(map[string]interface{}{
+			"comment":             feedback.Comment,
+			"sentiment_score":     sentiment.Neutral,
+			"sentiment_source":    "",
+			"sentiment_scored_at": nil,
+		})
 
 	if result.Error != nil {
 		fmt.Printf("Error updating feedback %s: %v\n", feedbackId, result.Error)
@@ -100,36 +139,16 @@ func (r *fbRepository) GetByIdea(ctx context.Context, ideaId uuid.UUID, queryPar
 			return db.Order("created_at ASC, id ASC").Preload("Reactions")
 		})
 
-	isDescending := true // Default sort direction
-	if queryParams.SortBy != "" && strings.HasSuffix(strings.ToLower(queryParams.SortBy), "asc") {
-		isDescending = false
-	}
-
-	if isDescending {
-		query = query.Order("created_at DESC, id DESC")
-	} else {
-		query = query.Order("created_at ASC, id ASC")
-	}
-
-	// Apply if LastCreatedAt and LastID are provided (i.e., not the first page)
-	if !queryParams.LastCreatedAt.IsZero() && queryParams.LastId.String() != "" {
-		if isDescending {
-			query = query.Where(
-				"(created_at < ?) OR (created_at = ? AND id < ?)",
-				queryParams.LastCreatedAt, queryParams.LastCreatedAt, queryParams.LastId,
-			)
-		} else {
-			query = query.Where(
-				"(created_at > ?) OR (created_at = ? AND id > ?)",
-				queryParams.LastCreatedAt, queryParams.LastCreatedAt, queryParams.LastId,
-			)
-		}
-	}
+	sort := feedbackSort(queryParams.SortBy)
 
-	if queryParams.Limit > 0 {
-		query = query.Limit(queryParams.Limit)
+	// LastCreatedAt and LastId are the keyset of the previous page for the
+	// clients not using cursors yet
+	if queryParams.Cursor == nil && !queryParams.LastCreatedAt.IsZero() && queryParams.LastId != uuid.Nil {
+		queryParams.Cursor = &domain.Cursor{Sort: sort, Value: timeKey(queryParams.LastCreatedAt), ID: queryParams.LastId.String()}
 	}
 
+	query = feedbackKeysets[sort].paginate(query, sort, *queryParams)
+
 	err = query.Find(&feedbacks).Error
 	if err != nil {
 		fmt.Println("Error fetching feedbacks:", err)
@@ -197,3 +216,41 @@ func (r *fbRepository) Delete(ctx context.Context, feedbackId uuid.UUID) error {
 
 	return nil
 }
+
+func (r *fbRepository) SetSentiment(ctx context.Context, feedbackId uuid.UUID, comment string, score float64, source string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Feedback{}).
+		Where("id = ? AND comment = ?", feedbackId, comment).
+		UpdateColumns(sentimentColumns(score, source))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func sentimentColumns(score float64, source string) map[string]interface{} {
+	columns := map[string]interface{}{
+		"sentiment_score":     score,
+		"sentiment_source":    source,
+		"sentiment_scored_at": nil,
+	}
+	if source != domain.SentimentSourceLexicon {
+		columns["sentiment_scored_at"] = time.Now()
+	}
+	return columns
+}
+
+func (r *fbRepository) GetUnscored(ctx context.Context, limit int) ([]domain.Feedback, error) {
+	var feedbacks []domain.Feedback
+
+	err := r.db.WithContext(ctx).
+		Where("sentiment_scored_at IS NULL").
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&feedbacks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return feedbacks, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"foundersignal/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FounderReputation is computed from the public ideas of a founder, private
+// ideas and drafts never count.
+type FounderReputation struct {
+	IdeasLaunched int64 // public ideas that went live, whatever their status now
+	TotalSignups  int64
+}
+
+type FounderProfileRepository interface {
+	GetByUser(ctx context.Context, userId string) (*domain.FounderProfile, error)
+	Save(ctx context.Context, profile *domain.FounderProfile) error
+	GetReputation(ctx context.Context, userId string) (*FounderReputation, error)
+}
+
+type founderProfileRepo struct {
+	db *gorm.DB
+}
+
+func NewFounderProfileRepo(db *gorm.DB) *founderProfileRepo {
+	return &founderProfileRepo{db: db}
+}
+
+func (r *founderProfileRepo) GetByUser(ctx context.Context, userId string) (*domain.FounderProfile, error) {
+	var profile domain.FounderProfile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userId).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Save creates the profile of the user or replaces it
+func (r *founderProfileRepo) Save(ctx context.Context, profile *domain.FounderProfile) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bio", "links", "is_public", "show_ideas", "show_stats", "updated_at"}),
+	}).Create(profile).Error
+}
+
+func (r *founderProfileRepo) GetReputation(ctx context.Context, userId string) (*FounderReputation, error) {
+	launched := r.db.WithContext(ctx).Model(&domain.Idea{}).
+		Where("user_id = ? AND is_private = ? AND status <> ?", userId, false, domain.IdeaStatusDraft)
+
+	var reputation FounderReputation
+	if err := launched.Session(&gorm.Session{}).Count(&reputation.IdeasLaunched).Error; err != nil {
+		return nil, err
+	}
+
+	// a signup is counted once per idea, like in the idea listings
+	signups := r.db.Model(&domain.AudienceMember{}).
+		Select("COUNT(DISTINCT user_id) AS signup_count").
+		Where("idea_id IN (?)", launched.Session(&gorm.Session{}).Select("id")).
+		Group("idea_id")
+	err := r.db.WithContext(ctx).Table("(?) AS s", signups).
+		Select("COALESCE(SUM(s.signup_count), 0)").
+		Scan(&reputation.TotalSignups).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &reputation, nil
+}
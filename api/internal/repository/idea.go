@@ -9,8 +9,10 @@ import (
 	"log"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -19,10 +21,19 @@ type IdeaRepository interface {
 	Update(ctx context.Context, idea *domain.Idea) error
 	Delete(ctx context.Context, ideaId uuid.UUID) error
 	GetIdeas(ctx context.Context, queryParams domain.QueryParams, spec IdeaQuerySpec) ([]*domain.Idea, int64, error)
-	GetByID(ctx context.Context, id uuid.UUID, getRelatedIdeas, withUser *bool) (*domain.Idea, []*domain.Idea, error)
+	GetByID(ctx context.Context, id uuid.UUID, withUser *bool) (*domain.Idea, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Idea, error)
 	GetByIds(ctx context.Context, ids []uuid.UUID) ([]*domain.Idea, error)
-	GetIdeasWithActivity(ctx context.Context, userID string, from, to time.Time, options ...QueryOption) ([]*response.IdeaWithActivity, error)
-	GetCountForUser(ctx context.Context, userId string, start, end *time.Time, status *domain.IdeaStatus) (int64, error)
+	GetByIdsWithCounts(ctx context.Context, ids []uuid.UUID) ([]*domain.Idea, error)
+	GetSearchRanks(ctx context.Context, search string, spec IdeaQuerySpec, limit int) ([]ScoredIdea, error)
+	GetFacetCounts(ctx context.Context, facet IdeaFacet, spec IdeaQuerySpec, limit int) ([]FacetCount, error)
+	SetTaxonomy(ctx context.Context, ideaId uuid.UUID, categoryId *uuid.UUID, tags []string) error
+	SetImage(ctx context.Context, ideaId uuid.UUID, imageUrl string, variants []domain.IdeaImageVariant) error
+	SetStatus(ctx context.Context, ideaId uuid.UUID, from, to domain.IdeaStatus) (bool, error)
+	RefreshTrendingScores(ctx context.Context, params TrendingParams) (int64, error)
+	GetIdeasWithActivity(ctx context.Context, spec IdeaQuerySpec, from, to time.Time, options ...QueryOption) ([]*response.IdeaWithActivity, error)
+	GetCountCreated(ctx context.Context, spec IdeaQuerySpec, start, end *time.Time) (int64, error)
+	GetCount(ctx context.Context, spec IdeaQuerySpec) (int64, error)
 	GetByUserId(ctx context.Context, userId string) ([]*domain.Idea, error)
 	HardDelete(ctx context.Context, ideaId uuid.UUID) error
 	FindDeletedByTitleAndUserID(ctx context.Context, userID, title string) (*domain.Idea, error)
@@ -35,6 +46,71 @@ type IdeaQuerySpec struct {
 	Status         domain.IdeaStatus
 	WithCounts     bool
 	ByUserId       string
+	AccessibleBy   string      // user id, ideas they own or collaborate on
+	WorkspaceID    *uuid.UUID  // ideas of the workspace
+	Personal       bool        // ideas outside of any workspace
+	CategoryIDs    []uuid.UUID // any of them, a category with its subcategories
+	Tags           []string    // all of them
+	Stage          string
+	IDs            []uuid.UUID // restricts the listing to these ideas
+}
+
+// IdeaFacet is a dimension the idea listings can be counted by
+type IdeaFacet string
+
+const (
+	IdeaFacetCategory IdeaFacet = "category"
+	IdeaFacetTag      IdeaFacet = "tag"
+	IdeaFacetStage    IdeaFacet = "stage"
+)
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// TrendingParams weights the engagement events of the trending score, each
+// event loses half of its weight every HalfLifeHours.
+type TrendingParams struct {
+	Since         time.Time // older events are ignored
+	HalfLifeHours float64
+
+	ViewWeight     float64
+	SignupWeight   float64
+	ReactionWeight float64
+	CommentWeight  float64
+}
+
+func ideaId(idea *domain.Idea) string { return idea.ID.String() }
+
+// ideaKeysets are the sorts of the idea listings, keyed by the sortBy query param
+var ideaKeysets = map[string]keyset[*domain.Idea]{
+	"newest": {column: "ideas.created_at", cast: "timestamptz", desc: true, id: "ideas.id",
+		value: func(i *domain.Idea) string { return timeKey(i.CreatedAt) }, rowId: ideaId},
+	"oldest": {column: "ideas.created_at", cast: "timestamptz", id: "ideas.id",
+		value: func(i *domain.Idea) string { return timeKey(i.CreatedAt) }, rowId: ideaId},
+	"views": {column: "COALESCE(v.view_count, 0)", cast: "bigint", desc: true, id: "ideas.id",
+		value: func(i *domain.Idea) string { return intKey(int64(i.Views)) }, rowId: ideaId},
+	"signups": {column: "COALESCE(s.signup_count, 0)", cast: "bigint", desc: true, id: "ideas.id",
+		value: func(i *domain.Idea) string { return intKey(int64(i.Signups)) }, rowId: ideaId},
+	"trending": {column: "ideas.trending_score", cast: "float8", desc: true, id: "ideas.id",
+		value: func(i *domain.Idea) string { return floatKey(i.TrendingScore) }, rowId: ideaId},
+}
+
+func ideaSort(sortBy string) string {
+	if _, ok := ideaKeysets[sortBy]; ok {
+		return sortBy
+	}
+	return "newest"
+}
+
+// NextIdeaCursor returns the cursor of the page after ideas, listed by GetIdeas
+func NextIdeaCursor(queryParams domain.QueryParams, ideas []*domain.Idea) string {
+	sort := ideaSort(queryParams.SortBy)
+	if isSearched(queryParams) {
+		return ideaKeysets[sort].nextRanked(sort, ideas, queryParams.Limit, func(i *domain.Idea) float64 { return i.SearchRank })
+	}
+	return ideaKeysets[sort].next(sort, ideas, queryParams.Limit)
 }
 
 type ideaRepository struct {
@@ -116,6 +192,27 @@ func (r *ideaRepository) Delete(ctx context.Context, ideaId uuid.UUID) error {
 		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.FeedbackReaction{}).Error; err != nil {
 			return fmt.Errorf("failed to delete Feedback Reactions: %w", err)
 		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Idea Members: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaInvitation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Idea Invitations: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaShareLink{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Share Links: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaFollower{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Idea Followers: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaUpdate{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Idea Updates: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaLifecycleRules{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Lifecycle Rules: %w", err)
+		}
+		if err := tx.Where("idea_id = ?", ideaId).Delete(&domain.IdeaEmbedding{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Idea Embeddings: %w", err)
+		}
 
 		// Finally, delete the idea itself
 		if err := tx.Delete(&domain.Idea{}, ideaId).Error; err != nil {
@@ -127,19 +224,7 @@ func (r *ideaRepository) Delete(ctx context.Context, ideaId uuid.UUID) error {
 }
 
 func (r *ideaRepository) GetIdeas(ctx context.Context, queryParams domain.QueryParams, spec IdeaQuerySpec) ([]*domain.Idea, int64, error) {
-	query := r.db.WithContext(ctx).Model(&domain.Idea{})
-
-	if spec.Status != "" {
-		query = query.Where("status = ?", spec.Status)
-	}
-
-	if spec.ByUserId != "" {
-		query = query.Where("user_id = ?", spec.ByUserId)
-	}
-
-	if spec.IncludePrivate != nil {
-		query = query.Where("is_private = ?", *spec.IncludePrivate)
-	}
+	query := applyIdeaQuerySpec(r.db.WithContext(ctx).Model(&domain.Idea{}).Preload("Category"), spec)
 
 	var tsQueryString string
 	if queryParams.Search != "" {
@@ -156,35 +241,26 @@ func (r *ideaRepository) GetIdeas(ctx context.Context, queryParams domain.QueryP
 		return nil, 0, err
 	}
 
-	orderClause := "created_at DESC"
-	needsCountsForSort := false
-
-	switch queryParams.SortBy {
-	case "newest":
-		orderClause = "created_at DESC"
-	case "oldest":
-		orderClause = "created_at ASC"
-	case "views":
-		orderClause = "views DESC"
-		needsCountsForSort = true
-	case "signups":
-		orderClause = "signups DESC"
-		needsCountsForSort = true
-	default:
-		// default already set;
-	}
+	sort := ideaSort(queryParams.SortBy)
+	needsCountsForSort := sort == "views" || sort == "signups"
 
 	if spec.WithCounts || needsCountsForSort {
 		// for sorting by views or signups, we need to join the counts
 		query = r.withCounts(query)
 	}
 
-	query = paginateAndOrder(query, queryParams.Limit, queryParams.Offset, orderClause)
-
-	if queryParams.Search != "" {
-		query = query.Order(gorm.Expr("ts_rank(search_vector, to_tsquery('english', ?)) DESC", tsQueryString))
+	var rank *searchRank
+	if tsQueryString != "" {
+		rank = &searchRank{expr: "ts_rank(ideas.search_vector, to_tsquery('english', ?))", args: []interface{}{tsQueryString}}
+		selectClause := "ideas.*"
+		if spec.WithCounts || needsCountsForSort {
+			selectClause = r.withCountsSelectClause()
+		}
+		query = query.Select(selectClause+", "+rank.expr+" AS search_rank", rank.args...)
 	}
 
+	query = ideaKeysets[sort].paginateRanked(query, sort, queryParams, rank)
+
 	var ideas []*domain.Idea
 	if err := query.Find(&ideas).Error; err != nil {
 		fmt.Println("Error finding ideas:", err)
@@ -194,14 +270,15 @@ func (r *ideaRepository) GetIdeas(ctx context.Context, queryParams domain.QueryP
 	return ideas, totalCount, nil
 }
 
-func (r *ideaRepository) GetByID(ctx context.Context, id uuid.UUID, getRelatedIdeas, withUser *bool) (*domain.Idea, []*domain.Idea, error) {
+func (r *ideaRepository) GetByID(ctx context.Context, id uuid.UUID, withUser *bool) (*domain.Idea, error) {
 	query := r.db.WithContext(ctx).Model(&domain.Idea{}).Where("id = ?", id).
 		Preload("Feedback", func(db *gorm.DB) *gorm.DB {
 			return db.Preload("Reactions")
 		}).
 		Preload("Reactions").
 		Preload("Signals").
-		Preload("AudienceMembers")
+		Preload("AudienceMembers").
+		Preload("Category")
 
 	if withUser != nil && *withUser {
 		query = query.Preload("User", func(db *gorm.DB) *gorm.DB {
@@ -212,15 +289,21 @@ func (r *ideaRepository) GetByID(ctx context.Context, id uuid.UUID, getRelatedId
 	idea := &domain.Idea{}
 	if err := query.Find(idea).Error; err != nil {
 		fmt.Println("Error finding idea:", err)
-		return nil, nil, err
+		return nil, err
 	}
 
-	var relatedIdeas []*domain.Idea
-	if getRelatedIdeas != nil && *getRelatedIdeas {
-		relatedIdeas = r.getRelatedIdeas(ctx, *idea)
+	return idea, nil
+}
+
+// Get loads the idea with its category only. Unlike GetByID it fails with
+// gorm.ErrRecordNotFound when the idea does not exist or was deleted.
+func (r *ideaRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Idea, error) {
+	var idea domain.Idea
+	if err := r.db.WithContext(ctx).Preload("Category").Where("id = ?", id).First(&idea).Error; err != nil {
+		return nil, err
 	}
 
-	return idea, relatedIdeas, nil
+	return &idea, nil
 }
 
 func (r *ideaRepository) GetByIds(ctx context.Context, ids []uuid.UUID) ([]*domain.Idea, error) {
@@ -239,6 +322,176 @@ func (r *ideaRepository) GetByIds(ctx context.Context, ids []uuid.UUID) ([]*doma
 	return ideas, nil
 }
 
+// GetByIdsWithCounts gets the ideas with their view and signup counts, in no particular order
+func (r *ideaRepository) GetByIdsWithCounts(ctx context.Context, ids []uuid.UUID) ([]*domain.Idea, error) {
+	var ideas []*domain.Idea
+
+	if len(ids) == 0 {
+		return ideas, nil
+	}
+
+	query := r.withCounts(r.db.WithContext(ctx).Model(&domain.Idea{}).Preload("Category")).Where("ideas.id IN ?", ids)
+	if err := query.Find(&ideas).Error; err != nil {
+		fmt.Println("Error finding ideas by ids:", err)
+		return nil, err
+	}
+
+	return ideas, nil
+}
+
+// GetSearchRanks returns the full text rank of the ideas matching any of the
+// search words, unlike GetIdeas which requires all of them.
+func (r *ideaRepository) GetSearchRanks(ctx context.Context, search string, spec IdeaQuerySpec, limit int) ([]ScoredIdea, error) {
+	var terms []string
+	for _, token := range simpleTokenizer(search) {
+		// keep letters and digits only, anything else is tsquery syntax
+		token = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, token)
+		if token != "" {
+			terms = append(terms, token+":*")
+		}
+	}
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	tsQueryString := strings.Join(terms, " | ")
+
+	var ranks []ScoredIdea
+	err := applyIdeaQuerySpec(r.db.WithContext(ctx).Model(&domain.Idea{}), spec).
+		Select("ideas.id AS idea_id, ts_rank(search_vector, to_tsquery('english', ?)) AS score", tsQueryString).
+		Where("search_vector @@ to_tsquery('english', ?)", tsQueryString).
+		Order("score DESC").
+		Limit(limit).
+		Scan(&ranks).Error
+	if err != nil {
+		fmt.Println("Error ranking ideas:", err)
+		return nil, err
+	}
+
+	return ranks, nil
+}
+
+// GetFacetCounts counts the ideas matching the spec per value of the facet,
+// most frequent first. Category values are category ids.
+func (r *ideaRepository) GetFacetCounts(ctx context.Context, facet IdeaFacet, spec IdeaQuerySpec, limit int) ([]FacetCount, error) {
+	query := applyIdeaQuerySpec(r.db.WithContext(ctx).Model(&domain.Idea{}), spec)
+
+	switch facet {
+	case IdeaFacetCategory:
+		query = query.Select("ideas.category_id::text AS value, COUNT(*) AS count").
+			Where("ideas.category_id IS NOT NULL").
+			Group("ideas.category_id")
+	case IdeaFacetTag:
+		query = query.Select("tag AS value, COUNT(*) AS count").
+			Joins("CROSS JOIN LATERAL jsonb_array_elements_text(CASE WHEN jsonb_typeof(ideas.tags) = 'array' THEN ideas.tags ELSE '[]'::jsonb END) AS tag").
+			Group("tag")
+	case IdeaFacetStage:
+		query = query.Select("ideas.stage AS value, COUNT(*) AS count").Group("ideas.stage")
+	default:
+		return nil, fmt.Errorf("unknown idea facet %q", facet)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var counts []FacetCount
+	if err := query.Order("count DESC, value ASC").Scan(&counts).Error; err != nil {
+		fmt.Println("Error counting idea facets:", err)
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// SetTaxonomy replaces the category and tags of an idea, a nil category clears it.
+func (r *ideaRepository) SetTaxonomy(ctx context.Context, ideaId uuid.UUID, categoryId *uuid.UUID, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	err := r.db.WithContext(ctx).Model(&domain.Idea{}).Where("id = ?", ideaId).Updates(map[string]interface{}{
+		"category_id": categoryId,
+		"tags":        datatypes.NewJSONSlice(tags),
+	}).Error
+	if err != nil {
+		fmt.Println("Error updating idea taxonomy:", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetStatus moves the idea from one status to another and reports whether it
+// did, false when the idea was not in the from status anymore.
+func (r *ideaRepository) SetStatus(ctx context.Context, ideaId uuid.UUID, from, to domain.IdeaStatus) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Idea{}).
+		Where("id = ? AND status = ?", ideaId, from).
+		Update("status", to)
+	if result.Error != nil {
+		fmt.Println("Error updating idea status:", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SetImage replaces the image of the idea, no variants clear the files of a previously uploaded image
+func (r *ideaRepository) SetImage(ctx context.Context, ideaId uuid.UUID, imageUrl string, variants []domain.IdeaImageVariant) error {
+	if variants == nil {
+		variants = []domain.IdeaImageVariant{}
+	}
+
+	return r.db.WithContext(ctx).Model(&domain.Idea{}).Where("id = ?", ideaId).Updates(map[string]interface{}{
+		"image_url":      imageUrl,
+		"image_variants": datatypes.NewJSONSlice(variants),
+	}).Error
+}
+
+// RefreshTrendingScores recomputes the trending score of every idea in one
+// statement and returns the number of ideas whose score changed.
+func (r *ideaRepository) RefreshTrendingScores(ctx context.Context, params TrendingParams) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		WITH events AS (
+			SELECT idea_id, created_at AS at, @view_weight::float8 AS weight
+			FROM signals WHERE event_type = @page_view AND created_at > @since AND deleted_at IS NULL
+			UNION ALL
+			SELECT idea_id, signup_time, @signup_weight::float8
+			FROM audience_members WHERE signup_time > @since AND deleted_at IS NULL
+			UNION ALL
+			SELECT idea_id, created_at, @reaction_weight::float8
+			FROM idea_reactions WHERE reaction_type = 'like' AND created_at > @since AND deleted_at IS NULL
+			UNION ALL
+			SELECT idea_id, created_at, @comment_weight::float8
+			FROM feedbacks WHERE created_at > @since AND deleted_at IS NULL
+		), scores AS (
+			SELECT idea_id, SUM(weight * POWER(0.5, EXTRACT(EPOCH FROM (NOW() - at)) / 3600.0 / @half_life)) AS score
+			FROM events GROUP BY idea_id
+		)
+		UPDATE ideas SET trending_score = COALESCE(scores.score, 0)
+		FROM ideas AS i LEFT JOIN scores ON scores.idea_id = i.id
+		WHERE ideas.id = i.id AND ideas.deleted_at IS NULL AND ideas.trending_score IS DISTINCT FROM COALESCE(scores.score, 0)`,
+		map[string]interface{}{
+			"since":           params.Since,
+			"half_life":       params.HalfLifeHours,
+			"page_view":       domain.EventTypePageView,
+			"view_weight":     params.ViewWeight,
+			"signup_weight":   params.SignupWeight,
+			"reaction_weight": params.ReactionWeight,
+			"comment_weight":  params.CommentWeight,
+		})
+	if result.Error != nil {
+		fmt.Println("Error refreshing trending scores:", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
 // GetByUserId gets all ideas for a user
 func (r *ideaRepository) GetByUserId(ctx context.Context, userId string) ([]*domain.Idea, error) {
 	var ideas []*domain.Idea
@@ -253,7 +506,7 @@ func (r *ideaRepository) GetByUserId(ctx context.Context, userId string) ([]*dom
 }
 
 // GetIdeasWithActivity fetches ideas with activity metrics for a time range
-func (r *ideaRepository) GetIdeasWithActivity(ctx context.Context, userID string, from, to time.Time, options ...QueryOption) ([]*response.IdeaWithActivity, error) {
+func (r *ideaRepository) GetIdeasWithActivity(ctx context.Context, spec IdeaQuerySpec, from, to time.Time, options ...QueryOption) ([]*response.IdeaWithActivity, error) {
 	var ideasWithActivity []*response.IdeaWithActivity
 
 	// Build subqueries for views and signups
@@ -274,8 +527,8 @@ func (r *ideaRepository) GetIdeasWithActivity(ctx context.Context, userID string
 		Model(&domain.Idea{}).
 		Select("ideas.*, COALESCE(s.signups, 0) as signups, COALESCE(v.views, 0) as views, s.latest_signup, v.latest_view").
 		Joins("LEFT JOIN (?) as s ON ideas.id = s.idea_id", signupsSub).
-		Joins("LEFT JOIN (?) as v ON ideas.id = v.idea_id", viewsSub).
-		Where("ideas.user_id = ?", userID)
+		Joins("LEFT JOIN (?) as v ON ideas.id = v.idea_id", viewsSub)
+	query = applyIdeaQuerySpec(query, spec)
 
 	// Apply any additional options
 	for _, option := range options {
@@ -289,21 +542,17 @@ func (r *ideaRepository) GetIdeasWithActivity(ctx context.Context, userID string
 	return ideasWithActivity, nil
 }
 
-func (r *ideaRepository) GetCountForUser(ctx context.Context, userId string, start, end *time.Time, status *domain.IdeaStatus) (int64, error) {
-	query := r.db.WithContext(ctx).
-		Model(&domain.Idea{}).
-		Where("user_id = ?", userId)
-
-	if status != nil {
-		query = query.Where("status = ?", *status)
-	}
+// GetCountCreated counts the ideas matching the spec created in the period,
+// a nil bound leaves the period open on that side
+func (r *ideaRepository) GetCountCreated(ctx context.Context, spec IdeaQuerySpec, start, end *time.Time) (int64, error) {
+	query := applyIdeaQuerySpec(r.db.WithContext(ctx).Model(&domain.Idea{}), spec)
 
 	if start != nil && end != nil {
-		query = query.Where("created_at BETWEEN ? AND ?", *start, *end)
+		query = query.Where("ideas.created_at BETWEEN ? AND ?", *start, *end)
 	} else if start != nil {
-		query = query.Where("created_at >= ?", *start)
+		query = query.Where("ideas.created_at >= ?", *start)
 	} else if end != nil {
-		query = query.Where("created_at <= ?", *end)
+		query = query.Where("ideas.created_at <= ?", *end)
 	}
 
 	var count int64
@@ -315,6 +564,18 @@ func (r *ideaRepository) GetCountForUser(ctx context.Context, userId string, sta
 	return count, nil
 }
 
+// GetCount counts the ideas matching the spec
+func (r *ideaRepository) GetCount(ctx context.Context, spec IdeaQuerySpec) (int64, error) {
+	query := applyIdeaQuerySpec(r.db.WithContext(ctx).Model(&domain.Idea{}), spec)
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *ideaRepository) HardDelete(ctx context.Context, ideaId uuid.UUID) error {
 	var feedbackIDs []uuid.UUID
 	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Feedback{}).Where("idea_id = ?", ideaId).Pluck("id", &feedbackIDs).Error
@@ -361,6 +622,30 @@ func (r *ideaRepository) HardDelete(ctx context.Context, ideaId uuid.UUID) error
 		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.Activity{}).Error; err != nil {
 			return fmt.Errorf("failed to hard delete Activities: %w", err)
 		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaEmbedding{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Embeddings: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaMember{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Members: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaInvitation{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Invitations: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaRevision{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Revisions: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaLifecycleRules{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Lifecycle Rules: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaFollower{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Followers: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaUpdate{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Updates: %w", err)
+		}
+		if err := tx.Unscoped().Where("idea_id = ?", ideaId).Delete(&domain.IdeaShareLink{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Share Links: %w", err)
+		}
 
 		// Finally, permanently delete the idea itself
 		if err := tx.Unscoped().Delete(&domain.Idea{}, ideaId).Error; err != nil {
@@ -430,6 +715,30 @@ func (r *ideaRepository) HardDeleteUserRelatedData(ctx context.Context, userId s
 			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.Activity{}).Error; err != nil {
 				return fmt.Errorf("failed to hard delete Activities for user %s: %w", userId, err)
 			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaEmbedding{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Embeddings for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaMember{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Members for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaInvitation{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Invitations for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaRevision{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Revisions for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaLifecycleRules{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Lifecycle Rules for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaFollower{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Followers for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaUpdate{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Updates for user %s: %w", userId, err)
+			}
+			if err := tx.Unscoped().Where("idea_id IN (?)", ideaIDs).Delete(&domain.IdeaShareLink{}).Error; err != nil {
+				return fmt.Errorf("failed to hard delete Idea Share Links for user %s: %w", userId, err)
+			}
 
 			// Finally, permanently delete the ideas themselves
 			if err := tx.Unscoped().Where("id IN (?)", ideaIDs).Delete(&domain.Idea{}).Error; err != nil {
@@ -437,6 +746,40 @@ func (r *ideaRepository) HardDeleteUserRelatedData(ctx context.Context, userId s
 			}
 		}
 
+		// The user also leaves the ideas shared with them
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&domain.IdeaMember{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Memberships for user %s: %w", userId, err)
+		}
+
+		// The user stops following ideas, the updates they posted on the ideas of others go too
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&domain.IdeaFollower{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Follows for user %s: %w", userId, err)
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&domain.IdeaUpdate{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Idea Updates of user %s: %w", userId, err)
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&domain.FounderProfile{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Founder Profile of user %s: %w", userId, err)
+		}
+
+		// Their edits of the ideas of others are kept without their identity
+		if err := tx.Unscoped().Model(&domain.IdeaRevision{}).Where("user_id = ?", userId).Update("user_id", "").Error; err != nil {
+			return fmt.Errorf("failed to anonymize Idea Revisions of user %s: %w", userId, err)
+		}
+
+		// and their workspaces, the ideas of other members become personal
+		ownedWorkspaces := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&domain.Workspace{}).Select("id").Where("owner_id = ?", userId)
+		if err := tx.Unscoped().Model(&domain.Idea{}).Where("workspace_id IN (?)", ownedWorkspaces).Update("workspace_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach Ideas from the workspaces of user %s: %w", userId, err)
+		}
+		if err := tx.Unscoped().Where("user_id = ? OR workspace_id IN (?)", userId, ownedWorkspaces).Delete(&domain.WorkspaceMember{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Workspace Members for user %s: %w", userId, err)
+		}
+		if err := tx.Unscoped().Where("owner_id = ?", userId).Delete(&domain.Workspace{}).Error; err != nil {
+			return fmt.Errorf("failed to hard delete Workspaces for user %s: %w", userId, err)
+		}
+
 		return nil
 	})
 }
@@ -564,41 +907,122 @@ func (r *ideaRepository) Restore(ctx context.Context, idea *domain.Idea) error {
 			return fmt.Errorf("failed to restore Activities: %w", err)
 		}
 
+		// Restore IdeaMembers
+		if err := tx.Unscoped().
+			Model(&domain.IdeaMember{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Idea Members: %w", err)
+		}
+
+		// Restore IdeaInvitations
+		if err := tx.Unscoped().
+			Model(&domain.IdeaInvitation{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Idea Invitations: %w", err)
+		}
+
+		// Restore IdeaShareLinks
+		if err := tx.Unscoped().
+			Model(&domain.IdeaShareLink{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Share Links: %w", err)
+		}
+
+		// Restore IdeaFollowers
+		if err := tx.Unscoped().
+			Model(&domain.IdeaFollower{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Idea Followers: %w", err)
+		}
+
+		// Restore IdeaUpdates
+		if err := tx.Unscoped().
+			Model(&domain.IdeaUpdate{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Idea Updates: %w", err)
+		}
+
+		// Restore IdeaLifecycleRules
+		if err := tx.Unscoped().
+			Model(&domain.IdeaLifecycleRules{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Lifecycle Rules: %w", err)
+		}
+
+		// Restore IdeaEmbeddings
+		if err := tx.Unscoped().
+			Model(&domain.IdeaEmbedding{}).
+			Where("idea_id = ? AND deleted_at IS NOT NULL", ideaID).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore Idea Embeddings: %w", err)
+		}
+
 		return nil
 	})
 }
 
-func (r *ideaRepository) getRelatedIdeas(ctx context.Context, idea domain.Idea) []*domain.Idea {
-	var relatedIdeas []*domain.Idea
-	baseQuery := r.db.WithContext(ctx).Model(&domain.Idea{}).
-		Preload("Signals").
-		Preload("AudienceMembers").
-		Limit(3)
+// DashboardScope is the ideas the dashboard works on, the ones of the workspace
+// selected in the switcher or the personal ideas of the user when it is nil.
+func DashboardScope(userId string, workspaceId *uuid.UUID) IdeaQuerySpec {
+	if workspaceId != nil {
+		return IdeaQuerySpec{WorkspaceID: workspaceId}
+	}
+	return IdeaQuerySpec{ByUserId: userId, Personal: true}
+}
+
+// applyIdeaQuerySpec filters on the ideas table, it is shared by the queries joining other tables
+func applyIdeaQuerySpec(query *gorm.DB, spec IdeaQuerySpec) *gorm.DB {
+	if spec.Status != "" {
+		query = query.Where("ideas.status = ?", spec.Status)
+	}
 
-	baseQuery = r.withCounts(baseQuery)
+	if spec.ByUserId != "" {
+		query = query.Where("ideas.user_id = ?", spec.ByUserId)
+	}
 
-	tokens := simpleTokenizer(idea.Title + " " + idea.TargetAudience)
+	if spec.AccessibleBy != "" {
+		// the ideas shared with the user are listed whatever their workspace
+		owned := "ideas.user_id = ?"
+		if spec.Personal {
+			owned = "(ideas.user_id = ? AND ideas.workspace_id IS NULL)"
+		}
+		query = query.Where("("+owned+" OR ideas.id IN (?))", spec.AccessibleBy,
+			query.Session(&gorm.Session{NewDB: true}).Model(&domain.IdeaMember{}).Select("idea_id").Where("user_id = ?", spec.AccessibleBy))
+	}
 
-	if len(tokens) == 0 {
-		return nil
+	if spec.WorkspaceID != nil {
+		query = query.Where("ideas.workspace_id = ?", *spec.WorkspaceID)
+	} else if spec.Personal && spec.AccessibleBy == "" {
+		query = query.Where("ideas.workspace_id IS NULL")
+	}
+
+	if spec.IncludePrivate != nil {
+		query = query.Where("ideas.is_private = ?", *spec.IncludePrivate)
 	}
 
-	tsQueryString := strings.Join(tokens, " | ")
+	if len(spec.CategoryIDs) > 0 {
+		query = query.Where("ideas.category_id IN ?", spec.CategoryIDs)
+	}
 
-	selectWithRelevance := fmt.Sprintf("%s, ts_rank(search_vector, to_tsquery('english', ?)) as relevance", r.withCountsSelectClause())
+	if len(spec.Tags) > 0 {
+		query = query.Where("ideas.tags @> ?::jsonb", datatypes.NewJSONSlice(spec.Tags))
+	}
 
-	relatedIdeasQuery := baseQuery.
-		Select(selectWithRelevance, tsQueryString).
-		Where("ideas.id != ? AND ideas.status = ? AND search_vector @@ to_tsquery('english', ?)", idea.ID, domain.IdeaStatusActive, tsQueryString).
-		Order("relevance DESC, signups DESC").
-		Limit(3)
+	if spec.Stage != "" {
+		query = query.Where("ideas.stage = ?", spec.Stage)
+	}
 
-	if err := relatedIdeasQuery.Find(&relatedIdeas).Error; err != nil {
-		fmt.Println("Error finding related ideas using FTS:", err)
-		return nil
+	if spec.IDs != nil {
+		query = query.Where("ideas.id IN ?", spec.IDs)
 	}
 
-	return relatedIdeas
+	return query
 }
 
 func (r *ideaRepository) withCounts(query *gorm.DB) *gorm.DB {
@@ -623,13 +1047,21 @@ func (r *ideaRepository) withCountsSelectClause() string {
 	selectIdeaFields := []string{
 		"ideas.id", "ideas.created_at", "ideas.updated_at", "ideas.deleted_at",
 		"ideas.user_id",
+		"ideas.workspace_id",
 		"ideas.title",
+		"ideas.slug",
 		"ideas.description",
 		"ideas.target_audience",
+		"ideas.is_private",
 		"ideas.status",
 		"ideas.stage",
 		"ideas.target_signups",
 		"ideas.image_url",
+		"ideas.share_image_url",
+		"ideas.image_variants",
+		"ideas.category_id",
+		"ideas.tags",
+		"ideas.trending_score",
 		"ideas.likes",
 		"ideas.dislikes",
 	}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/pkg/vector"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Similarity backends of the idea embeddings
+const (
+	VectorBackendMemory   = "memory"   // vectors are scored in Go
+	VectorBackendPgvector = "pgvector" // vectors are scored by the pgvector extension
+)
+
+type IdeaEmbeddingRepository interface {
+	Upsert(ctx context.Context, embedding *domain.IdeaEmbedding, values []float32) error
+	GetByIdeaID(ctx context.Context, ideaId uuid.UUID) (*domain.IdeaEmbedding, error)
+	GetIdeaIDsWithoutEmbedding(ctx context.Context, model string, limit int) ([]uuid.UUID, error)
+	Nearest(ctx context.Context, query []float32, model string, spec IdeaQuerySpec, limit int) ([]ScoredIdea, error)
+}
+
+// ScoredIdea is an idea id with its relevance for a search.
+type ScoredIdea struct {
+	IdeaID uuid.UUID
+	Score  float64
+}
+
+type ideaEmbeddingRepo struct {
+	db *gorm.DB
+}
+
+type pgvectorEmbeddingRepo struct {
+	*ideaEmbeddingRepo
+}
+
+// NewIdeaEmbeddingRepo returns the repository of the given similarity backend,
+// the pgvector backend expects database.EnablePgvector to have been run.
+func NewIdeaEmbeddingRepo(db *gorm.DB, backend string) IdeaEmbeddingRepository {
+	repo := &ideaEmbeddingRepo{db: db}
+	if backend == VectorBackendPgvector {
+		return &pgvectorEmbeddingRepo{ideaEmbeddingRepo: repo}
+	}
+	return repo
+}
+
+func (r *ideaEmbeddingRepo) Upsert(ctx context.Context, embedding *domain.IdeaEmbedding, values []float32) error {
+	return r.upsert(r.db.WithContext(ctx), embedding, values)
+}
+
+func (r *ideaEmbeddingRepo) upsert(tx *gorm.DB, embedding *domain.IdeaEmbedding, values []float32) error {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal embedding: %w", err)
+	}
+	embedding.Vector = raw
+	embedding.Dimensions = len(values)
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "idea_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"model", "content_hash", "dimensions", "vector", "updated_at"}),
+	}).Create(embedding).Error
+}
+
+func (r *ideaEmbeddingRepo) GetByIdeaID(ctx context.Context, ideaId uuid.UUID) (*domain.IdeaEmbedding, error) {
+	var embedding domain.IdeaEmbedding
+	if err := r.db.WithContext(ctx).Where("idea_id = ?", ideaId).First(&embedding).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &embedding, nil
+}
+
+// GetIdeaIDsWithoutEmbedding returns ideas that have no embedding yet or one
+// created by another model.
+func (r *ideaEmbeddingRepo) GetIdeaIDsWithoutEmbedding(ctx context.Context, model string, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&domain.Idea{}).
+		Joins("LEFT JOIN idea_embeddings ON idea_embeddings.idea_id = ideas.id AND idea_embeddings.deleted_at IS NULL").
+		Where("idea_embeddings.id IS NULL OR idea_embeddings.model <> ?", model).
+		Order("ideas.created_at DESC").
+		Limit(limit).
+		Pluck("ideas.id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *ideaEmbeddingRepo) candidates(ctx context.Context, model string, spec IdeaQuerySpec) *gorm.DB {
+	query := r.db.WithContext(ctx).Table("idea_embeddings").
+		Joins("JOIN ideas ON ideas.id = idea_embeddings.idea_id AND ideas.deleted_at IS NULL").
+		Where("idea_embeddings.deleted_at IS NULL AND idea_embeddings.model = ?", model)
+
+	return applyIdeaQuerySpec(query, spec)
+}
+
+// Nearest scans the stored vectors of the matching ideas and keeps the most
+// similar ones.
+func (r *ideaEmbeddingRepo) Nearest(ctx context.Context, query []float32, model string, spec IdeaQuerySpec, limit int) ([]ScoredIdea, error) {
+	rows, err := r.candidates(ctx, model, spec).
+		Where("idea_embeddings.dimensions = ?", len(query)).
+		Select("idea_embeddings.idea_id, idea_embeddings.vector").
+		Rows()
+	if err != nil {
+		fmt.Println("Error finding idea embeddings:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scored []ScoredIdea
+	for rows.Next() {
+		var ideaId uuid.UUID
+		var raw []byte
+		if err := rows.Scan(&ideaId, &raw); err != nil {
+			return nil, err
+		}
+
+		var values []float32
+		if err := json.Unmarshal(raw, &values); err != nil {
+			fmt.Println("Error decoding idea embedding:", err)
+			continue
+		}
+		scored = append(scored, ScoredIdea{IdeaID: ideaId, Score: vector.Cosine(query, values)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	return scored, nil
+}
+
+// Upsert also writes the native vector column used by the pgvector backend.
+func (r *pgvectorEmbeddingRepo) Upsert(ctx context.Context, embedding *domain.IdeaEmbedding, values []float32) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.upsert(tx, embedding, values); err != nil {
+			return err
+		}
+
+		return tx.Exec("UPDATE idea_embeddings SET embedding = ?::vector WHERE idea_id = ?", vector.Literal(values), embedding.IdeaID).Error
+	})
+}
+
+// Nearest lets pgvector order by cosine distance, so only the top rows leave the database.
+func (r *pgvectorEmbeddingRepo) Nearest(ctx context.Context, query []float32, model string, spec IdeaQuerySpec, limit int) ([]ScoredIdea, error) {
+	literal := vector.Literal(query)
+
+	var scored []ScoredIdea
+	err := r.candidates(ctx, model, spec).
+		Where("idea_embeddings.embedding IS NOT NULL AND vector_dims(idea_embeddings.embedding) = ?", len(query)).
+		Select("idea_embeddings.idea_id, 1 - (idea_embeddings.embedding <=> ?::vector) AS score", literal).
+		Order(gorm.Expr("idea_embeddings.embedding <=> ?::vector", literal)).
+		Limit(limit).
+		Scan(&scored).Error
+	if err != nil {
+		fmt.Println("Error finding nearest ideas with pgvector:", err)
+		return nil, err
+	}
+
+	return scored, nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"foundersignal/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdeaFollowRepository interface {
+	// Follow creates the follow, or changes its email digest setting when it exists
+	Follow(ctx context.Context, follower *domain.IdeaFollower) error
+	Unfollow(ctx context.Context, ideaId uuid.UUID, userId string) error
+	// GetFollow returns the follow of the idea by the user, nil when they do not follow it
+	GetFollow(ctx context.Context, ideaId uuid.UUID, userId string) (*domain.IdeaFollower, error)
+	CountFollowers(ctx context.Context, ideaId uuid.UUID) (int64, error)
+	// GetFollowerIDs returns the users following the idea that can still see
+	// it, except the given one
+	GetFollowerIDs(ctx context.Context, ideaId uuid.UUID, except string) ([]string, error)
+	// GetFollowedIdeas returns the ideas followed by the user that they can
+	// still see, most recently followed first
+	GetFollowedIdeas(ctx context.Context, userId string, queryParams domain.QueryParams) ([]*domain.Idea, int64, error)
+
+	// GetPendingDigests returns the updates not sent yet to the followers
+	// receiving the email digest that can still see the idea, oldest first
+	GetPendingDigests(ctx context.Context, until time.Time) ([]DigestEntry, error)
+	// MarkDigested records that the updates posted before until were sent to the user
+	MarkDigested(ctx context.Context, userId string, until time.Time) error
+}
+
+// DigestEntry is an update of a followed idea waiting for the email digest of a follower
+type DigestEntry struct {
+	UserID    string
+	IdeaID    uuid.UUID
+	IdeaTitle string
+	Content   string
+	CreatedAt time.Time
+}
+
+// followerCanSee matches the follows of ideas the follower can still open, the
+// public active ideas and the ones of their team. An idea made private or
+// paused after it was followed is hidden from the other followers.
+const followerCanSee = `((COALESCE(ideas.is_private, false) = false AND ideas.status = 'active')
+	OR ideas.user_id = idea_followers.user_id
+	OR EXISTS (SELECT 1 FROM idea_members WHERE idea_members.idea_id = ideas.id
+		AND idea_members.user_id = idea_followers.user_id AND idea_members.deleted_at IS NULL)
+	OR EXISTS (SELECT 1 FROM workspace_members WHERE workspace_members.workspace_id = ideas.workspace_id
+		AND workspace_members.user_id = idea_followers.user_id AND workspace_members.deleted_at IS NULL))`
+
+type ideaFollowRepository struct {
+	db *gorm.DB
+}
+
+func NewIdeaFollowRepo(db *gorm.DB) *ideaFollowRepository {
+	return &ideaFollowRepository{db: db}
+}
+
+func (r *ideaFollowRepository) Follow(ctx context.Context, follower *domain.IdeaFollower) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "idea_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email_digest", "updated_at"}),
+	}).Create(follower).Error
+}
+
+func (r *ideaFollowRepository) Unfollow(ctx context.Context, ideaId uuid.UUID, userId string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("idea_id = ? AND user_id = ?", ideaId, userId).
+		Delete(&domain.IdeaFollower{}).Error
+}
+
+func (r *ideaFollowRepository) GetFollow(ctx context.Context, ideaId uuid.UUID, userId string) (*domain.IdeaFollower, error) {
+	var follower domain.IdeaFollower
+	err := r.db.WithContext(ctx).
+		Where("idea_id = ? AND user_id = ?", ideaId, userId).
+		First(&follower).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &follower, nil
+}
+
+func (r *ideaFollowRepository) CountFollowers(ctx context.Context, ideaId uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.IdeaFollower{}).
+		Where("idea_id = ?", ideaId).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ideaFollowRepository) GetFollowerIDs(ctx context.Context, ideaId uuid.UUID, except string) ([]string, error) {
+	var userIds []string
+	err := r.db.WithContext(ctx).Model(&domain.IdeaFollower{}).
+		Joins("JOIN ideas ON ideas.id = idea_followers.idea_id AND ideas.deleted_at IS NULL").
+		Where("idea_followers.idea_id = ? AND idea_followers.user_id != ?", ideaId, except).
+		Where(followerCanSee).
+		Pluck("idea_followers.user_id", &userIds).Error
+	return userIds, err
+}
+
+func (r *ideaFollowRepository) GetFollowedIdeas(ctx context.Context, userId string, queryParams domain.QueryParams) ([]*domain.Idea, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.Idea{}).
+		Joins("JOIN idea_followers ON idea_followers.idea_id = ideas.id AND idea_followers.deleted_at IS NULL").
+		Where("idea_followers.user_id = ?", userId).
+		Where(followerCanSee)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return []*domain.Idea{}, 0, nil
+	}
+
+	if queryParams.Offset > 0 {
+		query = query.Offset(queryParams.Offset)
+	}
+	if queryParams.Limit > 0 {
+		query = query.Limit(queryParams.Limit)
+	}
+
+	var ideas []*domain.Idea
+	err := query.Select("ideas.*").Order("idea_followers.created_at DESC, idea_followers.id DESC").Find(&ideas).Error
+	return ideas, totalCount, err
+}
+
+func (r *ideaFollowRepository) GetPendingDigests(ctx context.Context, until time.Time) ([]DigestEntry, error) {
+	var entries []DigestEntry
+	err := r.db.WithContext(ctx).
+		Table("idea_followers").
+		Select("idea_followers.user_id, ideas.id AS idea_id, ideas.title AS idea_title, idea_updates.content, idea_updates.created_at").
+		Joins("JOIN idea_updates ON idea_updates.idea_id = idea_followers.idea_id AND idea_updates.deleted_at IS NULL").
+		Joins("JOIN ideas ON ideas.id = idea_followers.idea_id AND ideas.deleted_at IS NULL").
+		Where("idea_followers.deleted_at IS NULL AND idea_followers.email_digest").
+		Where(followerCanSee).
+		Where("idea_updates.created_at > idea_followers.digested_at AND idea_updates.created_at <= ?", until).
+		Where("idea_updates.user_id != idea_followers.user_id").
+		Order("idea_followers.user_id, idea_updates.idea_id, idea_updates.created_at ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+func (r *ideaFollowRepository) MarkDigested(ctx context.Context, userId string, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.IdeaFollower{}).
+		Where("user_id = ? AND digested_at < ?", userId, until).
+		Update("digested_at", until).Error
+}
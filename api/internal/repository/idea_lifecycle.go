@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"foundersignal/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdeaLifecycleRepository interface {
+	GetByIdea(ctx context.Context, ideaId uuid.UUID) (*domain.IdeaLifecycleRules, error)
+	// Save creates or replaces the rules of the idea
+	Save(ctx context.Context, rules *domain.IdeaLifecycleRules) error
+	ClearActivation(ctx context.Context, ideaId uuid.UUID) error
+
+	// FindToComplete returns the active ideas that reached their target signups
+	FindToComplete(ctx context.Context) ([]domain.Idea, error)
+	// FindInactive returns the active ideas without traffic for the days of their rule
+	FindInactive(ctx context.Context, now time.Time) ([]domain.Idea, error)
+	// FindToArchive returns the ideas with a final report that are not archived yet
+	FindToArchive(ctx context.Context) ([]domain.Idea, error)
+	// FindDueActivation returns the ideas scheduled to be activated before now, whatever their status
+	FindDueActivation(ctx context.Context, now time.Time) ([]domain.Idea, error)
+}
+
+type ideaLifecycleRepository struct {
+	db *gorm.DB
+}
+
+func NewIdeaLifecycleRepo(db *gorm.DB) *ideaLifecycleRepository {
+	return &ideaLifecycleRepository{db: db}
+}
+
+func (r *ideaLifecycleRepository) GetByIdea(ctx context.Context, ideaId uuid.UUID) (*domain.IdeaLifecycleRules, error) {
+	var rules domain.IdeaLifecycleRules
+	if err := r.db.WithContext(ctx).Where("idea_id = ?", ideaId).First(&rules).Error; err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *ideaLifecycleRepository) Save(ctx context.Context, rules *domain.IdeaLifecycleRules) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "idea_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"auto_complete", "auto_pause_after_days", "auto_archive", "activate_at", "updated_at"}),
+	}).Create(rules).Error
+}
+
+func (r *ideaLifecycleRepository) ClearActivation(ctx context.Context, ideaId uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&domain.IdeaLifecycleRules{}).
+		Where("idea_id = ?", ideaId).
+		Update("activate_at", nil).Error
+}
+
+// withRules joins the rules of the ideas, ideas without rules are left out
+func (r *ideaLifecycleRepository) withRules(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&domain.Idea{}).
+		Select("ideas.*").
+		Joins("JOIN idea_lifecycle_rules ON idea_lifecycle_rules.idea_id = ideas.id AND idea_lifecycle_rules.deleted_at IS NULL")
+}
+
+func (r *ideaLifecycleRepository) FindToComplete(ctx context.Context) ([]domain.Idea, error) {
+	var ideas []domain.Idea
+	err := r.withRules(ctx).
+		Where("idea_lifecycle_rules.auto_complete AND ideas.status = ?", domain.IdeaStatusActive).
+		Where("(SELECT COUNT(*) FROM audience_members WHERE audience_members.idea_id = ideas.id AND audience_members.deleted_at IS NULL) >= ideas.target_signups").
+		Find(&ideas).Error
+	return ideas, err
+}
+
+// FindInactive counts the days without traffic from the last signal, or from
+// the last edit of the idea when it is more recent, so that an idea activated
+// again is not paused right away.
+func (r *ideaLifecycleRepository) FindInactive(ctx context.Context, now time.Time) ([]domain.Idea, error) {
+	var ideas []domain.Idea
+	err := r.withRules(ctx).
+		Where("idea_lifecycle_rules.auto_pause_after_days > 0 AND ideas.status = ?", domain.IdeaStatusActive).
+		Where(`GREATEST(ideas.updated_at, COALESCE(
+			(SELECT MAX(signals.created_at) FROM signals WHERE signals.idea_id = ideas.id AND signals.deleted_at IS NULL),
+			ideas.created_at)) < ?::timestamptz - make_interval(days => idea_lifecycle_rules.auto_pause_after_days)`, now).
+		Find(&ideas).Error
+	return ideas, err
+}
+
+func (r *ideaLifecycleRepository) FindToArchive(ctx context.Context) ([]domain.Idea, error) {
+	var ideas []domain.Idea
+	err := r.withRules(ctx).
+		Where("idea_lifecycle_rules.auto_archive AND ideas.status != ?", domain.IdeaStatusArchived).
+		Where("EXISTS (SELECT 1 FROM reports WHERE reports.idea_id = ideas.id AND reports.type = ? AND reports.deleted_at IS NULL)", domain.ReportTypeFinal).
+		Find(&ideas).Error
+	return ideas, err
+}
+
+func (r *ideaLifecycleRepository) FindDueActivation(ctx context.Context, now time.Time) ([]domain.Idea, error) {
+	var ideas []domain.Idea
+	err := r.withRules(ctx).
+		Where("idea_lifecycle_rules.activate_at <= ?", now).
+		Find(&ideas).Error
+	return ideas, err
+}
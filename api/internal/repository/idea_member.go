@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdeaMemberRepository interface {
+	GetMember(ctx context.Context, ideaId uuid.UUID, userId string) (*domain.IdeaMember, error)
+	GetByIdea(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaMember, error)
+	GetIdeaIdsForUser(ctx context.Context, userId string) ([]uuid.UUID, error)
+	UpdateRole(ctx context.Context, ideaId uuid.UUID, userId string, role domain.IdeaRole) error
+	Delete(ctx context.Context, ideaId uuid.UUID, userId string) error
+
+	CreateInvitation(ctx context.Context, invitation *domain.IdeaInvitation) error
+	GetInvitationByToken(ctx context.Context, token string) (*domain.IdeaInvitation, error)
+	GetPendingInvitations(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaInvitation, error)
+	DeleteInvitation(ctx context.Context, ideaId, invitationId uuid.UUID) error
+	AcceptInvitation(ctx context.Context, invitation *domain.IdeaInvitation, userId string) (*domain.IdeaMember, error)
+}
+
+type ideaMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewIdeaMemberRepo(db *gorm.DB) *ideaMemberRepository {
+	return &ideaMemberRepository{db: db}
+}
+
+func (r *ideaMemberRepository) GetMember(ctx context.Context, ideaId uuid.UUID, userId string) (*domain.IdeaMember, error) {
+	var member domain.IdeaMember
+	err := r.db.WithContext(ctx).
+		Where("idea_id = ? AND user_id = ?", ideaId, userId).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *ideaMemberRepository) GetByIdea(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaMember, error) {
+	var members []domain.IdeaMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("idea_id = ?", ideaId).
+		Order("created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		fmt.Println("Error getting idea members:", err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetIdeaIdsForUser returns the ideas shared with the user, not the ones they own
+func (r *ideaMemberRepository) GetIdeaIdsForUser(ctx context.Context, userId string) ([]uuid.UUID, error) {
+	var ideaIds []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&domain.IdeaMember{}).
+		Where("user_id = ?", userId).
+		Pluck("idea_id", &ideaIds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ideaIds, nil
+}
+
+func (r *ideaMemberRepository) UpdateRole(ctx context.Context, ideaId uuid.UUID, userId string, role domain.IdeaRole) error {
+	result := r.db.WithContext(ctx).Model(&domain.IdeaMember{}).
+		Where("idea_id = ? AND user_id = ?", ideaId, userId).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the member for good so that they can be invited again
+func (r *ideaMemberRepository) Delete(ctx context.Context, ideaId uuid.UUID, userId string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("idea_id = ? AND user_id = ?", ideaId, userId).
+		Delete(&domain.IdeaMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *ideaMemberRepository) CreateInvitation(ctx context.Context, invitation *domain.IdeaInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *ideaMemberRepository) GetInvitationByToken(ctx context.Context, token string) (*domain.IdeaInvitation, error) {
+	var invitation domain.IdeaInvitation
+	err := r.db.WithContext(ctx).
+		Preload("Idea").
+		Where("token = ?", token).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (r *ideaMemberRepository) GetPendingInvitations(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaInvitation, error) {
+	var invitations []domain.IdeaInvitation
+	err := r.db.WithContext(ctx).
+		Where("idea_id = ? AND accepted_at IS NULL AND expires_at > ?", ideaId, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		fmt.Println("Error getting idea invitations:", err)
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *ideaMemberRepository) DeleteInvitation(ctx context.Context, ideaId, invitationId uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND idea_id = ? AND accepted_at IS NULL", invitationId, ideaId).
+		Delete(&domain.IdeaInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvitation adds the user with the role of the invitation, an existing
+// member gets the role of the invitation, and marks the invitation as used.
+func (r *ideaMemberRepository) AcceptInvitation(ctx context.Context, invitation *domain.IdeaInvitation, userId string) (*domain.IdeaMember, error) {
+	member := &domain.IdeaMember{
+		IdeaID:    invitation.IdeaID,
+		UserID:    userId,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.IdeaInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": now, "accepted_by": userId})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// accepted concurrently
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "idea_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "invited_by", "updated_at"}),
+		}).Create(member).Error
+	})
+	if err != nil {
+		fmt.Println("Error accepting idea invitation:", err)
+		return nil, err
+	}
+
+	return member, nil
+}
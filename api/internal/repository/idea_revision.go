@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"foundersignal/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdeaRevisionRepository interface {
+	// Create gives the revision the next version of the idea
+	Create(ctx context.Context, revision *domain.IdeaRevision) error
+	GetByIdea(ctx context.Context, ideaId uuid.UUID, queryParams domain.QueryParams) ([]domain.IdeaRevision, int64, error)
+	// GetForIdeas returns the revisions of the ideas made in the range, oldest first
+	GetForIdeas(ctx context.Context, ideaIds []uuid.UUID, from, to time.Time) ([]domain.IdeaRevision, error)
+	// GetStatusChanges returns the revisions of the ideas changing their status, oldest first
+	GetStatusChanges(ctx context.Context, ideaIds []uuid.UUID) ([]domain.IdeaRevision, error)
+}
+
+type ideaRevisionRepository struct {
+	db *gorm.DB
+}
+
+func revisionId(revision domain.IdeaRevision) string { return revision.ID.String() }
+
+var revisionKeyset = keyset[domain.IdeaRevision]{
+	column: "idea_revisions.version", cast: "bigint", desc: true, id: "idea_revisions.id",
+	value: func(r domain.IdeaRevision) string { return intKey(int64(r.Version)) }, rowId: revisionId,
+}
+
+// NextRevisionCursor returns the cursor of the page after revisions, listed by GetByIdea
+func NextRevisionCursor(queryParams domain.QueryParams, revisions []domain.IdeaRevision) string {
+	return revisionKeyset.next("newest", revisions, queryParams.Limit)
+}
+
+func NewIdeaRevisionRepo(db *gorm.DB) *ideaRevisionRepository {
+	return &ideaRevisionRepository{db: db}
+}
+
+func (r *ideaRevisionRepository) Create(ctx context.Context, revision *domain.IdeaRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the idea row is locked so that concurrent edits get distinct versions
+		var idea domain.Idea
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&idea, "id = ?", revision.IdeaID).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&domain.IdeaRevision{}).
+			Where("idea_id = ?", revision.IdeaID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		revision.Version = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+func (r *ideaRevisionRepository) GetByIdea(ctx context.Context, ideaId uuid.UUID, queryParams domain.QueryParams) ([]domain.IdeaRevision, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.IdeaRevision{}).
+		Where("idea_id = ?", ideaId)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return []domain.IdeaRevision{}, 0, nil
+	}
+
+	var revisions []domain.IdeaRevision
+	err := revisionKeyset.paginate(query, "newest", queryParams).Find(&revisions).Error
+	return revisions, totalCount, err
+}
+
+func (r *ideaRevisionRepository) GetForIdeas(ctx context.Context, ideaIds []uuid.UUID, from, to time.Time) ([]domain.IdeaRevision, error) {
+	var revisions []domain.IdeaRevision
+	if len(ideaIds) == 0 {
+		return revisions, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("idea_id IN ? AND created_at BETWEEN ? AND ?", ideaIds, from, to).
+		Order("created_at ASC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *ideaRevisionRepository) GetStatusChanges(ctx context.Context, ideaIds []uuid.UUID) ([]domain.IdeaRevision, error) {
+	var revisions []domain.IdeaRevision
+	if len(ideaIds) == 0 {
+		return revisions, nil
+	}
+
+	statusChange := datatypes.NewJSONSlice([]map[string]string{{"field": domain.IdeaFieldStatus}})
+	err := r.db.WithContext(ctx).
+		Where("idea_id IN ? AND changes @> ?::jsonb", ideaIds, statusChange).
+		Order("created_at ASC, version ASC").
+		Find(&revisions).Error
+	return revisions, err
+}
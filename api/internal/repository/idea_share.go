@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"foundersignal/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaShareRepository interface {
+	Create(ctx context.Context, link *domain.IdeaShareLink) error
+	GetByID(ctx context.Context, linkId uuid.UUID) (*domain.IdeaShareLink, error)
+	GetByIdea(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaShareLink, error)
+	Revoke(ctx context.Context, ideaId, linkId uuid.UUID) error
+	RecordAccess(ctx context.Context, linkId uuid.UUID) error
+}
+
+type ideaShareRepo struct {
+	db *gorm.DB
+}
+
+func NewIdeaShareRepo(db *gorm.DB) *ideaShareRepo {
+	return &ideaShareRepo{db: db}
+}
+
+func (r *ideaShareRepo) Create(ctx context.Context, link *domain.IdeaShareLink) error {
+	return r.db.WithContext(ctx).Create(link).Error
+}
+
+func (r *ideaShareRepo) GetByID(ctx context.Context, linkId uuid.UUID) (*domain.IdeaShareLink, error) {
+	var link domain.IdeaShareLink
+	if err := r.db.WithContext(ctx).Where("id = ?", linkId).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ideaShareRepo) GetByIdea(ctx context.Context, ideaId uuid.UUID) ([]domain.IdeaShareLink, error) {
+	var links []domain.IdeaShareLink
+	err := r.db.WithContext(ctx).
+		Where("idea_id = ?", ideaId).
+		Order("created_at DESC").
+		Find(&links).Error
+	return links, err
+}
+
+// Revoke keeps the link so that its access count stays visible
+func (r *ideaShareRepo) Revoke(ctx context.Context, ideaId, linkId uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&domain.IdeaShareLink{}).
+		Where("id = ? AND idea_id = ? AND revoked_at IS NULL", linkId, ideaId).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ideaShareRepo) RecordAccess(ctx context.Context, linkId uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&domain.IdeaShareLink{}).
+		Where("id = ?", linkId).
+		UpdateColumns(map[string]any{
+			"access_count":     gorm.Expr("access_count + 1"),
+			"last_accessed_at": time.Now(),
+		}).Error
+}
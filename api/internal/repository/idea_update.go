@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"foundersignal/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaUpdateRepository interface {
+	Create(ctx context.Context, update *domain.IdeaUpdate) error
+	GetByID(ctx context.Context, updateId uuid.UUID) (*domain.IdeaUpdate, error)
+	// GetByIdea returns the updates of the idea, newest first
+	GetByIdea(ctx context.Context, ideaId uuid.UUID, queryParams domain.QueryParams) ([]domain.IdeaUpdate, int64, error)
+	Delete(ctx context.Context, updateId uuid.UUID) error
+}
+
+type ideaUpdateRepository struct {
+	db *gorm.DB
+}
+
+func ideaUpdateId(update domain.IdeaUpdate) string { return update.ID.String() }
+
+var ideaUpdateKeyset = keyset[domain.IdeaUpdate]{
+	column: "idea_updates.created_at", cast: "timestamptz", desc: true, id: "idea_updates.id",
+	value: func(u domain.IdeaUpdate) string { return timeKey(u.CreatedAt) }, rowId: ideaUpdateId,
+}
+
+// NextIdeaUpdateCursor returns the cursor of the page after updates, listed by GetByIdea
+func NextIdeaUpdateCursor(queryParams domain.QueryParams, updates []domain.IdeaUpdate) string {
+	return ideaUpdateKeyset.next("newest", updates, queryParams.Limit)
+}
+
+func NewIdeaUpdateRepo(db *gorm.DB) *ideaUpdateRepository {
+	return &ideaUpdateRepository{db: db}
+}
+
+func (r *ideaUpdateRepository) Create(ctx context.Context, update *domain.IdeaUpdate) error {
+	return r.db.WithContext(ctx).Create(update).Error
+}
+
+func (r *ideaUpdateRepository) GetByID(ctx context.Context, updateId uuid.UUID) (*domain.IdeaUpdate, error) {
+	var update domain.IdeaUpdate
+	if err := r.db.WithContext(ctx).First(&update, "id = ?", updateId).Error; err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+func (r *ideaUpdateRepository) GetByIdea(ctx context.Context, ideaId uuid.UUID, queryParams domain.QueryParams) ([]domain.IdeaUpdate, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.IdeaUpdate{}).
+		Where("idea_id = ?", ideaId)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return []domain.IdeaUpdate{}, 0, nil
+	}
+
+	var updates []domain.IdeaUpdate
+	err := ideaUpdateKeyset.paginate(query, "newest", queryParams).Preload("User").Find(&updates).Error
+	return updates, totalCount, err
+}
+
+func (r *ideaUpdateRepository) Delete(ctx context.Context, updateId uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.IdeaUpdate{}, "id = ?", updateId).Error
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"fmt"
+	"foundersignal/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// keyset orders a listing by a sort key and the row id, so that a page can
+// start right after the last row of the previous one instead of skipping
+// rows with OFFSET.
+type keyset[T any] struct {
+	column string // SQL expression of the sort key
+	cast   string // SQL type the cursor value is cast back to
+	desc   bool
+	id     string // id column of the listing
+	textId bool   // the id is a text key instead of a uuid
+	value  func(T) string
+	rowId  func(T) string
+}
+
+// searchRank orders the rows of a searched listing that share a sort key by
+// relevance, highest first. The listing selects it as search_rank so that the
+// cursor of a page can carry the rank of its last row.
+type searchRank struct {
+	expr string // SQL expression of type real
+	args []interface{}
+}
+
+// paginate orders the query and limits it to one page. A cursor created for
+// the same sort continues after its row, otherwise the offset is used.
+func (k keyset[T]) paginate(query *gorm.DB, sort string, queryParams domain.QueryParams) *gorm.DB {
+	return k.paginateRanked(query, sort, queryParams, nil)
+}
+
+// paginateRanked works like paginate and breaks the ties of the sort key by the
+// search rank when there is one. A cursor whose value cannot be read back as
+// the sort key fails the query with domain.ErrInvalidCursor.
+func (k keyset[T]) paginateRanked(query *gorm.DB, sort string, queryParams domain.QueryParams, rank *searchRank) *gorm.DB {
+	direction, comparison := "ASC", ">"
+	if k.desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	cursor := queryParams.Cursor
+	if cursor != nil && cursor.Sort == sort && cursor.ID != "" && (rank != nil) == (cursor.Rank != nil) {
+		if !k.validCursor(cursor) {
+			query.AddError(domain.ErrInvalidCursor)
+			return query
+		}
+
+		if rank == nil {
+			query = query.Where(fmt.Sprintf("(%s, %s) %s (?::%s, ?)", k.column, k.id, comparison, k.cast), cursor.Value, cursor.ID)
+		} else {
+			args := []interface{}{cursor.Value, cursor.Value}
+			args = append(append(args, rank.args...), *cursor.Rank)
+			args = append(append(args, rank.args...), *cursor.Rank, cursor.ID)
+			query = query.Where(fmt.Sprintf("(%[1]s %[2]s ?::%[3]s OR (%[1]s = ?::%[3]s AND ((%[4]s) < ?::real OR ((%[4]s) = ?::real AND %[5]s %[2]s ?))))",
+				k.column, comparison, k.cast, rank.expr, k.id), args...)
+		}
+	} else if queryParams.Offset > 0 {
+		query = query.Offset(queryParams.Offset)
+	}
+
+	if queryParams.Limit > 0 {
+		query = query.Limit(queryParams.Limit)
+	}
+
+	if rank == nil {
+		return query.Order(fmt.Sprintf("%s %s, %s %s", k.column, direction, k.id, direction))
+	}
+	return query.
+		Order(fmt.Sprintf("%s %s", k.column, direction)).
+		Order(gorm.Expr(fmt.Sprintf("(%s) DESC", rank.expr), rank.args...)).
+		Order(fmt.Sprintf("%s %s", k.id, direction))
+}
+
+// validCursor reports whether the cursor value and id can be cast back to the
+// sort key and the id, a tampered cursor is refused instead of failing the query.
+func (k keyset[T]) validCursor(cursor *domain.Cursor) bool {
+	if !k.textId {
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return false
+		}
+	}
+
+	var err error
+	switch k.cast {
+	case "timestamptz":
+		_, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	case "bigint":
+		_, err = strconv.ParseInt(cursor.Value, 10, 64)
+	case "float8":
+		_, err = strconv.ParseFloat(cursor.Value, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(cursor.Value)
+	}
+	return err == nil
+}
+
+// next returns the encoded cursor after the last item, empty when the page is
+// not full and there is nothing left to fetch.
+func (k keyset[T]) next(sort string, items []T, limit int) string {
+	return k.nextRanked(sort, items, limit, nil)
+}
+
+// nextRanked works like next for a listing paginated with a search rank, the
+// rank of an item is read with rank.
+func (k keyset[T]) nextRanked(sort string, items []T, limit int, rank func(T) float64) string {
+	if limit <= 0 || len(items) < limit {
+		return ""
+	}
+
+	last := items[len(items)-1]
+	cursor := domain.Cursor{Sort: sort, Value: k.value(last), ID: k.rowId(last)}
+	if rank != nil {
+		r := rank(last)
+		cursor.Rank = &r
+	}
+	return cursor.Encode()
+}
+
+// isSearched tells if a listing is filtered by a keyword search, its rows are
+// then ranked by relevance.
+func isSearched(queryParams domain.QueryParams) bool {
+	return len(strings.Fields(queryParams.Search)) > 0
+}
+
+func timeKey(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func intKey(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func floatKey(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MVPLocaleRepository interface {
+	Upsert(ctx context.Context, variant *domain.MVPLocale) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MVPLocaleStatus, htmlUrl string) error
+	GetByMVP(ctx context.Context, mvpId uuid.UUID) ([]domain.MVPLocale, error)
+	GetByMVPAndLocale(ctx context.Context, mvpId uuid.UUID, locale string) (*domain.MVPLocale, error)
+	Delete(ctx context.Context, mvpId uuid.UUID, locale string) error
+}
+
+type mvpLocaleRepository struct {
+	db *gorm.DB
+}
+
+func NewMVPLocaleRepo(db *gorm.DB) *mvpLocaleRepository {
+	return &mvpLocaleRepository{db: db}
+}
+
+// Upsert creates the variant or resets an existing one for the same MVP and locale.
+func (r *mvpLocaleRepository) Upsert(ctx context.Context, variant *domain.MVPLocale) error {
+	if variant.ID == uuid.Nil {
+		variant.ID = uuid.New()
+	}
+
+	err := r.db.WithContext(ctx).Unscoped().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mvp_simulator_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at", "deleted_at"}),
+	}).Create(variant).Error
+	if err != nil {
+		fmt.Println("Error upserting mvp locale:", err)
+		return err
+	}
+
+	// the ID of an existing row is kept on conflict, reload it
+	return r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ? AND locale = ?", variant.MVPSimulatorID, variant.Locale).
+		First(variant).Error
+}
+
+func (r *mvpLocaleRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.MVPLocaleStatus, htmlUrl string) error {
+	updates := map[string]interface{}{"status": string(status)}
+	if htmlUrl != "" {
+		updates["html_url"] = htmlUrl
+	}
+
+	return r.db.WithContext(ctx).Model(&domain.MVPLocale{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *mvpLocaleRepository) GetByMVP(ctx context.Context, mvpId uuid.UUID) ([]domain.MVPLocale, error) {
+	var variants []domain.MVPLocale
+	err := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ?", mvpId).
+		Order("locale ASC").
+		Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (r *mvpLocaleRepository) GetByMVPAndLocale(ctx context.Context, mvpId uuid.UUID, locale string) (*domain.MVPLocale, error) {
+	var variant domain.MVPLocale
+	err := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ? AND locale = ?", mvpId, locale).
+		First(&variant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (r *mvpLocaleRepository) Delete(ctx context.Context, mvpId uuid.UUID, locale string) error {
+	result := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ? AND locale = ?", mvpId, locale).
+		Delete(&domain.MVPLocale{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
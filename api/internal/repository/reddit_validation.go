@@ -20,6 +20,42 @@ type redditValidationRepository struct {
 	db *gorm.DB
 }
 
+func validationId(v *domain.RedditValidation) string { return v.ID.String() }
+
+func validationCreatedAt(v *domain.RedditValidation) string { return timeKey(v.CreatedAt) }
+
+var validationKeysets = map[string]keyset[*domain.RedditValidation]{
+	"newest": {column: "reddit_validations.created_at", cast: "timestamptz", desc: true, id: "reddit_validations.id", value: validationCreatedAt, rowId: validationId},
+	"oldest": {column: "reddit_validations.created_at", cast: "timestamptz", id: "reddit_validations.id", value: validationCreatedAt, rowId: validationId},
+	"score": {column: "reddit_validations.validation_score", cast: "float8", desc: true, id: "reddit_validations.id",
+		value: func(v *domain.RedditValidation) string { return floatKey(v.ValidationScore) }, rowId: validationId},
+}
+
+// validationSort only allows the known sorts, sortBy used to be a column name
+// sorted in the direction of order, which is still accepted
+func validationSort(queryParams domain.QueryParams) string {
+	switch queryParams.SortBy {
+	case "created_at":
+		if queryParams.Order == "asc" {
+			return "oldest"
+		}
+		return "newest"
+	case "validation_score":
+		return "score"
+	}
+
+	if _, ok := validationKeysets[queryParams.SortBy]; ok {
+		return queryParams.SortBy
+	}
+	return "newest"
+}
+
+// NextValidationCursor returns the cursor of the page after validations, listed by GetForUser
+func NextValidationCursor(queryParams domain.QueryParams, validations []*domain.RedditValidation) string {
+	sort := validationSort(queryParams)
+	return validationKeysets[sort].next(sort, validations, queryParams.Limit)
+}
+
 func NewRedditValidationRepository(db *gorm.DB) RedditValidationRepository {
 	return &redditValidationRepository{db: db}
 }
@@ -57,33 +93,24 @@ func (r *redditValidationRepository) GetForUser(ctx context.Context, userID stri
 	var validations []*domain.RedditValidation
 	var total int64
 
-	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	// the validations of the workspace ideas are shared by its members
+	query := r.db.WithContext(ctx).
+		Joins("JOIN ideas ON ideas.id = reddit_validations.idea_id")
+	if queryParams.WorkspaceID != nil {
+		query = applyIdeaQuerySpec(query, IdeaQuerySpec{WorkspaceID: queryParams.WorkspaceID})
+	} else {
+		query = applyIdeaQuerySpec(query.Where("reddit_validations.user_id = ?", userID), IdeaQuerySpec{Personal: true})
+	}
 
 	// Count total
 	if err := query.Model(&domain.RedditValidation{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination
-	if queryParams.Limit > 0 {
-		query = query.Limit(queryParams.Limit)
-	}
-	if queryParams.Offset > 0 {
-		query = query.Offset(queryParams.Offset)
-	}
-
-	// Apply sorting
-	if queryParams.SortBy != "" {
-		order := "DESC"
-		if queryParams.Order == "asc" {
-			order = "ASC"
-		}
-		query = query.Order(queryParams.SortBy + " " + order)
-	} else {
-		query = query.Order("created_at DESC")
-	}
+	sort := validationSort(queryParams)
+	query = validationKeysets[sort].paginate(query, sort, queryParams)
 
-	if err := query.Find(&validations).Error; err != nil {
+	if err := query.Select("reddit_validations.*").Find(&validations).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"foundersignal/internal/domain"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,13 +19,48 @@ type ReportRepository interface {
 	GetByID(ctx context.Context, reportID uuid.UUID) (*domain.Report, error)
 	GetForIdea(ctx context.Context, ideaID uuid.UUID) ([]domain.Report, error)
 	GetReportByTypeAndTimeRange(ctx context.Context, ideaID uuid.UUID, reportType domain.ReportType, startDate, endDate time.Time) (*domain.Report, error)
-	GetAll(ctx context.Context, userID string) ([]domain.Report, int64, error)
+	GetAll(ctx context.Context, userID string, workspaceId *uuid.UUID) ([]domain.Report, int64, error)
 }
 
 type reportRepository struct {
 	db *gorm.DB
 }
 
+func reportId(report domain.Report) string { return report.ID.String() }
+
+func reportDate(report domain.Report) string { return timeKey(report.Date) }
+
+var reportKeysets = map[string]keyset[domain.Report]{
+	"newest": {column: "reports.date", cast: "timestamptz", desc: true, id: "reports.id", value: reportDate, rowId: reportId},
+	"oldest": {column: "reports.date", cast: "timestamptz", id: "reports.id", value: reportDate, rowId: reportId},
+	"views": {column: "reports.views", cast: "bigint", desc: true, id: "reports.id",
+		value: func(r domain.Report) string { return intKey(r.Views) }, rowId: reportId},
+	"conversionRate": {column: "reports.signups", cast: "bigint", desc: true, id: "reports.id",
+		value: func(r domain.Report) string { return intKey(r.Signups) }, rowId: reportId},
+	"sentiment": {column: "reports.sentiment", cast: "float8", desc: true, id: "reports.id",
+		value: func(r domain.Report) string { return floatKey(r.Sentiment) }, rowId: reportId},
+	"nameAsc": {column: "ideas.title", cast: "text", id: "reports.id",
+		value: func(r domain.Report) string { return r.Idea.Title }, rowId: reportId},
+	"validated": {column: "reports.validated", cast: "boolean", desc: true, id: "reports.id",
+		value: func(r domain.Report) string { return strconv.FormatBool(r.Validated) }, rowId: reportId},
+}
+
+func reportSort(sortBy string) string {
+	if _, ok := reportKeysets[sortBy]; ok {
+		return sortBy
+	}
+	return "newest"
+}
+
+// NextReportCursor returns the cursor of the page after reports, listed by GetForUser
+func NextReportCursor(queryParams domain.QueryParams, reports []domain.Report) string {
+	sort := reportSort(queryParams.SortBy)
+	if isSearched(queryParams) {
+		return reportKeysets[sort].nextRanked(sort, reports, queryParams.Limit, func(r domain.Report) float64 { return r.SearchRank })
+	}
+	return reportKeysets[sort].next(sort, reports, queryParams.Limit)
+}
+
 func NewReportRepository(db *gorm.DB) *reportRepository {
 	return &reportRepository{db: db}
 }
@@ -49,8 +85,8 @@ func (r *reportRepository) GetForUser(ctx context.Context, userID string, queryP
 	var reports []domain.Report
 	query := r.db.WithContext(ctx).
 		Model(&domain.Report{}).
-		Joins("JOIN ideas ON ideas.id = reports.idea_id").
-		Where("ideas.user_id = ?", userID)
+		Joins("JOIN ideas ON ideas.id = reports.idea_id")
+	query = applyIdeaQuerySpec(query, DashboardScope(userID, queryParams.WorkspaceID))
 
 	if queryParams.FilterBy != "" {
 		query = query.Where("reports.type = ?", domain.ReportType(queryParams.FilterBy))
@@ -75,33 +111,21 @@ func (r *reportRepository) GetForUser(ctx context.Context, userID string, queryP
 		return []domain.Report{}, 0, nil
 	}
 
-	orderClause := "reports.date DESC"
-	switch queryParams.SortBy {
-	case "newest":
-		orderClause = "reports.date DESC"
-	case "oldest":
-		orderClause = "reports.date ASC"
-	case "views":
-		orderClause = "reports.views DESC"
-	case "conversionRate":
-		orderClause = "reports.signups DESC"
-	case "sentiment":
-		orderClause = "reports.sentiment DESC"
-	case "nameAsc":
-		orderClause = "ideas.title ASC"
-	case "validated":
-		orderClause = "reports.validated DESC"
-	default:
-		orderClause = "reports.date DESC"
+	var rank *searchRank
+	if tsQueryString != "" {
+		rank = &searchRank{expr: "ts_rank(ideas.search_vector, to_tsquery('english', ?))", args: []interface{}{tsQueryString}}
+		query = query.Select("reports.*, "+rank.expr+" AS search_rank", rank.args...)
+	} else {
+		query = query.Select("reports.*")
 	}
 
-	query = paginateAndOrder(query, queryParams.Limit, queryParams.Offset, orderClause)
-
-	if queryParams.Search != "" {
-		query = query.Order(gorm.Expr("ts_rank(ideas.search_vector, to_tsquery('english', ?)) DESC", tsQueryString))
-	}
+	sort := reportSort(queryParams.SortBy)
+	query = reportKeysets[sort].paginateRanked(query, sort, queryParams, rank)
 
 	err := query.Preload("Idea").Find(&reports).Error
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, 0, err
+	}
 	if err != nil {
 		return nil, 0, errors.New("failed to fetch reports")
 	}
@@ -146,12 +170,12 @@ func (r *reportRepository) GetReportByTypeAndTimeRange(ctx context.Context, idea
 }
 
 // For internal use only
-func (r *reportRepository) GetAll(ctx context.Context, userID string) ([]domain.Report, int64, error) {
+func (r *reportRepository) GetAll(ctx context.Context, userID string, workspaceId *uuid.UUID) ([]domain.Report, int64, error) {
 	var reports []domain.Report
 	query := r.db.WithContext(ctx).
 		Model(&domain.Report{}).
-		Joins("JOIN ideas ON ideas.id = reports.idea_id").
-		Where("ideas.user_id = ?", userID).
+		Joins("JOIN ideas ON ideas.id = reports.idea_id")
+	query = applyIdeaQuerySpec(query, DashboardScope(userID, workspaceId)).
 		Preload("Idea").
 		Order("reports.date DESC")
 
@@ -160,7 +184,7 @@ func (r *reportRepository) GetAll(ctx context.Context, userID string) ([]domain.
 		return nil, 0, err
 	}
 
-	err := query.Find(&reports).Error
+	err := query.Select("reports.*").Find(&reports).Error
 	if err != nil {
 		return nil, 0, errors.New("failed to fetch reports")
 	}
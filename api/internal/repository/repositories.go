@@ -5,46 +5,61 @@ import (
 )
 
 type Repositories struct {
-	User     UserRepository
-	Idea     IdeaRepository
-	Audience AudienceRepository
-	Signal   SignalRepository
-	Feedback FeedbackRepository
-	Reaction ReactionRepository
-	MVP      MVPRepository
-	Report   ReportRepository
-	Activity ActivityRepository
-	Paddle   PaddleRepository
-	Reddit   RedditValidationRepository
+	User      UserRepository
+	Idea      IdeaRepository
+	Embedding IdeaEmbeddingRepository
+	Category  CategoryRepository
+	Member    IdeaMemberRepository
+	Revision  IdeaRevisionRepository
+	Lifecycle IdeaLifecycleRepository
+	Follow    IdeaFollowRepository
+	Update    IdeaUpdateRepository
+	Profile   FounderProfileRepository
+	Share     IdeaShareRepository
+	Workspace WorkspaceRepository
+	Audience  AudienceRepository
+	Signal    SignalRepository
+	Feedback  FeedbackRepository
+	Reaction  ReactionRepository
+	MVP       MVPRepository
+	Locale    MVPLocaleRepository
+	Survey    SurveyRepository
+	Report    ReportRepository
+	Activity  ActivityRepository
+	Paddle    PaddleRepository
+	Reddit    RedditValidationRepository
 }
 
-func NewRepositories(db *gorm.DB) *Repositories {
+type RepositoriesConfig struct {
+	VectorBackend string // VectorBackendMemory or VectorBackendPgvector
+}
+
+func NewRepositories(db *gorm.DB, cfg RepositoriesConfig) *Repositories {
 	return &Repositories{
-		User:     NewUserRepository(db),
-		Idea:     NewIdeasRepo(db),
-		Audience: NewAudienceRepo(db),
-		Signal:   NewSignalRepo(db),
-		Feedback: NewFeedbackRepo(db),
-		Reaction: NewReactionRepo(db),
-		MVP:      NewMVPRepo(db),
-		Report:   NewReportRepository(db),
-		Activity: NewActivityRepository(db),
-		Paddle:   NewPaddleRepository(db),
-		Reddit:   NewRedditValidationRepository(db),
+		User:      NewUserRepository(db),
+		Idea:      NewIdeasRepo(db),
+		Embedding: NewIdeaEmbeddingRepo(db, cfg.VectorBackend),
+		Category:  NewCategoryRepo(db),
+		Member:    NewIdeaMemberRepo(db),
+		Revision:  NewIdeaRevisionRepo(db),
+		Lifecycle: NewIdeaLifecycleRepo(db),
+		Follow:    NewIdeaFollowRepo(db),
+		Update:    NewIdeaUpdateRepo(db),
+		Profile:   NewFounderProfileRepo(db),
+		Share:     NewIdeaShareRepo(db),
+		Workspace: NewWorkspaceRepo(db),
+		Audience:  NewAudienceRepo(db),
+		Signal:    NewSignalRepo(db),
+		Feedback:  NewFeedbackRepo(db),
+		Reaction:  NewReactionRepo(db),
+		MVP:       NewMVPRepo(db),
+		Locale:    NewMVPLocaleRepo(db),
+		Survey:    NewSurveyRepo(db),
+		Report:    NewReportRepository(db),
+		Activity:  NewActivityRepository(db),
+		Paddle:    NewPaddleRepository(db),
+		Reddit:    NewRedditValidationRepository(db),
 	}
 }
 
 type QueryOption func(*gorm.DB) *gorm.DB
-
-func paginateAndOrder(query *gorm.DB, limit, offset int, order string) *gorm.DB {
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
-	if offset > 0 {
-		query = query.Offset(offset)
-	}
-	if order != "" {
-		query = query.Order(order)
-	}
-	return query
-}
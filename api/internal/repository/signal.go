@@ -15,9 +15,10 @@ type SignalRepository interface {
 	GetDailyViewsByIdeaIDs(ctx context.Context, ideaIds []uuid.UUID, from, to time.Time) (map[uuid.UUID]map[string]int, error)
 	GetRecentByUserIdeas(ctx context.Context, userId string, limit int) ([]domain.Signal, error)
 	GetByIdeaId(ctx context.Context, ideaId uuid.UUID, userId *string, eventType *domain.EventType) ([]*domain.Signal, error)
-	GetCountForIdeaOwner(ctx context.Context, ideaOwnerId string, specs SignalQuerySpecs) (int64, error)
+	GetCountForIdeas(ctx context.Context, spec IdeaQuerySpec, specs SignalQuerySpecs) (int64, error)
 	GetCountByIdeaId(ctx context.Context, ideaId uuid.UUID, eventType *domain.EventType, start, end *time.Time, fields []string) (int64, error)
 	GetByIdeaWithTimeRange(ctx context.Context, ideaId uuid.UUID, startDate, endDate time.Time) ([]domain.Signal, error)
+	GetByMVPId(ctx context.Context, mvpId uuid.UUID, eventTypes []domain.EventType) ([]domain.Signal, error)
 }
 
 type signalRepository struct {
@@ -148,15 +149,16 @@ func (r *signalRepository) GetCountByIdeaId(
 	return count, nil
 }
 
-func (r *signalRepository) GetCountForIdeaOwner(ctx context.Context, ideaOwnerId string, specs SignalQuerySpecs) (int64, error) {
+// GetCountForIdeas counts the signals of the ideas matching the spec
+func (r *signalRepository) GetCountForIdeas(ctx context.Context, spec IdeaQuerySpec, specs SignalQuerySpecs) (int64, error) {
 	if specs.EventType == "" {
 		specs.EventType = domain.EventTypePageView
 	}
 
 	query := r.db.WithContext(ctx).
 		Model(&domain.Signal{}).
-		Joins("JOIN ideas ON ideas.id = signals.idea_id").
-		Where("ideas.user_id = ?", ideaOwnerId).
+		Joins("JOIN ideas ON ideas.id = signals.idea_id")
+	query = applyIdeaQuerySpec(query, spec).
 		Where("signals.event_type = ?", specs.EventType)
 
 	if specs.Start != nil && specs.End != nil {
@@ -190,3 +192,19 @@ func (r *signalRepository) GetByIdeaWithTimeRange(ctx context.Context, ideaId uu
 
 	return signals, nil
 }
+
+func (r *signalRepository) GetByMVPId(ctx context.Context, mvpId uuid.UUID, eventTypes []domain.EventType) ([]domain.Signal, error) {
+	var signals []domain.Signal
+
+	query := r.db.WithContext(ctx).Where("mvp_simulator_id = ?", mvpId)
+	if len(eventTypes) > 0 {
+		query = query.Where("event_type IN ?", eventTypes)
+	}
+
+	if err := query.Order("signals.created_at ASC").Find(&signals).Error; err != nil {
+		fmt.Printf("Error fetching signals for mvp %s: %v\n", mvpId, err)
+		return nil, err
+	}
+
+	return signals, nil
+}
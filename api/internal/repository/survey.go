@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SurveyRepository interface {
+	UpsertAnswers(ctx context.Context, answers []domain.SurveyAnswer) error
+	GetByMVP(ctx context.Context, mvpId uuid.UUID) ([]domain.SurveyAnswer, error)
+}
+
+type surveyRepository struct {
+	db *gorm.DB
+}
+
+func NewSurveyRepo(db *gorm.DB) *surveyRepository {
+	return &surveyRepository{db: db}
+}
+
+// UpsertAnswers stores the answers of a member, an answer to the same question replaces the previous one.
+func (r *surveyRepository) UpsertAnswers(ctx context.Context, answers []domain.SurveyAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mvp_simulator_id"}, {Name: "audience_user_id"}, {Name: "question_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"choice", "text", "updated_at"}),
+	}).Create(&answers).Error
+	if err != nil {
+		fmt.Println("Error upserting survey answers:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *surveyRepository) GetByMVP(ctx context.Context, mvpId uuid.UUID) ([]domain.SurveyAnswer, error) {
+	var answers []domain.SurveyAnswer
+	err := r.db.WithContext(ctx).
+		Where("mvp_simulator_id = ?", mvpId).
+		Order("created_at DESC").
+		Find(&answers).Error
+	if err != nil {
+		fmt.Println("Error getting survey answers:", err)
+		return nil, err
+	}
+
+	return answers, nil
+}
@@ -13,6 +13,7 @@ type UserRepository interface {
 	Update(ctx context.Context, userID string, user *domain.User) error
 	FindByID(ctx context.Context, userID string) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByUsername(ctx context.Context, username string) (*domain.User, error)
 	Delete(ctx context.Context, userID string) error
 	FindByPaddleSubscriptionID(ctx context.Context, paddleSubscriptionID string) (*domain.User, error)
 	FindByPaddleCustomerID(ctx context.Context, paddleCustomerID string) (*domain.User, error)
@@ -69,6 +70,17 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 	return &user, err
 }
 
+// FindByUsername ignores the case of the username, it returns nil when no user has it.
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("LOWER(username) = LOWER(?)", username).First(&user).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	return &user, err
+}
+
 func (r *userRepository) FindByPaddleSubscriptionID(ctx context.Context, paddleSubscriptionID string) (*domain.User, error) {
 	var user domain.User
 	err := r.db.WithContext(ctx).Where("paddle_subscription_id = ?", paddleSubscriptionID).First(&user).Error
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepository interface {
+	Create(ctx context.Context, workspace *domain.Workspace) error
+	Update(ctx context.Context, workspaceId uuid.UUID, workspace *domain.Workspace) error
+	UpdateSubscription(ctx context.Context, workspaceId uuid.UUID, workspace *domain.Workspace) error
+	Delete(ctx context.Context, workspaceId uuid.UUID) error
+	GetByID(ctx context.Context, workspaceId uuid.UUID) (*domain.Workspace, error)
+	GetForUser(ctx context.Context, userId string) ([]domain.WorkspaceMember, error)
+	FindByPaddleSubscriptionID(ctx context.Context, paddleSubscriptionID string) (*domain.Workspace, error)
+
+	GetMember(ctx context.Context, workspaceId uuid.UUID, userId string) (*domain.WorkspaceMember, error)
+	GetMembers(ctx context.Context, workspaceId uuid.UUID) ([]domain.WorkspaceMember, error)
+	AddMember(ctx context.Context, member *domain.WorkspaceMember) error
+	UpdateMemberRole(ctx context.Context, workspaceId uuid.UUID, userId string, role domain.WorkspaceRole) error
+	RemoveMember(ctx context.Context, workspaceId uuid.UUID, userId string) error
+
+	MoveIdea(ctx context.Context, ideaId uuid.UUID, workspaceId *uuid.UUID) error
+}
+
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceRepo(db *gorm.DB) *workspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+// Create stores the workspace with its owner as the first member
+func (r *workspaceRepository) Create(ctx context.Context, workspace *domain.Workspace) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(workspace).Error; err != nil {
+			fmt.Println("Error creating workspace:", err)
+			return err
+		}
+
+		owner := &domain.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        domain.WorkspaceRoleOwner,
+		}
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *workspaceRepository) Update(ctx context.Context, workspaceId uuid.UUID, workspace *domain.Workspace) error {
+	workspace.ID = workspaceId
+	if err := r.db.WithContext(ctx).Model(workspace).Updates(workspace).Error; err != nil {
+		fmt.Println("Error updating workspace:", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSubscription writes the subscription fields that are set, IsPaying is
+// always written so that a canceled or paused subscription stops the billing.
+func (r *workspaceRepository) UpdateSubscription(ctx context.Context, workspaceId uuid.UUID, workspace *domain.Workspace) error {
+	updates := map[string]interface{}{"is_paying": workspace.IsPaying}
+	if workspace.Plan != "" {
+		updates["plan"] = workspace.Plan
+	}
+	if workspace.SubscriptionStatus != "" {
+		updates["subscription_status"] = workspace.SubscriptionStatus
+	}
+	if workspace.PaddleSubscriptionID != nil {
+		updates["paddle_subscription_id"] = workspace.PaddleSubscriptionID
+	}
+	if workspace.PaddleCustomerID != nil {
+		updates["paddle_customer_id"] = workspace.PaddleCustomerID
+	}
+	if workspace.PaddlePlanID != nil {
+		updates["paddle_plan_id"] = workspace.PaddlePlanID
+	}
+	if workspace.SubscriptionExpiresAt != nil {
+		updates["subscription_expires_at"] = workspace.SubscriptionExpiresAt
+	}
+	if workspace.SubscriptionCancelledAt != nil {
+		updates["subscription_cancelled_at"] = workspace.SubscriptionCancelledAt
+	}
+	if workspace.SubscriptionPausedAt != nil {
+		updates["subscription_paused_at"] = workspace.SubscriptionPausedAt
+	}
+
+	if err := r.db.WithContext(ctx).Model(&domain.Workspace{}).Where("id = ?", workspaceId).Updates(updates).Error; err != nil {
+		fmt.Println("Error updating workspace subscription:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the workspace and its members, its ideas become personal
+// ideas of their creators.
+func (r *workspaceRepository) Delete(ctx context.Context, workspaceId uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.Idea{}).Where("workspace_id = ?", workspaceId).Update("workspace_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach Ideas: %w", err)
+		}
+		if err := tx.Unscoped().Where("workspace_id = ?", workspaceId).Delete(&domain.WorkspaceMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete Workspace Members: %w", err)
+		}
+
+		result := tx.Where("id = ?", workspaceId).Delete(&domain.Workspace{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete Workspace: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *workspaceRepository) GetByID(ctx context.Context, workspaceId uuid.UUID) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	if err := r.db.WithContext(ctx).Where("id = ?", workspaceId).First(&workspace).Error; err != nil {
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+// GetForUser returns the memberships of the user with their workspace
+func (r *workspaceRepository) GetForUser(ctx context.Context, userId string) ([]domain.WorkspaceMember, error) {
+	var members []domain.WorkspaceMember
+	err := r.db.WithContext(ctx).
+		Joins("Workspace").
+		Where("workspace_members.user_id = ?", userId).
+		Order("workspace_members.created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		fmt.Println("Error getting workspaces for user:", err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *workspaceRepository) FindByPaddleSubscriptionID(ctx context.Context, paddleSubscriptionID string) (*domain.Workspace, error) {
+	var workspace domain.Workspace
+	err := r.db.WithContext(ctx).Where("paddle_subscription_id = ?", paddleSubscriptionID).First(&workspace).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+
+	return &workspace, err
+}
+
+func (r *workspaceRepository) GetMember(ctx context.Context, workspaceId uuid.UUID, userId string) (*domain.WorkspaceMember, error) {
+	var member domain.WorkspaceMember
+	err := r.db.WithContext(ctx).
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *workspaceRepository) GetMembers(ctx context.Context, workspaceId uuid.UUID) ([]domain.WorkspaceMember, error) {
+	var members []domain.WorkspaceMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("workspace_id = ?", workspaceId).
+		Order("created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		fmt.Println("Error getting workspace members:", err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *workspaceRepository) AddMember(ctx context.Context, member *domain.WorkspaceMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *workspaceRepository) UpdateMemberRole(ctx context.Context, workspaceId uuid.UUID, userId string, role domain.WorkspaceRole) error {
+	result := r.db.WithContext(ctx).Model(&domain.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND role <> ?", workspaceId, userId, domain.WorkspaceRoleOwner).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RemoveMember deletes the member for good so that they can be added again,
+// the owner cannot be removed.
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceId uuid.UUID, userId string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("workspace_id = ? AND user_id = ? AND role <> ?", workspaceId, userId, domain.WorkspaceRoleOwner).
+		Delete(&domain.WorkspaceMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// MoveIdea sets the workspace of the idea, nil makes it a personal idea
+func (r *workspaceRepository) MoveIdea(ctx context.Context, ideaId uuid.UUID, workspaceId *uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&domain.Idea{}).
+		Where("id = ?", ideaId).
+		Update("workspace_id", workspaceId)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ type AnalyticsData struct {
 	Signups        int64
 	Sentiment      float64
 	EngagementRate float64
+	Feedbacks      int64 // the sentiment is the average of these, 0 when there are none
 }
 
 type AnalyticsService interface {
@@ -62,12 +63,7 @@ func (s *analyticsService) GetIdeaReportAnalytics(ctx context.Context, ideaID uu
 			pageViewsCount++
 		}
 
-		var sessionKey string
-		if signal.UserID != "" {
-			sessionKey = signal.UserID
-		} else {
-			sessionKey = "anon_" + signal.IPAddress + "_" + signal.UserAgent
-		}
+		sessionKey := signalSessionKey(&signal)
 
 		if _, ok := sessions[sessionKey]; !ok {
 			sessions[sessionKey] = &sessionActivity{}
@@ -152,6 +148,7 @@ func (s *analyticsService) GetIdeaReportAnalytics(ctx context.Context, ideaID uu
 		Signups:        signups,
 		Sentiment:      sentiment,
 		EngagementRate: engagementRate,
+		Feedbacks:      int64(feedbackCount),
 	}, nil
 }
 
@@ -386,3 +383,11 @@ func calculatePercentageChange(current, previous float64) float64 {
 
 	return ((current - previous) / previous) * 100.0
 }
+
+// signalSessionKey identifies the visitor behind a signal, anonymous visitors are keyed by IP and user agent.
+func signalSessionKey(signal *domain.Signal) string {
+	if signal.UserID != "" {
+		return signal.UserID
+	}
+	return "anon_" + signal.IPAddress + "_" + signal.UserAgent
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+)
+
+var ErrUnknownCategory = errors.New("unknown category")
+
+type CategoryService interface {
+	SeedDefaults(ctx context.Context) error
+	GetTree(ctx context.Context) ([]response.Category, error)
+	Resolve(ctx context.Context, categorySlug string) (*domain.Category, []uuid.UUID, error)
+	GetAll(ctx context.Context) ([]domain.Category, error)
+}
+
+type categoryService struct {
+	repo repository.CategoryRepository
+}
+
+func NewCategoryService(repo repository.CategoryRepository) *categoryService {
+	return &categoryService{
+		repo: repo,
+	}
+}
+
+// SeedDefaults creates the missing categories of domain.DefaultCategories.
+func (s *categoryService) SeedDefaults(ctx context.Context) error {
+	for i, seed := range domain.DefaultCategories {
+		parent := &domain.Category{Name: seed.Name, Slug: slug.Make(seed.Name), Position: i}
+		if err := s.repo.FirstOrCreate(ctx, parent); err != nil {
+			return fmt.Errorf("failed to seed category %s: %w", seed.Name, err)
+		}
+
+		for j, name := range seed.Children {
+			child := &domain.Category{Name: name, Slug: slug.Make(name), ParentID: &parent.ID, Position: j}
+			if err := s.repo.FirstOrCreate(ctx, child); err != nil {
+				return fmt.Errorf("failed to seed category %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *categoryService) GetAll(ctx context.Context) ([]domain.Category, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *categoryService) GetTree(ctx context.Context) ([]response.Category, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return buildCategoryTree(categories, nil), nil
+}
+
+// Resolve finds the category by slug and returns it with the ids of the
+// category and all of its descendants, which is what a filter matches.
+func (s *categoryService) Resolve(ctx context.Context, categorySlug string) (*domain.Category, []uuid.UUID, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	for i := range categories {
+		if categories[i].Slug == categorySlug {
+			return &categories[i], categoryWithDescendants(categories, categories[i].ID), nil
+		}
+	}
+
+	return nil, nil, ErrUnknownCategory
+}
+
+func categoryWithDescendants(categories []domain.Category, rootId uuid.UUID) []uuid.UUID {
+	ids := []uuid.UUID{rootId}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range categories {
+			if c.ParentID != nil && *c.ParentID == ids[i] {
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func buildCategoryTree(categories []domain.Category, parentId *uuid.UUID) []response.Category {
+	nodes := []response.Category{}
+	for _, c := range categories {
+		if (parentId == nil) != (c.ParentID == nil) || (parentId != nil && *c.ParentID != *parentId) {
+			continue
+		}
+		nodes = append(nodes, response.Category{
+			ID:       c.ID,
+			Name:     c.Name,
+			Slug:     c.Slug,
+			Children: buildCategoryTree(categories, &c.ID),
+		})
+	}
+	return nodes
+}
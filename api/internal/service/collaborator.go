@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/mailer"
+	"foundersignal/internal/repository"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidIdeaRole         = errors.New("collaborators can only be editors or viewers")
+	ErrCannotInviteOwner       = errors.New("the owner of the idea cannot be invited")
+	ErrInvitationExpired       = errors.New("the invitation has expired")
+	ErrInvitationAlreadyUsed   = errors.New("the invitation has already been accepted")
+	ErrInvitationEmailMismatch = errors.New("the invitation was sent to another email address")
+)
+
+const defaultInvitationTTL = 7 * 24 * time.Hour
+
+type CollaboratorService interface {
+	Invite(ctx context.Context, userId string, ideaId uuid.UUID, req request.InviteCollaborator) (*response.IdeaInvitation, error)
+	Accept(ctx context.Context, userId string, token string) (*response.AcceptedInvitation, error)
+	GetCollaborators(ctx context.Context, userId string, ideaId uuid.UUID) (*response.IdeaCollaborators, error)
+	UpdateRole(ctx context.Context, userId string, ideaId uuid.UUID, memberId string, req request.UpdateCollaboratorRole) error
+	Remove(ctx context.Context, userId string, ideaId uuid.UUID, memberId string) error
+	RevokeInvitation(ctx context.Context, userId string, ideaId, invitationId uuid.UUID) error
+}
+
+type CollaboratorConfig struct {
+	AppUrl        string
+	InvitationTTL time.Duration
+}
+
+type collaboratorService struct {
+	memberRepo repository.IdeaMemberRepository
+	userRepo   repository.UserRepository
+	access     IdeaAuthorizer
+	mailer     mailer.Mailer
+	cfg        CollaboratorConfig
+}
+
+func NewCollaboratorService(memberRepo repository.IdeaMemberRepository, userRepo repository.UserRepository, access IdeaAuthorizer,
+	mailer mailer.Mailer, cfg CollaboratorConfig) *collaboratorService {
+	if cfg.InvitationTTL <= 0 {
+		cfg.InvitationTTL = defaultInvitationTTL
+	}
+
+	return &collaboratorService{
+		memberRepo: memberRepo,
+		userRepo:   userRepo,
+		access:     access,
+		mailer:     mailer,
+		cfg:        cfg,
+	}
+}
+
+// Invite emails a link to join the idea with the given role, only the owner
+// can invite collaborators.
+func (s *collaboratorService) Invite(ctx context.Context, userId string, ideaId uuid.UUID, req request.InviteCollaborator) (*response.IdeaInvitation, error) {
+	role := domain.IdeaRole(req.Role)
+	if !role.IsInvitable() {
+		return nil, ErrInvalidIdeaRole
+	}
+
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	inviter, err := s.userRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if strings.EqualFold(inviter.Email, email) {
+		return nil, ErrCannotInviteOwner
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	invitation := &domain.IdeaInvitation{
+		IdeaID:    ideaId,
+		Email:     email,
+		Role:      role,
+		Token:     token,
+		InvitedBy: userId,
+		ExpiresAt: time.Now().Add(s.cfg.InvitationTTL),
+	}
+	if err := s.memberRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	go s.sendInvitation(*invitation, idea.Title, displayName(inviter))
+
+	return toInvitationResponse(invitation), nil
+}
+
+func (s *collaboratorService) sendInvitation(invitation domain.IdeaInvitation, ideaTitle, inviterName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	link := fmt.Sprintf("%s/invitations/%s", strings.TrimRight(s.cfg.AppUrl, "/"), invitation.Token)
+	msg := mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("%s invited you to collaborate on %q", inviterName, ideaTitle),
+		Text: fmt.Sprintf("%s invited you to join %q on FounderSignal as %s.\n\nAccept the invitation: %s\n\nThe invitation expires on %s.",
+			inviterName, ideaTitle, invitation.Role, link, invitation.ExpiresAt.Format("January 2, 2006")),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Printf("WARN: Failed to send invitation %s: %v", invitation.ID, err)
+	}
+}
+
+// Accept makes the user a member of the idea, the invitation has to be sent
+// to the email of their account.
+func (s *collaboratorService) Accept(ctx context.Context, userId string, token string) (*response.AcceptedInvitation, error) {
+	invitation, err := s.memberRepo.GetInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	accepted := &response.AcceptedInvitation{
+		IdeaID:    invitation.IdeaID,
+		IdeaTitle: invitation.Idea.Title,
+		Role:      string(invitation.Role),
+	}
+
+	if invitation.AcceptedAt != nil {
+		if invitation.AcceptedBy == userId {
+			return accepted, nil
+		}
+		return nil, ErrInvitationAlreadyUsed
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+	if invitation.Idea.UserID == userId {
+		return nil, ErrCannotInviteOwner
+	}
+
+	if _, err := s.memberRepo.AcceptInvitation(ctx, invitation, userId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationAlreadyUsed
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return accepted, nil
+}
+
+// GetCollaborators is visible to everyone with access to the idea
+func (s *collaboratorService) GetCollaborators(ctx context.Context, userId string, ideaId uuid.UUID) (*response.IdeaCollaborators, error) {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.access.RoleFor(ctx, userId, idea)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, idea.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idea owner: %w", err)
+	}
+
+	members, err := s.memberRepo.GetByIdea(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collaborators: %w", err)
+	}
+
+	res := &response.IdeaCollaborators{
+		Role:    string(role),
+		Owner:   toCollaborator(*owner, domain.IdeaRoleOwner, idea.CreatedAt),
+		Members: make([]response.Collaborator, 0, len(members)),
+	}
+	for _, member := range members {
+		res.Members = append(res.Members, toCollaborator(member.User, member.Role, member.CreatedAt))
+	}
+
+	if role == domain.IdeaRoleOwner {
+		invitations, err := s.memberRepo.GetPendingInvitations(ctx, ideaId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invitations: %w", err)
+		}
+
+		res.Invitations = make([]response.IdeaInvitation, 0, len(invitations))
+		for i := range invitations {
+			res.Invitations = append(res.Invitations, *toInvitationResponse(&invitations[i]))
+		}
+	}
+
+	return res, nil
+}
+
+func (s *collaboratorService) UpdateRole(ctx context.Context, userId string, ideaId uuid.UUID, memberId string, req request.UpdateCollaboratorRole) error {
+	role := domain.IdeaRole(req.Role)
+	if !role.IsInvitable() {
+		return ErrInvalidIdeaRole
+	}
+
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleOwner); err != nil {
+		return err
+	}
+
+	return s.memberRepo.UpdateRole(ctx, ideaId, memberId, role)
+}
+
+// Remove is done by the owner, or by a collaborator leaving the idea
+func (s *collaboratorService) Remove(ctx context.Context, userId string, ideaId uuid.UUID, memberId string) error {
+	required := domain.IdeaRoleOwner
+	if memberId == userId {
+		required = domain.IdeaRoleViewer
+	}
+
+	if _, err := s.access.Authorize(ctx, userId, ideaId, required); err != nil {
+		return err
+	}
+
+	return s.memberRepo.Delete(ctx, ideaId, memberId)
+}
+
+func (s *collaboratorService) RevokeInvitation(ctx context.Context, userId string, ideaId, invitationId uuid.UUID) error {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleOwner); err != nil {
+		return err
+	}
+
+	return s.memberRepo.DeleteInvitation(ctx, ideaId, invitationId)
+}
+
+func newInvitationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func displayName(user *domain.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	if user.Username != "" {
+		return user.Username
+	}
+	return user.Email
+}
+
+func toCollaborator(user domain.User, role domain.IdeaRole, since time.Time) response.Collaborator {
+	return response.Collaborator{
+		UserID:    user.ID,
+		Name:      displayName(&user),
+		Email:     user.Email,
+		ImageURL:  user.ImageURL,
+		Role:      string(role),
+		CreatedAt: since,
+	}
+}
+
+func toInvitationResponse(invitation *domain.IdeaInvitation) *response.IdeaInvitation {
+	return &response.IdeaInvitation{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      string(invitation.Role),
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
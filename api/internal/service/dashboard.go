@@ -17,9 +17,11 @@ import (
 )
 
 type DashboardService interface {
-	GetDashboardData(ctx context.Context, userID string) (*response.DashboardResponse, error)
+	GetDashboardData(ctx context.Context, userID string, workspaceId *uuid.UUID) (*response.DashboardResponse, error)
 	GetRecentActivityForUser(ctx context.Context, userId string) ([]response.ActivityItem, error)
 	GetIdea(ctx context.Context, id uuid.UUID, userId string, specs DashboardIdeaSpecs) (*response.DashboardIdeaResponse, error)
+	// GetIdeaHistory lists the edits of the idea, newest first
+	GetIdeaHistory(ctx context.Context, userId string, ideaId uuid.UUID, queryParams domain.QueryParams) (*response.IdeaHistory, error)
 	GetAudienceForFounder(ctx context.Context, founderId string, withStats bool, queryParams domain.QueryParams) (response.AudienceResponse, error)
 }
 
@@ -31,6 +33,8 @@ type dashboardService struct {
 	audienceRepo repository.AudienceRepository
 	reactionRepo repository.ReactionRepository
 	activityRepo repository.ActivityRepository
+	revisionRepo repository.IdeaRevisionRepository
+	access       IdeaAuthorizer
 }
 
 type signalsResult struct {
@@ -68,7 +72,8 @@ const (
 )
 
 func NewDashboardService(repo repository.IdeaRepository, mvpRepo repository.MVPRepository, feedbackRepo repository.FeedbackRepository, signalRepo repository.SignalRepository,
-	audienceRepo repository.AudienceRepository, reactionRepo repository.ReactionRepository, activityRepo repository.ActivityRepository) *dashboardService {
+	audienceRepo repository.AudienceRepository, reactionRepo repository.ReactionRepository, activityRepo repository.ActivityRepository,
+	revisionRepo repository.IdeaRevisionRepository, access IdeaAuthorizer) *dashboardService {
 	return &dashboardService{
 		repo:         repo,
 		mvpRepo:      mvpRepo,
@@ -77,10 +82,14 @@ func NewDashboardService(repo repository.IdeaRepository, mvpRepo repository.MVPR
 		audienceRepo: audienceRepo,
 		reactionRepo: reactionRepo,
 		activityRepo: activityRepo,
+		revisionRepo: revisionRepo,
+		access:       access,
 	}
 }
 
-func (s *dashboardService) GetDashboardData(ctx context.Context, userID string) (*response.DashboardResponse, error) {
+// GetDashboardData summarizes the ideas of the workspace selected in the
+// switcher, or the personal ideas of the user when workspaceId is nil.
+func (s *dashboardService) GetDashboardData(ctx context.Context, userID string, workspaceId *uuid.UUID) (*response.DashboardResponse, error) {
 	// Get current time and time range
 	now := time.Now()
 	thirtyDaysAgo := now.AddDate(0, -1, 0)
@@ -88,10 +97,11 @@ func (s *dashboardService) GetDashboardData(ctx context.Context, userID string)
 
 	dashboardData := &response.DashboardResponse{}
 
-	userIdeas, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{}, repository.IdeaQuerySpec{
-		WithCounts: true,
-		ByUserId:   userID,
-	})
+	scope := repository.DashboardScope(userID, workspaceId)
+
+	spec := scope
+	spec.WithCounts = true
+	userIdeas, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{}, spec)
 	if err != nil {
 		// This error is from fetching userIdeas, which is critical.
 		fmt.Printf("Failed to get dashboard prerequisites: %v\n", err)
@@ -103,7 +113,7 @@ func (s *dashboardService) GetDashboardData(ctx context.Context, userID string)
 		userIdeasMap[idea.ID.String()] = idea
 	}
 
-	metrics, err := s.getAnalyticsMetrics(ctx, userID, thirtyDaysAgo, now, userIdeas)
+	metrics, err := s.getAnalyticsMetrics(ctx, scope, thirtyDaysAgo, now, userIdeas)
 	if err != nil {
 		fmt.Printf("Failed to get analytics metrics: %v\n", err)
 		return nil, fmt.Errorf("failed to get analytics metrics: %w", err)
@@ -128,9 +138,14 @@ func (s *dashboardService) GetDashboardData(ctx context.Context, userID string)
 }
 
 func (s *dashboardService) GetIdea(ctx context.Context, id uuid.UUID, userId string, specs DashboardIdeaSpecs) (*response.DashboardIdeaResponse, error) {
-	rawIdea, _, err := s.repo.GetByID(ctx, id, nil, nil)
+	if _, err := s.access.Authorize(ctx, userId, id, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	// the dashboard shows the comments, signals and audience of the idea
+	rawIdea, err := s.repo.GetByID(ctx, id, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get idea by ID: %w", err)
+		return nil, err
 	}
 
 	var analyticsData response.AnalyticsData
@@ -197,10 +212,9 @@ func (s *dashboardService) GetAudienceForFounder(ctx context.Context, founderId
 			_ideas[audience.IdeaID.String()] = nil
 		}
 
-		userIdeas, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{}, repository.IdeaQuerySpec{
-			ByUserId:   founderId,
-			WithCounts: true,
-		})
+		spec := repository.DashboardScope(founderId, queryParams.WorkspaceID)
+		spec.WithCounts = true
+		userIdeas, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{}, spec)
 		if err != nil {
 			// This error is from fetching userIdeas, which is critical.
 			fmt.Printf("Failed to get dashboard prerequisites: %v\n", err)
@@ -253,7 +267,7 @@ func (s *dashboardService) GetAudienceForFounder(ctx context.Context, founderId
 		thirtyDaysAgo := now.AddDate(0, -1, 0)
 
 		// activity data for current and previous periods
-		currentPeriodIdeasWithActivity, err := s.repo.GetIdeasWithActivity(ctx, founderId, thirtyDaysAgo, now)
+		currentPeriodIdeasWithActivity, err := s.repo.GetIdeasWithActivity(ctx, repository.IdeaQuerySpec{ByUserId: founderId}, thirtyDaysAgo, now)
 		if err != nil {
 			return response.AudienceResponse{}, fmt.Errorf("failed to get current period ideas activity for stats: %w", err)
 		}
@@ -262,7 +276,7 @@ func (s *dashboardService) GetAudienceForFounder(ctx context.Context, founderId
 		duration := now.Sub(thirtyDaysAgo)
 		previousPeriodStart := thirtyDaysAgo.Add(-duration) // subtract the same duration for the previous period
 
-		previousPeriodIdeasWithActivity, err := s.repo.GetIdeasWithActivity(ctx, founderId, previousPeriodStart, previousPeriodEnd)
+		previousPeriodIdeasWithActivity, err := s.repo.GetIdeasWithActivity(ctx, repository.IdeaQuerySpec{ByUserId: founderId}, previousPeriodStart, previousPeriodEnd)
 		if err != nil {
 			return response.AudienceResponse{}, fmt.Errorf("failed to get previous period ideas activity for stats: %w", err)
 		}
@@ -275,11 +289,13 @@ func (s *dashboardService) GetAudienceForFounder(ctx context.Context, founderId
 		Stats:     audienceStats,
 		Total:     total,
 		Metrics:   metrics,
+
+		NextCursor: repository.NextAudienceCursor(queryParams, audienceMembersDomain),
 	}, nil
 }
 
-func (s *dashboardService) getAnalyticsMetrics(ctx context.Context, userID string, from, to time.Time, ideas []*domain.Idea) (response.Metrics, error) {
-	currentPeriodIdeas, err := s.repo.GetIdeasWithActivity(ctx, userID, from, to)
+func (s *dashboardService) getAnalyticsMetrics(ctx context.Context, scope repository.IdeaQuerySpec, from, to time.Time, ideas []*domain.Idea) (response.Metrics, error) {
+	currentPeriodIdeas, err := s.repo.GetIdeasWithActivity(ctx, scope, from, to)
 	if err != nil {
 		return response.Metrics{}, err
 	}
@@ -287,7 +303,7 @@ func (s *dashboardService) getAnalyticsMetrics(ctx context.Context, userID strin
 	previousPeriodEnd := from.Add(-time.Nanosecond)
 	previousPeriodStart := from.AddDate(0, 0, -int(to.Sub(from).Hours()/24))
 
-	previousPeriodIdeas, err := s.repo.GetIdeasWithActivity(ctx, userID, previousPeriodStart, previousPeriodEnd)
+	previousPeriodIdeas, err := s.repo.GetIdeasWithActivity(ctx, scope, previousPeriodStart, previousPeriodEnd)
 	if err != nil {
 		return response.Metrics{}, err
 	}
@@ -402,6 +418,11 @@ func (s *dashboardService) getAnalyticsData(
 		return result, fmt.Errorf("failed to get daily signups: %w", err)
 	}
 
+	changeMarkers, err := s.getChangeMarkers(ctx, ideaIDs, from, to)
+	if err != nil {
+		return result, fmt.Errorf("failed to get change markers: %w", err)
+	}
+
 	for _, idea := range ideas {
 		var ideaDataPoints []response.DataPoint
 		ideaTotalViews := 0
@@ -466,6 +487,7 @@ func (s *dashboardService) getAnalyticsData(
 			IdeaTitle:  idea.Title,
 			DataPoints: ideaDataPoints,
 			Totals:     ideaTotals,
+			Markers:    changeMarkers[idea.ID],
 		})
 	}
 
@@ -570,6 +592,9 @@ func (s *dashboardService) GetRecentActivityForUser(
 	for _, reaction := range recentReactions {
 		ideaIdSet[reaction.IdeaID] = struct{}{}
 	}
+	for _, activity := range recentActivities {
+		ideaIdSet[activity.IdeaID] = struct{}{}
+	}
 
 	var ideaIDsToFetch []uuid.UUID
 	for id := range ideaIdSet {
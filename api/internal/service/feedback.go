@@ -7,11 +7,10 @@ import (
 	"foundersignal/internal/dto"
 	"foundersignal/internal/dto/request"
 	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/sentiment"
 	"foundersignal/internal/repository"
 	"foundersignal/internal/websocket"
 	"log"
-	"math/rand"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,23 +19,38 @@ import (
 type FeedbackService interface {
 	Add(ctx context.Context, parsedIdeaId uuid.UUID, parsedParentId *uuid.UUID, userId string, fb *request.CreateFeedback) (uuid.UUID, error)
 	Update(ctx context.Context, userId string, feedbackId uuid.UUID, comment string) error
-	GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, queryParams domain.QueryParams) (*response.IdeaCommentResponse, error)
+	GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, shareToken string, queryParams domain.QueryParams) (*response.IdeaCommentResponse, error)
 	Delete(ctx context.Context, feedbackId uuid.UUID, userId string) error
 	GetCountByIdeaId(ctx context.Context, ideaId uuid.UUID) (int64, error)
+
+	// BackfillSentiment scores the comments that were never scored, the ones
+	// written before the analyzer and the ones whose scoring was interrupted.
+	BackfillSentiment(ctx context.Context) error
 }
 
+const (
+	sentimentTimeout       = 30 * time.Second
+	sentimentBackfillBatch = 20 // comments scored by one AI call
+)
+
 type fbService struct {
 	repo        repository.FeedbackRepository
 	ideaRepo    repository.IdeaRepository
 	broadcaster websocket.ActivityBroadcaster
+	shares      IdeaShareService
+	access      IdeaAuthorizer
+	analyzer    SentimentAnalyzer
 }
 
 func NewFeedbackService(repo repository.FeedbackRepository, ideaRepo repository.IdeaRepository,
-	broadcaster websocket.ActivityBroadcaster) *fbService {
+	broadcaster websocket.ActivityBroadcaster, shares IdeaShareService, access IdeaAuthorizer, analyzer SentimentAnalyzer) *fbService {
 	return &fbService{
 		repo:        repo,
 		ideaRepo:    ideaRepo,
 		broadcaster: broadcaster,
+		shares:      shares,
+		access:      access,
+		analyzer:    analyzer,
 	}
 }
 
@@ -45,7 +59,7 @@ func (s *fbService) Add(ctx context.Context, ideaId uuid.UUID, parsedParentId *u
 		IdeaID:         ideaId,
 		UserID:         userId,
 		Comment:        req.Comment,
-		SentimentScore: calculateSentiment(req.Comment),
+		SentimentScore: sentiment.Neutral, // until it is scored in the background
 	}
 
 	if parsedParentId != nil && *parsedParentId != uuid.Nil {
@@ -63,8 +77,9 @@ func (s *fbService) Add(ctx context.Context, ideaId uuid.UUID, parsedParentId *u
 	if err != nil {
 		return uuid.Nil, err
 	}
+	s.scoreSentiment(feedback.ID, feedback.Comment)
 
-	idea, _, err := s.ideaRepo.GetByID(ctx, ideaId, nil, nil)
+	idea, err := s.ideaRepo.GetByID(ctx, ideaId, nil)
 	if err != nil {
 		log.Printf("Error fetching idea %s for broadcasting feedback: %v", ideaId, err)
 		// Continue without broadcasting
@@ -98,17 +113,32 @@ func (s *fbService) Update(ctx context.Context, userId string, feedbackId uuid.U
 	if err != nil {
 		return fmt.Errorf("error updating feedback: %w", err)
 	}
+	s.scoreSentiment(feedbackId, comment)
 
 	return nil
 }
 
-func (s *fbService) GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, queryParams domain.QueryParams) (*response.IdeaCommentResponse, error) {
+func (s *fbService) GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, shareToken string, queryParams domain.QueryParams) (*response.IdeaCommentResponse, error) {
+	idea, err := s.ideaRepo.GetByID(ctx, ideaId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var viewerId string
+	if userId != nil {
+		viewerId = *userId
+	}
+	if _, err := s.shares.CanView(ctx, idea, viewerId, shareToken); err != nil {
+		return nil, err
+	}
+
 	feedbacks, total, err := s.repo.GetByIdea(ctx, ideaId, &queryParams)
 	if err != nil {
 		return nil, err
 	}
 
 	comments := dto.FeedbackToIdeaComments(feedbacks, userId, total)
+	comments.NextCursor = repository.NextFeedbackCursor(queryParams, feedbacks)
 
 	return comments, nil
 }
@@ -119,17 +149,18 @@ func (s *fbService) Delete(ctx context.Context, feedbackId uuid.UUID, userId str
 		return err
 	}
 
-	idea, _, err := s.ideaRepo.GetByID(ctx, feedback.IdeaID, nil, nil)
+	idea, err := s.ideaRepo.GetByID(ctx, feedback.IdeaID, nil)
 	if err != nil {
 		// Log the error but don't fail, as the primary goal is deletion authorization.
 		// The idea might be deleted, but comments should still be manageable if they exist.
 		log.Printf("Error fetching idea %s for broadcasting feedback deletion: %v", feedback.IdeaID, err)
 	}
 
+	// editors moderate the comments of the idea
 	isCommentAuthor := feedback.UserID == userId
-	isIdeaOwner := idea != nil && idea.UserID == userId
+	canModerate := idea != nil && s.access.AuthorizeIdea(ctx, userId, idea, domain.IdeaRoleEditor) == nil
 
-	if !isCommentAuthor && !isIdeaOwner {
+	if !isCommentAuthor && !canModerate {
 		return fmt.Errorf("user %s is not authorized to delete feedback %s", userId, feedbackId)
 	}
 
@@ -150,33 +181,67 @@ func (s *fbService) GetCountByIdeaId(ctx context.Context, ideaId uuid.UUID) (int
 	return count, nil
 }
 
-// example of a very naive keyword-based approach (for illustration only)
-func calculateSentiment(comment string) float64 {
-	source := rand.NewSource(time.Now().UnixNano())
-	r := rand.New(source)
+func (s *fbService) BackfillSentiment(ctx context.Context) error {
+	total := 0
+	for {
+		feedbacks, err := s.repo.GetUnscored(ctx, sentimentBackfillBatch)
+		if err != nil {
+			return fmt.Errorf("failed to find unscored feedback: %w", err)
+		}
+		if len(feedbacks) == 0 {
+			break
+		}
 
-	lowerComment := strings.ToLower(comment)
-	positiveKeywords := []string{"great", "love", "excellent", "good", "amazing"}
-	negativeKeywords := []string{"bad", "terrible", "hate", "poor", "awful"}
-	score := 0.5 // Start neutral
+		comments := make([]string, len(feedbacks))
+		for i, fb := range feedbacks {
+			comments[i] = fb.Comment
+		}
 
-	for _, pk := range positiveKeywords {
-		if strings.Contains(lowerComment, pk) {
-			score += 0.1
+		sentiments, err := s.analyzer.Analyze(ctx, comments)
+		if err != nil {
+			return fmt.Errorf("failed to score feedback: %w", err)
 		}
-	}
-	for _, nk := range negativeKeywords {
-		if strings.Contains(lowerComment, nk) {
-			score -= 0.1
+
+		stored := 0
+		for i, fb := range feedbacks {
+			ok, err := s.repo.SetSentiment(ctx, fb.ID, fb.Comment, sentiments[i].Score, sentiments[i].Source)
+			if err != nil {
+				return fmt.Errorf("failed to save the sentiment of feedback %s: %w", fb.ID, err)
+			}
+			if ok && sentiments[i].Source != domain.SentimentSourceLexicon {
+				stored++
+			}
+		}
+
+		total += stored
+		// comments edited meanwhile are scored by their edit and the ones the AI
+		// could not score are retried on the next start, stop instead of fetching them again
+		if stored == 0 || len(feedbacks) < sentimentBackfillBatch {
+			break
 		}
 	}
-	// Clamp score between 0.0 and 1.0
-	if score > 1.0 {
-		return 0.5 + r.Float64()*0.5
-	}
-	if score < 0.0 {
-		return r.Float64() * 0.49
+
+	if total > 0 {
+		log.Printf("Scored the sentiment of %d comments", total)
 	}
+	return nil
+}
 
-	return score
+// scoreSentiment scores the comment in the background. The score of a comment
+// that was edited in the meantime is dropped, the edit scores it again.
+func (s *fbService) scoreSentiment(feedbackId uuid.UUID, comment string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sentimentTimeout)
+		defer cancel()
+
+		sentiments, err := s.analyzer.Analyze(ctx, []string{comment})
+		if err != nil || len(sentiments) != 1 {
+			log.Printf("WARN: Failed to score the sentiment of feedback %s: %v", feedbackId, err)
+			return
+		}
+
+		if _, err := s.repo.SetSentiment(ctx, feedbackId, comment, sentiments[0].Score, sentiments[0].Source); err != nil {
+			log.Printf("WARN: Failed to save the sentiment of feedback %s: %v", feedbackId, err)
+		}
+	}()
 }
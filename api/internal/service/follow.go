@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg"
+	"foundersignal/internal/pkg/mailer"
+	"foundersignal/internal/repository"
+	"foundersignal/internal/websocket"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrCannotFollowOwnIdea = errors.New("you cannot follow your own idea")
+
+type FollowService interface {
+	Follow(ctx context.Context, userId string, ideaId uuid.UUID, req request.FollowIdea) (*response.FollowStatus, error)
+	Unfollow(ctx context.Context, userId string, ideaId uuid.UUID) (*response.FollowStatus, error)
+	GetStatus(ctx context.Context, userId string, ideaId uuid.UUID) (*response.FollowStatus, error)
+	GetFollowedIdeas(ctx context.Context, userId string, queryParams domain.QueryParams) (*response.IdeaListResponse, error)
+
+	// PostUpdate publishes a progress update and notifies the followers in-app,
+	// they get it by email in their next digest.
+	PostUpdate(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateIdeaUpdate) (*response.IdeaUpdate, error)
+	GetUpdates(ctx context.Context, userId string, ideaId uuid.UUID, queryParams domain.QueryParams) (*response.IdeaUpdates, error)
+	DeleteUpdate(ctx context.Context, userId string, ideaId, updateId uuid.UUID) error
+
+	// SendDigests emails the pending updates to each follower, one email per follower
+	SendDigests(ctx context.Context) error
+	Start(ctx context.Context)
+}
+
+type FollowConfig struct {
+	AppUrl         string
+	DigestInterval time.Duration
+}
+
+type followService struct {
+	repo         repository.IdeaFollowRepository
+	updateRepo   repository.IdeaUpdateRepository
+	ideaRepo     repository.IdeaRepository
+	userRepo     repository.UserRepository
+	activityRepo repository.ActivityRepository
+	broadcaster  websocket.ActivityBroadcaster
+	mailer       mailer.Mailer
+	shares       IdeaShareService
+	access       IdeaAuthorizer
+	cfg          FollowConfig
+}
+
+func NewFollowService(repo repository.IdeaFollowRepository, updateRepo repository.IdeaUpdateRepository, ideaRepo repository.IdeaRepository,
+	userRepo repository.UserRepository, activityRepo repository.ActivityRepository, broadcaster websocket.ActivityBroadcaster, mailer mailer.Mailer,
+	shares IdeaShareService, access IdeaAuthorizer, cfg FollowConfig) *followService {
+	return &followService{
+		repo:         repo,
+		updateRepo:   updateRepo,
+		ideaRepo:     ideaRepo,
+		userRepo:     userRepo,
+		activityRepo: activityRepo,
+		broadcaster:  broadcaster,
+		mailer:       mailer,
+		shares:       shares,
+		access:       access,
+		cfg:          cfg,
+	}
+}
+
+// visibleIdea loads the idea when the user can see its public page, the
+// private and non-active ideas are only shown to their team.
+func (s *followService) visibleIdea(ctx context.Context, userId string, ideaId uuid.UUID) (*domain.Idea, error) {
+	idea, err := s.ideaRepo.GetByID(ctx, ideaId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.shares.CanView(ctx, idea, userId, ""); err != nil {
+		return nil, err
+	}
+
+	return idea, nil
+}
+
+func (s *followService) Follow(ctx context.Context, userId string, ideaId uuid.UUID, req request.FollowIdea) (*response.FollowStatus, error) {
+	idea, err := s.visibleIdea(ctx, userId, ideaId)
+	if err != nil {
+		return nil, err
+	}
+	if idea.UserID == userId {
+		return nil, ErrCannotFollowOwnIdea
+	}
+
+	follower := &domain.IdeaFollower{
+		IdeaID:      ideaId,
+		UserID:      userId,
+		EmailDigest: req.EmailDigest == nil || *req.EmailDigest,
+		DigestedAt:  time.Now(), // only the updates posted from now on are emailed
+	}
+	if err := s.repo.Follow(ctx, follower); err != nil {
+		return nil, fmt.Errorf("failed to follow idea: %w", err)
+	}
+
+	return s.status(ctx, ideaId, true, follower.EmailDigest)
+}
+
+func (s *followService) Unfollow(ctx context.Context, userId string, ideaId uuid.UUID) (*response.FollowStatus, error) {
+	if err := s.repo.Unfollow(ctx, ideaId, userId); err != nil {
+		return nil, fmt.Errorf("failed to unfollow idea: %w", err)
+	}
+
+	return s.status(ctx, ideaId, false, false)
+}
+
+func (s *followService) GetStatus(ctx context.Context, userId string, ideaId uuid.UUID) (*response.FollowStatus, error) {
+	if _, err := s.visibleIdea(ctx, userId, ideaId); err != nil {
+		return nil, err
+	}
+
+	follow, err := s.repo.GetFollow(ctx, ideaId, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow: %w", err)
+	}
+
+	return s.status(ctx, ideaId, follow != nil, follow != nil && follow.EmailDigest)
+}
+
+func (s *followService) status(ctx context.Context, ideaId uuid.UUID, following, emailDigest bool) (*response.FollowStatus, error) {
+	followers, err := s.repo.CountFollowers(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+
+	return &response.FollowStatus{
+		Following:   following,
+		EmailDigest: emailDigest,
+		Followers:   followers,
+	}, nil
+}
+
+func (s *followService) GetFollowedIdeas(ctx context.Context, userId string, queryParams domain.QueryParams) (*response.IdeaListResponse, error) {
+	ideas, total, err := s.repo.GetFollowedIdeas(ctx, userId, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed ideas: %w", err)
+	}
+
+	return dto.ToIdeasListResponse(ideas, total, nil), nil
+}
+
+func (s *followService) PostUpdate(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateIdeaUpdate) (*response.IdeaUpdate, error) {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &domain.IdeaUpdate{
+		IdeaID:  ideaId,
+		UserID:  userId,
+		Content: strings.TrimSpace(req.Content),
+	}
+	if err := s.updateRepo.Create(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to post update: %w", err)
+	}
+
+	go s.notifyFollowers(*idea, *update)
+
+	author, err := s.userRepo.FindByID(ctx, userId)
+	if err != nil {
+		log.Printf("WARN: Failed to find the author of update %s: %v", update.ID, err)
+	}
+	if author != nil {
+		update.User = *author
+	}
+
+	res := toIdeaUpdate(*update)
+	return &res, nil
+}
+
+// notifyFollowers adds the update to the activity feed of every follower and
+// pushes it to the ones connected to the Hub.
+func (s *followService) notifyFollowers(idea domain.Idea, update domain.IdeaUpdate) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	followerIds, err := s.repo.GetFollowerIDs(ctx, idea.ID, update.UserID)
+	if err != nil {
+		log.Printf("WARN: Failed to get the followers of idea %s: %v", idea.ID, err)
+		return
+	}
+
+	message := fmt.Sprintf("New update on '%s': \"%s\"", idea.Title, pkg.TruncateComment(update.Content, 60))
+	for _, followerId := range followerIds {
+		activity := &domain.Activity{
+			UserID:      followerId,
+			IdeaID:      idea.ID,
+			Message:     message,
+			Type:        domain.ActivityTypeIdeaUpdate,
+			TriggeredBy: &update.UserID,
+			ReferenceID: update.ID.String(),
+		}
+		if err := s.activityRepo.Create(ctx, activity); err != nil {
+			log.Printf("WARN: Failed to create the update activity of follower %s: %v", followerId, err)
+			continue
+		}
+
+		s.broadcaster.FormatAndBroadcastIdeaUpdate(followerId, *activity, idea.Title)
+	}
+}
+
+func (s *followService) GetUpdates(ctx context.Context, userId string, ideaId uuid.UUID, queryParams domain.QueryParams) (*response.IdeaUpdates, error) {
+	if _, err := s.visibleIdea(ctx, userId, ideaId); err != nil {
+		return nil, err
+	}
+
+	updates, total, err := s.updateRepo.GetByIdea(ctx, ideaId, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updates: %w", err)
+	}
+
+	res := &response.IdeaUpdates{
+		Updates:    make([]response.IdeaUpdate, 0, len(updates)),
+		Total:      total,
+		NextCursor: repository.NextIdeaUpdateCursor(queryParams, updates),
+	}
+	for _, update := range updates {
+		res.Updates = append(res.Updates, toIdeaUpdate(update))
+	}
+
+	return res, nil
+}
+
+func (s *followService) DeleteUpdate(ctx context.Context, userId string, ideaId, updateId uuid.UUID) error {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return err
+	}
+
+	update, err := s.updateRepo.GetByID(ctx, updateId)
+	if err != nil {
+		return err
+	}
+	if update.IdeaID != ideaId {
+		return gorm.ErrRecordNotFound
+	}
+
+	return s.updateRepo.Delete(ctx, updateId)
+}
+
+// SendDigests groups the pending updates by follower. The updates of a
+// follower are only marked as sent once their email went out, so a failed
+// email is retried with the next digest.
+func (s *followService) SendDigests(ctx context.Context) error {
+	until := time.Now()
+	entries, err := s.repo.GetPendingDigests(ctx, until)
+	if err != nil {
+		return fmt.Errorf("failed to get pending digests: %w", err)
+	}
+
+	byUser := make(map[string][]repository.DigestEntry)
+	var userIds []string
+	for _, entry := range entries {
+		if _, ok := byUser[entry.UserID]; !ok {
+			userIds = append(userIds, entry.UserID)
+		}
+		byUser[entry.UserID] = append(byUser[entry.UserID], entry)
+	}
+
+	sent := 0
+	for _, userId := range userIds {
+		user, err := s.userRepo.FindByID(ctx, userId)
+		if err != nil || user == nil || user.Email == "" {
+			log.Printf("WARN: Failed to find the email of follower %s: %v", userId, err)
+			continue
+		}
+
+		if err := s.mailer.Send(ctx, s.digestMessage(user, byUser[userId])); err != nil {
+			log.Printf("WARN: Failed to send the digest of follower %s: %v", userId, err)
+			continue
+		}
+
+		if err := s.repo.MarkDigested(ctx, userId, until); err != nil {
+			log.Printf("WARN: Failed to mark the digest of follower %s as sent: %v", userId, err)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		log.Printf("Sent %d update digests", sent)
+	}
+	return nil
+}
+
+func (s *followService) digestMessage(user *domain.User, entries []repository.DigestEntry) mailer.Message {
+	var text, htmlBody strings.Builder
+	text.WriteString("Here is what happened on the ideas you follow:\n")
+	htmlBody.WriteString("<p>Here is what happened on the ideas you follow:</p>")
+
+	var currentIdea uuid.UUID
+	for _, entry := range entries {
+		if entry.IdeaID != currentIdea {
+			if currentIdea != uuid.Nil {
+				htmlBody.WriteString("</ul>")
+			}
+			currentIdea = entry.IdeaID
+			link := fmt.Sprintf("%s/explore/%s", strings.TrimRight(s.cfg.AppUrl, "/"), entry.IdeaID)
+
+			fmt.Fprintf(&text, "\n%s (%s)\n", entry.IdeaTitle, link)
+			fmt.Fprintf(&htmlBody, `<h3><a href="%s">%s</a></h3><ul>`, html.EscapeString(link), html.EscapeString(entry.IdeaTitle))
+		}
+
+		date := entry.CreatedAt.Format("Jan 02")
+		fmt.Fprintf(&text, "- %s: %s\n", date, entry.Content)
+		fmt.Fprintf(&htmlBody, "<li><strong>%s</strong>: %s</li>", date, html.EscapeString(entry.Content))
+	}
+	htmlBody.WriteString("</ul>")
+
+	subject := fmt.Sprintf("%d new updates on the ideas you follow", len(entries))
+	if len(entries) == 1 {
+		subject = fmt.Sprintf("New update on %s", entries[0].IdeaTitle)
+	}
+
+	return mailer.Message{
+		To:      user.Email,
+		Subject: subject,
+		Text:    text.String(),
+		HTML:    htmlBody.String(),
+	}
+}
+
+// Start sends the digests on every interval until the context is cancelled,
+// the first ones are sent after one interval.
+func (s *followService) Start(ctx context.Context) {
+	if s.cfg.DigestInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.DigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SendDigests(ctx); err != nil {
+				log.Printf("WARN: %v", err)
+			}
+		}
+	}
+}
+
+func toIdeaUpdate(update domain.IdeaUpdate) response.IdeaUpdate {
+	return response.IdeaUpdate{
+		ID:         update.ID,
+		Content:    update.Content,
+		AuthorName: publicName(&update.User),
+		CreatedAt:  update.CreatedAt,
+	}
+}
+
+// publicName is the name shown on public pages, the email is never shown there
+func publicName(user *domain.User) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	if user.Username != "" {
+		return user.Username
+	}
+	return "The team"
+}
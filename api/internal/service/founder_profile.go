@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type FounderProfileService interface {
+	// GetPublicProfile returns the profile of the founder with the username,
+	// a hidden profile is not found.
+	GetPublicProfile(ctx context.Context, username string, queryParams domain.QueryParams) (*response.FounderProfile, error)
+	GetSettings(ctx context.Context, userId string) (*domain.FounderProfile, error)
+	UpdateSettings(ctx context.Context, userId string, req request.UpdateProfile) (*domain.FounderProfile, error)
+}
+
+type founderProfileService struct {
+	repo     repository.FounderProfileRepository
+	userRepo repository.UserRepository
+	ideaRepo repository.IdeaRepository
+}
+
+func NewFounderProfileService(repo repository.FounderProfileRepository, userRepo repository.UserRepository,
+	ideaRepo repository.IdeaRepository) *founderProfileService {
+	return &founderProfileService{
+		repo:     repo,
+		userRepo: userRepo,
+		ideaRepo: ideaRepo,
+	}
+}
+
+func (s *founderProfileService) GetPublicProfile(ctx context.Context, username string, queryParams domain.QueryParams) (*response.FounderProfile, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find founder: %w", err)
+	}
+	if user == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	profile, err := s.getProfile(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !profile.IsPublic {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	res := &response.FounderProfile{
+		Username: user.Username,
+		Name:     strings.TrimSpace(user.FirstName + " " + user.LastName),
+		ImageURL: user.ImageURL,
+		Bio:      profile.Bio,
+		Links:    profile.Links,
+		JoinedAt: user.CreatedAt,
+	}
+
+	if profile.ShowStats {
+		reputation, err := s.repo.GetReputation(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get founder reputation: %w", err)
+		}
+		res.Reputation = &response.FounderReputation{
+			IdeasLaunched: reputation.IdeasLaunched,
+			TotalSignups:  reputation.TotalSignups,
+		}
+	}
+
+	if profile.ShowIdeas {
+		ideas, err := s.publicIdeas(ctx, user.ID, queryParams, profile.ShowStats)
+		if err != nil {
+			return nil, err
+		}
+		res.Ideas = ideas
+	}
+
+	return res, nil
+}
+
+// publicIdeas lists the active ideas of the founder that are not private.
+// Hidden stats are neither returned nor usable to sort the ideas.
+func (s *founderProfileService) publicIdeas(ctx context.Context, userId string, queryParams domain.QueryParams, withStats bool) (*response.IdeaListResponse, error) {
+	isPrivate := false
+	spec := repository.IdeaQuerySpec{
+		ByUserId:       userId,
+		IncludePrivate: &isPrivate,
+		Status:         domain.IdeaStatusActive,
+		WithCounts:     withStats,
+	}
+	if !withStats {
+		queryParams.SortBy = ""
+	}
+
+	ideas, total, err := s.ideaRepo.GetIdeas(ctx, queryParams, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get founder ideas: %w", err)
+	}
+
+	res := dto.ToIdeasListResponse(ideas, total, nil)
+	res.NextCursor = repository.NextIdeaCursor(queryParams, ideas)
+	if !withStats {
+		for i := range res.Ideas {
+			res.Ideas[i].Views = 0
+			res.Ideas[i].Signups = 0
+			res.Ideas[i].EngagementRate = 0
+		}
+	}
+
+	return res, nil
+}
+
+func (s *founderProfileService) GetSettings(ctx context.Context, userId string) (*domain.FounderProfile, error) {
+	return s.getProfile(ctx, userId)
+}
+
+func (s *founderProfileService) UpdateSettings(ctx context.Context, userId string, req request.UpdateProfile) (*domain.FounderProfile, error) {
+	links := make([]domain.ProfileLink, 0, len(req.Links))
+	for _, link := range req.Links {
+		links = append(links, domain.ProfileLink{
+			Label: strings.TrimSpace(link.Label),
+			URL:   strings.TrimSpace(link.URL),
+		})
+	}
+
+	profile := &domain.FounderProfile{
+		UserID:    userId,
+		Bio:       strings.TrimSpace(req.Bio),
+		Links:     datatypes.NewJSONSlice(links),
+		IsPublic:  req.IsPublic,
+		ShowIdeas: req.ShowIdeas,
+		ShowStats: req.ShowStats,
+	}
+	if err := s.repo.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save founder profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// getProfile returns the saved profile of the user or the default one
+func (s *founderProfileService) getProfile(ctx context.Context, userId string) (*domain.FounderProfile, error) {
+	profile, err := s.repo.GetByUser(ctx, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultFounderProfile(userId), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get founder profile: %w", err)
+	}
+
+	return profile, nil
+}
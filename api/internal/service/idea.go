@@ -9,30 +9,41 @@ import (
 	"foundersignal/internal/dto/request"
 	"foundersignal/internal/dto/response"
 	"foundersignal/internal/repository"
+	"foundersignal/internal/websocket"
 	"foundersignal/pkg/validator"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gosimple/slug"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/text/language"
 	"gorm.io/datatypes"
-	"gorm.io/gorm"
 )
 
 type IdeaService interface {
-	Create(ctx context.Context, userId string, req *request.CreateIdea) (uuid.UUID, uuid.UUID, error)
+	Create(ctx context.Context, userId string, req *request.CreateIdea) (*response.IdeaCreated, error)
 	Update(ctx context.Context, userId string, ideaId uuid.UUID, req request.UpdateIdea) error
 	Delete(ctx context.Context, userId string, ideaId uuid.UUID) error
 	GetIdeas(ctx context.Context, queryParams domain.QueryParams) (*response.IdeaListResponse, error)
 	GetUserIdeas(ctx context.Context, userId string, getStats bool, queryParams domain.QueryParams) (*response.IdeaListResponse, error)
-	GetByID(ctx context.Context, id uuid.UUID, userId string) (*response.PublicIdeaResponse, error)
-	RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID, userID string, eventType string, ipAddress string, userAgent string, metadata map[string]interface{}) error
+	GetByID(ctx context.Context, id uuid.UUID, userId, shareToken string) (*response.PublicIdeaResponse, error)
+	RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID, userID, shareToken string, eventType string, ipAddress string, userAgent string, metadata map[string]interface{}) (*response.WaitlistPosition, error)
+
+	// Import creates the ideas of a JSON or CSV file, each row on its own. The
+	// rows that cannot be created are reported with their errors.
+	Import(ctx context.Context, userId string, workspaceId *uuid.UUID, req request.ImportIdeas, filename string, data []byte) (*response.IdeaImport, error)
+	// Export lists the ideas of the workspace, or the ideas owned by the user
+	// when workspaceId is nil, with their MVPs and reports.
+	Export(ctx context.Context, userId string, workspaceId *uuid.UUID) (*response.IdeaExport, error)
 }
 
 type IdeaServiceConfig struct {
 	StarterPlanIdeaCreationDays int
+	DuplicateSimilarity         float64 // a new idea at least this similar to a public one gets a warning
+	RelatedMinSimilarity        float64
 }
 
 type ideaService struct {
@@ -41,36 +52,81 @@ type ideaService struct {
 	mvpRepo      repository.MVPRepository
 	signalRepo   repository.SignalRepository
 	audienceRepo repository.AudienceRepository
+	revisionRepo repository.IdeaRevisionRepository
+	reportRepo   repository.ReportRepository
+
+	aiService  AIService
+	shareCards ShareCardService
+	waitlist   WaitlistService
+	surveys    SurveyService
+	search     IdeaSearchService
+	categories CategoryService
+	images     IdeaImageService
+	shares     IdeaShareService
+	statuses   IdeaStatusMachine
+	access     IdeaAuthorizer
+	config     IdeaServiceConfig
+
+	broadcaster websocket.ActivityBroadcaster
+
+	// related ideas are looked up on every public view, keep them for a while
+	relatedMu sync.Mutex
+	related   map[uuid.UUID]cachedRelatedIdeas
+}
 
-	aiService AIService
-	config    IdeaServiceConfig
+type cachedRelatedIdeas struct {
+	matches   []repository.ScoredIdea
+	expiresAt time.Time
 }
 
 const (
 	RegisteredUserPlaceholderEmail = "registered_user"
 	AnonymousUserPlaceholderEmail  = "anonymous_user"
+
+	maxRelatedIdeas      = 3
+	maxDuplicateWarnings = 3
+	duplicateCheckWait   = 3 * time.Second
+	relatedIdeasTTL      = 30 * time.Minute
+	maxCachedRelated     = 1024
+
+	// cursor sort of the hybrid search results
+	relevanceSort = "relevance"
 )
 
 func NewIdeasService(repo repository.IdeaRepository, mvpRepo repository.MVPRepository, u repository.UserRepository, signalRepo repository.SignalRepository,
-	audienceRepo repository.AudienceRepository, aiService AIService, config IdeaServiceConfig) *ideaService {
+	audienceRepo repository.AudienceRepository, revisionRepo repository.IdeaRevisionRepository, reportRepo repository.ReportRepository, aiService AIService, shareCards ShareCardService, waitlist WaitlistService, surveys SurveyService, search IdeaSearchService,
+	categories CategoryService, images IdeaImageService, shares IdeaShareService, statuses IdeaStatusMachine, access IdeaAuthorizer, broadcaster websocket.ActivityBroadcaster, config IdeaServiceConfig) *ideaService {
 	return &ideaService{
 		u:            u,
 		repo:         repo,
 		mvpRepo:      mvpRepo,
 		signalRepo:   signalRepo,
 		audienceRepo: audienceRepo,
+		revisionRepo: revisionRepo,
+		reportRepo:   reportRepo,
 		aiService:    aiService,
+		shareCards:   shareCards,
+		waitlist:     waitlist,
+		surveys:      surveys,
+		search:       search,
+		categories:   categories,
+		images:       images,
+		shares:       shares,
+		statuses:     statuses,
+		access:       access,
 		config:       config,
+		broadcaster:  broadcaster,
+		related:      make(map[uuid.UUID]cachedRelatedIdeas),
 	}
 }
 
-func (s *ideaService) Create(ctx context.Context, userId string, req *request.CreateIdea) (uuid.UUID, uuid.UUID, error) {
+func (s *ideaService) Create(ctx context.Context, userId string, req *request.CreateIdea) (*response.IdeaCreated, error) {
 	user, err := s.u.FindByID(ctx, userId)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to find user: %w", err)
+		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 	if user == nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user not found")
 	}
 
 	// The following commented-out code is a placeholder for future logic to handle starter trial limits.
@@ -78,29 +134,62 @@ func (s *ideaService) Create(ctx context.Context, userId string, req *request.Cr
 	// 	return uuid.Nil, fmt.Errorf("you have reached your idea limit for the starter plan. please upgrade your plan to create more ideas")
 	// }
 
+	// ideas created in a workspace are billed to it
+	if req.WorkspaceID != nil {
+		if _, err := s.access.AuthorizeWorkspace(ctx, userId, *req.WorkspaceID, domain.WorkspaceRoleMember); err != nil {
+			return nil, err
+		}
+	}
+	billing, err := s.access.Billing(ctx, userId, req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	ideaStatus := domain.IdeaStatusActive
-	ideaLimit := domain.GetIdeaLimitForPlan(user.Plan)
-	activeStatus := domain.IdeaStatusActive
-	currentCount, err := s.repo.GetCountForUser(ctx, userId, nil, nil, &activeStatus)
+	ideaLimit := billing.Limits().IdeaLimit
+	currentCount, err := s.countActiveIdeas(ctx, billing)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to check idea count: %w", err)
+		return nil, fmt.Errorf("failed to check idea count: %w", err)
 	}
 	if int(currentCount) >= ideaLimit {
 		if time.Now().Before(user.CreatedAt.AddDate(0, 0, s.config.StarterPlanIdeaCreationDays)) {
 			ideaStatus = domain.IdeaStatusDraft // Allow creation of a draft idea if within the starter plan creation days
 		} else {
-			return uuid.Nil, uuid.Nil, fmt.Errorf("you have reached your idea limit for the %s plan. please upgrade your plan to create more", user.Plan)
+			return nil, fmt.Errorf("you have reached your idea limit for the %s plan. please upgrade your plan to create more", billing.Plan)
 		}
 	}
 
 	if err := validator.Validate(req); err != nil {
-		return uuid.Nil, uuid.Nil, err
+		return nil, err
 	}
 
 	if userId == "" {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("userId is required")
+		return nil, fmt.Errorf("userId is required")
+	}
+
+	idea, mvp, restored, err := s.insert(ctx, userId, req, ideaStatus, true)
+	if err != nil {
+		if idea != nil {
+			return &response.IdeaCreated{ID: idea.ID}, err
+		}
+		return nil, err
+	}
+	if restored {
+		return &response.IdeaCreated{ID: idea.ID, SimilarIdeas: []response.SimilarIdea{}}, nil
 	}
 
+	similarIdeas := s.findDuplicates(ctx, userId, idea)
+
+	s.markFreeTrialUsed(ctx, user, billing)
+
+	return &response.IdeaCreated{ID: idea.ID, MVPID: mvp.ID, SimilarIdeas: similarIdeas}, nil
+}
+
+// insert stores the idea of a validated request, with its initial MVP when
+// withMVP is set. A deleted idea of the user with the same title is restored
+// instead, unless ForceNew is set, and gets no new MVP. The idea is returned
+// with the error when only its MVP failed.
+func (s *ideaService) insert(ctx context.Context, userId string, req *request.CreateIdea, ideaStatus domain.IdeaStatus, withMVP bool) (idea *domain.Idea, mvp *domain.MVPSimulator, restored bool, err error) {
 	// unique slug for the idea, include last part of userId at the end of the slug
 	ideaSlug := slug.Make(req.Title)
 
@@ -113,24 +202,32 @@ func (s *ideaService) Create(ctx context.Context, userId string, req *request.Cr
 
 	ideaSlug = fmt.Sprintf("%s-%s", ideaSlug, userIdSuffix)
 
-	idea := &domain.Idea{
+	categoryId, err := s.resolveCategory(ctx, req.Category)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	idea = &domain.Idea{
 		UserID:         userId,
+		WorkspaceID:    req.WorkspaceID,
 		Title:          req.Title,
 		Description:    req.Description,
 		TargetAudience: req.TargetAudience,
 		Slug:           ideaSlug,
 		Status:         string(ideaStatus),
+		CategoryID:     categoryId,
+		Tags:           normalizeTags(req.Tags, maxIdeaTags),
 	}
 
 	existingDeletedIdea, err := s.repo.FindDeletedByTitleAndUserID(ctx, userId, req.Title)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to check for existing deleted idea: %w", err)
+		return nil, nil, false, fmt.Errorf("failed to check for existing deleted idea: %w", err)
 	}
 
 	if existingDeletedIdea != nil {
 		if req.ForceNew {
 			if err := s.repo.HardDelete(ctx, existingDeletedIdea.ID); err != nil {
-				return uuid.Nil, uuid.Nil, fmt.Errorf("failed to hard delete previous idea for new creation: %w", err)
+				return nil, nil, false, fmt.Errorf("failed to hard delete previous idea for new creation: %w", err)
 			}
 
 			log.Printf("Successfully hard-deleted old idea %s to create a new one with title '%s'", existingDeletedIdea.ID, req.Title)
@@ -141,81 +238,74 @@ func (s *ideaService) Create(ctx context.Context, userId string, req *request.Cr
 
 			// If a soft-deleted idea is found, restore it instead of creating a new one
 			if err := s.repo.Restore(ctx, idea); err != nil {
-				return uuid.Nil, uuid.Nil, fmt.Errorf("failed to restore idea: %w", err)
+				return nil, nil, false, fmt.Errorf("failed to restore idea: %w", err)
 			}
-			return existingDeletedIdea.ID, uuid.Nil, nil
+			go s.refreshEmbedding(existingDeletedIdea.ID)
+			return idea, nil, true, nil
 		}
 	}
 
 	ideaId, err := s.repo.Create(ctx, idea)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to create idea: %w", err)
+		return nil, nil, false, fmt.Errorf("failed to create idea: %w", err)
 	}
+	idea.ID = ideaId
+
+	if withMVP {
+		mvp = &domain.MVPSimulator{
+			Base:     domain.Base{ID: uuid.New()},
+			IdeaID:   ideaId,
+			Name:     "Initial Version",
+			IsActive: true,
+			HTMLURL:  req.HTMLURL,
+		}
 
-	mvp := &domain.MVPSimulator{
-		Base:     domain.Base{ID: uuid.New()},
-		IdeaID:   ideaId,
-		Name:     "Initial Version",
-		IsActive: true,
-		HTMLURL:  req.HTMLURL,
+		if _, err := s.mvpRepo.Create(ctx, mvp); err != nil {
+			log.Printf("Error creating MVP for idea %s: %v", idea.ID, err)
+			return idea, nil, false, fmt.Errorf("failed to create MVP for idea: %w", err)
+		}
 	}
 
-	if _, err := s.mvpRepo.Create(ctx, mvp); err != nil {
-		log.Printf("Error creating MVP for idea %s: %v", idea.ID, err)
-		return ideaId, uuid.Nil, fmt.Errorf("failed to create MVP for idea: %w", err)
+	go s.refreshShareCards(ideaId)
+	if idea.CategoryID == nil || len(idea.Tags) == 0 {
+		go s.suggestTaxonomy(ideaId)
 	}
 
-	// If the user is on the starter plan / not paying, set the UsedFreeTrial flag to true
-	if !user.IsPaying && !user.UsedFreeTrial {
+	return idea, mvp, false, nil
+}
+
+// markFreeTrialUsed flags the personal starter trial of a user who is not paying as used
+func (s *ideaService) markFreeTrialUsed(ctx context.Context, user *domain.User, billing *Billing) {
+	if billing.WorkspaceID == nil && !user.IsPaying && !user.UsedFreeTrial {
 		_user := &domain.User{
 			UsedFreeTrial: true,
 		}
 
-		if err := s.u.Update(ctx, userId, _user); err != nil {
+		if err := s.u.Update(ctx, user.ID, _user); err != nil {
 			log.Printf("WARNING: Failed to update user after creating idea: %v", err)
 		}
 	}
-
-	return ideaId, mvp.ID, nil
 }
 
 func (s *ideaService) Update(ctx context.Context, userId string, ideaId uuid.UUID, req request.UpdateIdea) error {
-	user, err := s.u.FindByID(ctx, userId)
+	existingIdea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
 	if err != nil {
-		return fmt.Errorf("failed to find user: %w", err)
-	}
-	if user == nil {
-		return fmt.Errorf("user not found")
+		return err
 	}
 
-	// Check if the idea exists
-	existingIdea, _, err := s.repo.GetByID(ctx, ideaId, nil, nil)
+	// collaborators work within the plan of the owner or the workspace
+	billing, err := s.access.BillingFor(ctx, existingIdea)
 	if err != nil {
 		return err
 	}
 
-	if existingIdea == nil {
-		return gorm.ErrRecordNotFound
-	}
-
-	if existingIdea.UserID != userId {
-		return fmt.Errorf("user not authorized to update this idea")
-	}
-
-	if req.Status != nil && *req.Status == string(domain.IdeaStatusActive) && existingIdea.Status != string(domain.IdeaStatusActive) {
-		activeStatus := domain.IdeaStatusActive
-		currentCount, err := s.repo.GetCountForUser(ctx, userId, nil, nil, &activeStatus)
-		if err != nil {
-			return fmt.Errorf("failed to check idea count: %w", err)
-		}
-
-		ideaLimit := domain.GetIdeaLimitForPlan(user.Plan)
-		if int(currentCount) >= ideaLimit {
-			return fmt.Errorf("you have reached your idea limit for the %s plan. please upgrade your plan or deactivate an idea to activate more", user.Plan)
+	if req.Status != nil {
+		if err := s.statuses.CanTransition(ctx, existingIdea, domain.IdeaStatus(*req.Status)); err != nil {
+			return err
 		}
 	}
 
-	if req.IsPrivate != nil && *req.IsPrivate && user.Plan == domain.StarterPlan {
+	if req.IsPrivate != nil && *req.IsPrivate && billing.Plan == domain.StarterPlan {
 		return fmt.Errorf("private ideas are not allowed on the starter plan. please upgrade your plan to create private ideas")
 	}
 
@@ -249,27 +339,260 @@ func (s *ideaService) Update(ctx context.Context, userId string, ideaId uuid.UUI
 		idea.IsPrivate = req.IsPrivate
 	}
 
+	changes := diffIdea(existingIdea, req)
+
+	// Validate the taxonomy before writing anything
+	categoryId, tags := existingIdea.CategoryID, []string(existingIdea.Tags)
+	if req.Category != nil {
+		if categoryId, err = s.resolveCategory(ctx, *req.Category); err != nil {
+			return err
+		}
+	}
+	if req.Tags != nil {
+		tags = normalizeTags(*req.Tags, maxIdeaTags)
+	}
+
 	// Update the idea
 	if err := s.repo.Update(ctx, idea); err != nil {
 		return err
 	}
 
+	// Updates skips nil and empty values, which clear the category and tags
+	if req.Category != nil || req.Tags != nil {
+		if err := s.repo.SetTaxonomy(ctx, ideaId, categoryId, tags); err != nil {
+			return err
+		}
+	}
+
+	// An image set by URL replaces the uploaded one, its files are not used
+	// anymore. Updates also skips an empty URL, a removed image is written here.
+	if req.ImageURL != nil && *req.ImageURL != existingIdea.ImageURL && (*req.ImageURL == "" || len(existingIdea.ImageVariants) > 0) {
+		if err := s.repo.SetImage(ctx, ideaId, *req.ImageURL, nil); err != nil {
+			return err
+		}
+		s.images.DeleteFiles(existingIdea.ImageVariants)
+	}
+
+	recordRevision(ctx, s.revisionRepo, userId, ideaId, changes)
+
+	// The share cards show the title and description, regenerate them when either changes
+	if (req.Title != nil && *req.Title != existingIdea.Title) || (req.Description != nil && *req.Description != existingIdea.Description) {
+		go s.refreshShareCards(ideaId)
+	}
+
+	if (req.Title != nil && *req.Title != existingIdea.Title) || (req.Description != nil && *req.Description != existingIdea.Description) ||
+		(req.TargetAudience != nil && *req.TargetAudience != existingIdea.TargetAudience) {
+		go s.refreshEmbedding(ideaId)
+	}
+
 	return nil
 }
 
-func (s *ideaService) Delete(ctx context.Context, userId string, ideaId uuid.UUID) error {
-	// Check if the idea exists
-	existingIdea, _, err := s.repo.GetByID(ctx, ideaId, nil, nil)
+// countActiveIdeas counts the active ideas sharing the idea limit of the billing
+func (s *ideaService) countActiveIdeas(ctx context.Context, billing *Billing) (int64, error) {
+	spec := billing.IdeaSpec()
+	spec.Status = domain.IdeaStatusActive
+
+	return s.repo.GetCount(ctx, spec)
+}
+
+func (s *ideaService) refreshShareCards(ideaId uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.shareCards.RefreshIdeaCards(ctx, ideaId); err != nil {
+		log.Printf("WARN: Failed to refresh share cards for idea %s: %v", ideaId, err)
+	}
+}
+
+func (s *ideaService) refreshEmbedding(ideaId uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.search.RefreshIdea(ctx, ideaId); err != nil {
+		log.Printf("WARN: Failed to refresh search embedding for idea %s: %v", ideaId, err)
+	}
+}
+
+// findDuplicates lists the public ideas that are very close to a new one,
+// with their similarity. The lookup runs in the background and is only waited
+// for duplicateCheckWait, a slower lookup warns the founder over the websocket
+// instead. Failures only cost the warning, they never fail the creation.
+func (s *ideaService) findDuplicates(ctx context.Context, userId string, idea *domain.Idea) []response.SimilarIdea {
+	found := make(chan []response.SimilarIdea)
+	go func() {
+		similarIdeas := s.lookupDuplicates(idea.ID)
+		select {
+		case found <- similarIdeas:
+		default:
+			s.warnAboutDuplicates(userId, idea, similarIdeas)
+		}
+	}()
+
+	select {
+	case similarIdeas := <-found:
+		return similarIdeas
+	case <-time.After(duplicateCheckWait):
+	case <-ctx.Done():
+	}
+	return []response.SimilarIdea{}
+}
+
+// lookupDuplicates embeds a new idea and scores the public ideas closest to
+// it. A failed embedding is left to the embedding backfill of the next start.
+func (s *ideaService) lookupDuplicates(ideaId uuid.UUID) []response.SimilarIdea {
+	similarIdeas := []response.SimilarIdea{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.search.RefreshIdea(ctx, ideaId); err != nil {
+		log.Printf("WARN: Failed to embed idea %s for duplicate detection: %v", ideaId, err)
+		return similarIdeas
+	}
+
+	isPrivate := false
+	matches, err := s.search.Similar(ctx, ideaId, repository.IdeaQuerySpec{
+		IncludePrivate: &isPrivate,
+		Status:         domain.IdeaStatusActive,
+	}, s.config.DuplicateSimilarity, maxDuplicateWarnings)
 	if err != nil {
-		return err
+		log.Printf("WARN: Failed to look for duplicates of idea %s: %v", ideaId, err)
+		return similarIdeas
+	}
+	if len(matches) == 0 {
+		return similarIdeas
+	}
+
+	ideas, err := s.ideasInOrder(ctx, matches)
+	if err != nil {
+		log.Printf("WARN: Failed to load duplicates of idea %s: %v", ideaId, err)
+		return similarIdeas
+	}
+
+	scores := make(map[uuid.UUID]float64, len(matches))
+	for _, m := range matches {
+		scores[m.IdeaID] = m.Score
+	}
+	for _, duplicate := range ideas {
+		similarIdeas = append(similarIdeas, response.SimilarIdea{
+			ID:         duplicate.ID,
+			Title:      duplicate.Title,
+			Similarity: scores[duplicate.ID],
+		})
+	}
+
+	return similarIdeas
+}
+
+// warnAboutDuplicates tells the founder about the duplicates found after the
+// creation response was sent.
+func (s *ideaService) warnAboutDuplicates(userId string, idea *domain.Idea, duplicates []response.SimilarIdea) {
+	if len(duplicates) == 0 {
+		return
+	}
+
+	titles := make([]string, len(duplicates))
+	for i, duplicate := range duplicates {
+		titles[i] = fmt.Sprintf("%q", duplicate.Title)
+	}
+	s.broadcaster.BroadcastActivity(userId, &response.ActivityItem{
+		ID:           idea.ID.String(),
+		Type:         "similar_ideas",
+		IdeaID:       idea.ID.String(),
+		IdeaTitle:    idea.Title,
+		Message:      fmt.Sprintf("Your idea looks very similar to %s, already on FounderSignal.", strings.Join(titles, ", ")),
+		ReferenceURL: fmt.Sprintf("/explore/%s", duplicates[0].ID),
+		Timestamp:    time.Now(),
+	})
+}
+
+// relatedIdeas are the public ideas closest to the given one by meaning. The
+// matches are cached for relatedIdeasTTL, only the ideas are loaded per view
+// and the ones made private or no longer active since are left out.
+func (s *ideaService) relatedIdeas(ctx context.Context, ideaId uuid.UUID) []*domain.Idea {
+	matches, err := s.relatedMatches(ctx, ideaId)
+	if err != nil {
+		log.Printf("WARN: Failed to find related ideas for idea %s: %v", ideaId, err)
+		return nil
+	}
+
+	ideas, err := s.ideasInOrder(ctx, matches)
+	if err != nil {
+		log.Printf("WARN: Failed to load related ideas for idea %s: %v", ideaId, err)
+		return nil
+	}
+
+	related := make([]*domain.Idea, 0, len(ideas))
+	for _, idea := range ideas {
+		if (idea.IsPrivate != nil && *idea.IsPrivate) || idea.Status != string(domain.IdeaStatusActive) {
+			continue
+		}
+		related = append(related, idea)
+	}
+
+	return related
+}
+
+func (s *ideaService) relatedMatches(ctx context.Context, ideaId uuid.UUID) ([]repository.ScoredIdea, error) {
+	s.relatedMu.Lock()
+	cached, ok := s.related[ideaId]
+	s.relatedMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.matches, nil
+	}
+
+	isPrivate := false
+	matches, err := s.search.Similar(ctx, ideaId, repository.IdeaQuerySpec{
+		IncludePrivate: &isPrivate,
+		Status:         domain.IdeaStatusActive,
+	}, s.config.RelatedMinSimilarity, maxRelatedIdeas)
+	if err != nil {
+		return nil, err
+	}
+
+	s.relatedMu.Lock()
+	if len(s.related) >= maxCachedRelated {
+		s.related = make(map[uuid.UUID]cachedRelatedIdeas)
+	}
+	s.related[ideaId] = cachedRelatedIdeas{matches: matches, expiresAt: time.Now().Add(relatedIdeasTTL)}
+	s.relatedMu.Unlock()
+
+	return matches, nil
+}
+
+// ideasInOrder loads the scored ideas with their counts, keeping the order of
+// the scores. Ideas that no longer exist are skipped, so the result can be
+// shorter than the input.
+func (s *ideaService) ideasInOrder(ctx context.Context, scored []repository.ScoredIdea) ([]*domain.Idea, error) {
+	ids := make([]uuid.UUID, len(scored))
+	for i, r := range scored {
+		ids[i] = r.IdeaID
+	}
+
+	found, err := s.repo.GetByIdsWithCounts(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	if existingIdea == nil {
-		return gorm.ErrRecordNotFound
+	byId := make(map[uuid.UUID]*domain.Idea, len(found))
+	for _, idea := range found {
+		byId[idea.ID] = idea
 	}
+	ideas := make([]*domain.Idea, 0, len(ids))
+	for _, id := range ids {
+		if idea, ok := byId[id]; ok {
+			ideas = append(ideas, idea)
+		}
+	}
+
+	return ideas, nil
+}
 
-	if existingIdea.UserID != userId {
-		return fmt.Errorf("user not authorized to delete this idea")
+func (s *ideaService) Delete(ctx context.Context, userId string, ideaId uuid.UUID) error {
+	// Only the owner can delete the idea
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleOwner); err != nil {
+		return err
 	}
 
 	// Delete the idea
@@ -280,20 +603,23 @@ func (s *ideaService) Delete(ctx context.Context, userId string, ideaId uuid.UUI
 	return nil
 }
 
-func (s *ideaService) GetByID(ctx context.Context, id uuid.UUID, userId string) (*response.PublicIdeaResponse, error) {
-	includeRelated := true
+func (s *ideaService) GetByID(ctx context.Context, id uuid.UUID, userId, shareToken string) (*response.PublicIdeaResponse, error) {
 	withUser := true
-	idea, relatedIdeas, err := s.repo.GetByID(ctx, id, &includeRelated, &withUser)
+	idea, err := s.repo.GetByID(ctx, id, &withUser)
 	if err != nil {
 		return nil, err
 	}
 
-	// Only the owner can view non-active ideas
-	if idea.Status != string(domain.IdeaStatusActive) && idea.UserID != userId {
-		return nil, gorm.ErrRecordNotFound
+	// Only the team and the holders of a share link can view private and non-active ideas
+	link, err := s.shares.CanView(ctx, idea, userId, shareToken)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		s.shares.RecordAccess(link)
 	}
 
-	publicIdea := dto.ToPublicIdea(idea, relatedIdeas, userId)
+	publicIdea := dto.ToPublicIdea(idea, s.relatedIdeas(ctx, idea.ID), userId)
 
 	return publicIdea, nil
 }
@@ -301,26 +627,133 @@ func (s *ideaService) GetByID(ctx context.Context, id uuid.UUID, userId string)
 func (s *ideaService) GetIdeas(ctx context.Context, queryParams domain.QueryParams) (*response.IdeaListResponse, error) {
 	// This method is used for the /explore endpoint and only returns active ideas
 	includePrivateIdeas := false
-	ideasRaw, totalCount, err := s.repo.GetIdeas(ctx, queryParams, repository.IdeaQuerySpec{
+	spec := repository.IdeaQuerySpec{
 		IncludePrivate: &includePrivateIdeas,
 		Status:         domain.IdeaStatusActive, // Only active ideas
 		WithCounts:     true,
-	})
+	}
+	if err := s.applyTaxonomyFilters(ctx, queryParams, &spec); err != nil {
+		return nil, err
+	}
+
+	ideasRaw, totalCount, nextCursor, err := s.findIdeas(ctx, queryParams, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := s.getFacets(ctx, spec)
 	if err != nil {
 		return nil, err
 	}
 
 	ideas := dto.ToIdeasListResponse(ideasRaw, totalCount, nil)
+	ideas.Facets = facets
+	ideas.NextCursor = nextCursor
 
 	return ideas, nil
 }
 
+// findIdeas runs the keyword search, or the hybrid one when requested, and
+// returns the cursor of the next page. Hybrid results are ordered by relevance
+// unless another sort is requested, and fall back to keyword search when the
+// query cannot be embedded.
+func (s *ideaService) findIdeas(ctx context.Context, queryParams domain.QueryParams, spec repository.IdeaQuerySpec) ([]*domain.Idea, int64, string, error) {
+	if queryParams.Search == "" || queryParams.SearchMode != domain.SearchModeHybrid {
+		return s.findIdeasByKeyword(ctx, queryParams, spec)
+	}
+
+	ranked, err := s.search.Rank(ctx, queryParams.Search, spec)
+	if err != nil {
+		log.Printf("WARN: Hybrid search failed, falling back to keyword search: %v", err)
+		return s.findIdeasByKeywordFallback(ctx, queryParams, spec)
+	}
+
+	// the matches are then listed like any other ideas, in the requested order
+	if queryParams.SortBy != "" && queryParams.SortBy != relevanceSort {
+		spec.IDs = make([]uuid.UUID, len(ranked))
+		for i, match := range ranked {
+			spec.IDs[i] = match.IdeaID
+		}
+		queryParams.Search = ""
+		return s.findIdeasByKeyword(ctx, queryParams, spec)
+	}
+
+	// the ranking is computed in memory, so its cursor is a plain offset
+	offset := relevanceOffset(queryParams)
+
+	totalCount := int64(len(ranked))
+	start := min(max(offset, 0), len(ranked))
+	end := len(ranked)
+	if queryParams.Limit > 0 {
+		end = min(start+queryParams.Limit, len(ranked))
+	}
+	ideas, err := s.ideasInOrder(ctx, ranked[start:end])
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	var nextCursor string
+	if end < len(ranked) {
+		nextCursor = domain.Cursor{Sort: relevanceSort, Offset: end}.Encode()
+	}
+
+	return ideas, totalCount, nextCursor, nil
+}
+
+// findIdeasByKeywordFallback lists the keyword matches when the hybrid ranking
+// is unavailable. It pages by offset and hands out relevance cursors, so a
+// client keeps its place whichever search answers the next page.
+func (s *ideaService) findIdeasByKeywordFallback(ctx context.Context, queryParams domain.QueryParams, spec repository.IdeaQuerySpec) ([]*domain.Idea, int64, string, error) {
+	offset := relevanceOffset(queryParams)
+	queryParams.Offset, queryParams.Cursor = offset, nil
+
+	ideas, totalCount, err := s.repo.GetIdeas(ctx, queryParams, spec)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	var nextCursor string
+	if end := offset + len(ideas); int64(end) < totalCount {
+		nextCursor = domain.Cursor{Sort: relevanceSort, Offset: end}.Encode()
+	}
+
+	return ideas, totalCount, nextCursor, nil
+}
+
+// relevanceOffset is where a page of the relevance ranking starts
+func relevanceOffset(queryParams domain.QueryParams) int {
+	if queryParams.Cursor != nil && queryParams.Cursor.Sort == relevanceSort {
+		return queryParams.Cursor.Offset
+	}
+	return queryParams.Offset
+}
+
+func (s *ideaService) findIdeasByKeyword(ctx context.Context, queryParams domain.QueryParams, spec repository.IdeaQuerySpec) ([]*domain.Idea, int64, string, error) {
+	ideas, totalCount, err := s.repo.GetIdeas(ctx, queryParams, spec)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	return ideas, totalCount, repository.NextIdeaCursor(queryParams, ideas), nil
+}
+
 func (s *ideaService) GetUserIdeas(ctx context.Context, userId string, getStats bool, queryParams domain.QueryParams) (*response.IdeaListResponse, error) {
-	ideasRaw, totalCount, err := s.repo.GetIdeas(ctx, queryParams, repository.IdeaQuerySpec{
-		WithCounts: true,
-		ByUserId:   userId,
-		Status:     domain.IdeaStatus(queryParams.FilterBy),
-	})
+	spec := repository.IdeaQuerySpec{
+		WithCounts:   true,
+		AccessibleBy: userId, // owned and shared ideas
+		Personal:     true,
+		Status:       domain.IdeaStatus(queryParams.FilterBy),
+	}
+	if queryParams.WorkspaceID != nil {
+		// the switcher checked the membership
+		spec.AccessibleBy, spec.Personal = "", false
+		spec.WorkspaceID = queryParams.WorkspaceID
+	}
+	if err := s.applyTaxonomyFilters(ctx, queryParams, &spec); err != nil {
+		return nil, err
+	}
+
+	ideasRaw, totalCount, nextCursor, err := s.findIdeas(ctx, queryParams, spec)
 	if err != nil {
 		return nil, err
 	}
@@ -328,43 +761,57 @@ func (s *ideaService) GetUserIdeas(ctx context.Context, userId string, getStats
 	var stats *response.UserDashboardStats
 
 	if getStats {
-		stats, err = s.getUserDashboardStats(ctx, userId)
+		stats, err = s.getUserDashboardStats(ctx, repository.DashboardScope(userId, queryParams.WorkspaceID))
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	ideas := dto.ToIdeasListResponse(ideasRaw, totalCount, stats)
+	ideas.NextCursor = nextCursor
 
 	return ideas, nil
 }
 
-func (s *ideaService) RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID, userID string, eventType string, ipAddress string, userAgent string, metadata map[string]interface{}) error {
+// RecordSignal stores a tracking event, a CTA click adds the visitor to the
+// waitlist and returns its position and referral link.
+func (s *ideaService) RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID, userID, shareToken string, eventType string, ipAddress string, userAgent string, metadata map[string]interface{}) (*response.WaitlistPosition, error) {
 
 	if ideaID == uuid.Nil || mvpId == uuid.Nil {
-		return fmt.Errorf("ideaID and mvpId are required to record a signal")
+		return nil, fmt.Errorf("ideaID and mvpId are required to record a signal")
 	}
 
 	var metadataJson datatypes.JSON
 	if metadata != nil {
 		_metaJSON, err := json.Marshal(metadata)
 		if err != nil {
-			return fmt.Errorf("failed to marshal metadata: %w", err)
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
 		metadataJson = datatypes.JSON(_metaJSON)
 	}
 
-	idea, _, err := s.repo.GetByID(ctx, ideaID, nil, nil)
+	idea, err := s.repo.GetByID(ctx, ideaID, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get idea by ID: %w", err)
+		return nil, fmt.Errorf("failed to get idea by ID: %w", err)
 	}
 	if idea == nil {
-		return fmt.Errorf("idea not found")
+		return nil, fmt.Errorf("idea not found")
+	}
+
+	// a private idea is tracked for the visitors of its share links
+	if idea.Status != string(domain.IdeaStatusActive) {
+		return nil, fmt.Errorf("cannot record signal for idea that is either non-active or private")
+	}
+	if _, err := s.shares.CanView(ctx, idea, userID, shareToken); err != nil {
+		return nil, fmt.Errorf("cannot record signal for idea that is either non-active or private")
 	}
 
-	isPrivate := idea.IsPrivate != nil && *idea.IsPrivate
-	if idea.Status != string(domain.IdeaStatusActive) || isPrivate {
-		return fmt.Errorf("cannot record signal for idea that is either non-active or private")
+	// the tracking script reports the lang of the served page, keep it for locale analytics
+	var locale string
+	if rawLocale, ok := metadata["locale"].(string); ok {
+		if tag, err := language.Parse(rawLocale); err == nil {
+			locale = tag.String()
+		}
 	}
 
 	signal := &domain.Signal{
@@ -372,6 +819,7 @@ func (s *ideaService) RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID,
 		MVPSimulatorID: mvpId,
 		UserID:         userID,
 		EventType:      eventType,
+		Locale:         locale,
 		IPAddress:      ipAddress,
 		UserAgent:      userAgent,
 		Metadata:       metadataJson,
@@ -379,7 +827,17 @@ func (s *ideaService) RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID,
 
 	err = s.signalRepo.Create(ctx, signal)
 	if err != nil {
-		return fmt.Errorf("failed to create signal: %w", err)
+		return nil, fmt.Errorf("failed to create signal: %w", err)
+	}
+
+	visitorId, _ := metadata["visitorId"].(string)
+	fingerprint := signupFingerprint(visitorId, ipAddress, userAgent)
+
+	if eventType == string(domain.EventTypeSurvey) {
+		if err := s.surveys.RecordResponse(ctx, ideaID, mvpId, userID, fingerprint, metadata); err != nil {
+			log.Printf("WARN: Failed to record survey response for idea %s: %v", ideaID, err)
+		}
+		return nil, nil
 	}
 
 	// If the event is a CTA click, create/update AudienceMember
@@ -409,17 +867,25 @@ func (s *ideaService) RecordSignal(ctx context.Context, ideaID, mvpId uuid.UUID,
 			userEmail = AnonymousUserPlaceholderEmail // Placeholder for anonymous users
 		}
 
-		_, err = s.audienceRepo.Upsert(ctx, ideaID, mvpId, finalUserID, userEmail)
+		// the referral code of the shared link is forwarded in the metadata
+		referralCode, _ := metadata["ref"].(string)
+
+		position, err := s.waitlist.Join(ctx, ideaID, mvpId, finalUserID, userEmail, referralCode, fingerprint)
 		if err != nil {
 			// Log this error but don't necessarily fail the whole signal recording
 			log.Printf("WARN: Failed to upsert audience member for idea %s, user %s after CTA click: %v", ideaID, finalUserID, err)
+			return nil, nil
 		}
+
+		return position, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string) (*response.UserDashboardStats, error) {
+// getUserDashboardStats sums up the ideas of the spec, the ones of the selected
+// workspace or the personal ideas of the user
+func (s *ideaService) getUserDashboardStats(ctx context.Context, spec repository.IdeaQuerySpec) (*response.UserDashboardStats, error) {
 	now := time.Now()
 	currentMonthStart, currentMonthEnd, prevMonthStart, prevMonthEnd, _ := getTrendDateRanges(now)
 
@@ -430,7 +896,8 @@ func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string)
 	var currentMonthViews, prevMonthViews int64
 	var totalViews, totalSignups int64
 
-	activeStatus := domain.IdeaStatusActive
+	activeSpec := spec
+	activeSpec.Status = domain.IdeaStatusActive
 	pageViewEventType := domain.EventTypePageView
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -438,35 +905,35 @@ func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string)
 	// get active ideas count
 	g.Go(func() error {
 		var err error
-		totalActiveIdeas, err = s.repo.GetCountForUser(gCtx, userId, nil, nil, &activeStatus)
+		totalActiveIdeas, err = s.repo.GetCountCreated(gCtx, activeSpec, nil, nil)
 		return err
 	})
 
 	// get total ideas count for this month
 	g.Go(func() error {
 		var err error
-		currentTotalIdeas, err = s.repo.GetCountForUser(gCtx, userId, &currentMonthStart, &currentMonthEnd, nil)
+		currentTotalIdeas, err = s.repo.GetCountCreated(gCtx, spec, &currentMonthStart, &currentMonthEnd)
 		return err
 	})
 
 	// get total ideas count for prev month
 	g.Go(func() error {
 		var err error
-		prevMonthTotalIdeas, err = s.repo.GetCountForUser(gCtx, userId, &prevMonthStart, &prevMonthEnd, nil)
+		prevMonthTotalIdeas, err = s.repo.GetCountCreated(gCtx, spec, &prevMonthStart, &prevMonthEnd)
 		return err
 	})
 
 	// get active ideas count for this month
 	g.Go(func() error {
 		var err error
-		currentActiveIdeas, err = s.repo.GetCountForUser(gCtx, userId, &currentMonthStart, &currentMonthEnd, &activeStatus)
+		currentActiveIdeas, err = s.repo.GetCountCreated(gCtx, activeSpec, &currentMonthStart, &currentMonthEnd)
 		return err
 	})
 
 	// get active ideas count for prev month
 	g.Go(func() error {
 		var err error
-		prevMonthActiveIdeas, err = s.repo.GetCountForUser(gCtx, userId, &prevMonthStart, &prevMonthEnd, &activeStatus)
+		prevMonthActiveIdeas, err = s.repo.GetCountCreated(gCtx, activeSpec, &prevMonthStart, &prevMonthEnd)
 
 		return err
 	})
@@ -474,20 +941,20 @@ func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string)
 	// get total signups count for this month
 	g.Go(func() error {
 		var err error
-		currentMonthSignups, err = s.audienceRepo.GetCountForIdeaOwner(gCtx, userId, &currentMonthStart, &currentMonthEnd)
+		currentMonthSignups, err = s.audienceRepo.GetCountForIdeas(gCtx, spec, &currentMonthStart, &currentMonthEnd)
 		return err
 	})
 
 	// get total signups count for prev month
 	g.Go(func() error {
 		var err error
-		prevMonthSignups, err = s.audienceRepo.GetCountForIdeaOwner(gCtx, userId, &prevMonthStart, &prevMonthEnd)
+		prevMonthSignups, err = s.audienceRepo.GetCountForIdeas(gCtx, spec, &prevMonthStart, &prevMonthEnd)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		currentMonthViews, err = s.signalRepo.GetCountForIdeaOwner(gCtx, userId, repository.SignalQuerySpecs{
+		currentMonthViews, err = s.signalRepo.GetCountForIdeas(gCtx, spec, repository.SignalQuerySpecs{
 			EventType: pageViewEventType,
 			Start:     &currentMonthStart,
 			End:       &currentMonthEnd,
@@ -497,7 +964,7 @@ func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string)
 
 	g.Go(func() error {
 		var err error
-		prevMonthViews, err = s.signalRepo.GetCountForIdeaOwner(gCtx, userId, repository.SignalQuerySpecs{
+		prevMonthViews, err = s.signalRepo.GetCountForIdeas(gCtx, spec, repository.SignalQuerySpecs{
 			EventType: pageViewEventType,
 			Start:     &prevMonthStart,
 			End:       &prevMonthEnd,
@@ -507,14 +974,14 @@ func (s *ideaService) getUserDashboardStats(ctx context.Context, userId string)
 
 	g.Go(func() error {
 		var err error
-		totalViews, err = s.signalRepo.GetCountForIdeaOwner(gCtx, userId, repository.SignalQuerySpecs{
+		totalViews, err = s.signalRepo.GetCountForIdeas(gCtx, spec, repository.SignalQuerySpecs{
 			EventType: domain.EventTypePageView})
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		totalSignups, err = s.audienceRepo.GetCountForIdeaOwner(gCtx, userId, nil, nil)
+		totalSignups, err = s.audienceRepo.GetCountForIdeas(gCtx, spec, nil, nil)
 		return err
 	})
 
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdeaAccessDenied      = errors.New("you do not have access to this idea")
+	ErrWorkspaceAccessDenied = errors.New("you do not have access to this workspace")
+)
+
+// Billing is who an idea is billed to, its workspace or the owner of a
+// personal idea. The plan limits are checked against it.
+type Billing struct {
+	Plan        domain.UserPlan
+	IsPaying    bool
+	WorkspaceID *uuid.UUID
+	UserID      string // the owner of the personal ideas, empty for a workspace
+}
+
+func (b *Billing) Limits() domain.PlanDetails {
+	return domain.GetPlanDetails(b.Plan)
+}
+
+// IdeaSpec is the listing spec of the ideas billed the same way
+func (b *Billing) IdeaSpec() repository.IdeaQuerySpec {
+	if b.WorkspaceID != nil {
+		return repository.IdeaQuerySpec{WorkspaceID: b.WorkspaceID}
+	}
+	return repository.IdeaQuerySpec{ByUserId: b.UserID, Personal: true}
+}
+
+// IdeaAuthorizer decides what a user can do on an idea. Services check access
+// through it instead of comparing the user to Idea.UserID, so that owners and
+// collaborators are treated the same way everywhere.
+type IdeaAuthorizer interface {
+	// Authorize loads the idea, without its relations, and fails with
+	// gorm.ErrRecordNotFound when it does not exist or ErrIdeaAccessDenied
+	// when the user does not have at least the required role on it.
+	Authorize(ctx context.Context, userId string, ideaId uuid.UUID, required domain.IdeaRole) (*domain.Idea, error)
+	// AuthorizeIdea is Authorize for an idea that is already loaded.
+	AuthorizeIdea(ctx context.Context, userId string, idea *domain.Idea, required domain.IdeaRole) error
+	// RoleFor returns the role of the user on the idea, empty when they have none.
+	// Members of the workspace of the idea get the idea role of their workspace role.
+	RoleFor(ctx context.Context, userId string, idea *domain.Idea) (domain.IdeaRole, error)
+	// AuthorizeWorkspace fails with ErrWorkspaceAccessDenied when the user is not
+	// a member of the workspace with at least the required role.
+	AuthorizeWorkspace(ctx context.Context, userId string, workspaceId uuid.UUID, required domain.WorkspaceRole) (*domain.WorkspaceMember, error)
+	// Billing returns who the ideas of the workspace are billed to, or the
+	// personal ideas of the user when workspaceId is nil.
+	Billing(ctx context.Context, userId string, workspaceId *uuid.UUID) (*Billing, error)
+	// BillingFor is Billing for an idea, collaborators work within its plan.
+	BillingFor(ctx context.Context, idea *domain.Idea) (*Billing, error)
+}
+
+type ideaAuthorizer struct {
+	ideaRepo      repository.IdeaRepository
+	memberRepo    repository.IdeaMemberRepository
+	workspaceRepo repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+}
+
+func NewIdeaAuthorizer(ideaRepo repository.IdeaRepository, memberRepo repository.IdeaMemberRepository, workspaceRepo repository.WorkspaceRepository,
+	userRepo repository.UserRepository) *ideaAuthorizer {
+	return &ideaAuthorizer{
+		ideaRepo:      ideaRepo,
+		memberRepo:    memberRepo,
+		workspaceRepo: workspaceRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (a *ideaAuthorizer) Authorize(ctx context.Context, userId string, ideaId uuid.UUID, required domain.IdeaRole) (*domain.Idea, error) {
+	idea, err := a.ideaRepo.Get(ctx, ideaId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get idea: %w", err)
+	}
+
+	if err := a.AuthorizeIdea(ctx, userId, idea, required); err != nil {
+		return nil, err
+	}
+
+	return idea, nil
+}
+
+func (a *ideaAuthorizer) AuthorizeIdea(ctx context.Context, userId string, idea *domain.Idea, required domain.IdeaRole) error {
+	role, err := a.RoleFor(ctx, userId, idea)
+	if err != nil {
+		return err
+	}
+
+	if !role.Allows(required) {
+		return ErrIdeaAccessDenied
+	}
+
+	return nil
+}
+
+func (a *ideaAuthorizer) RoleFor(ctx context.Context, userId string, idea *domain.Idea) (domain.IdeaRole, error) {
+	if userId == "" || idea == nil {
+		return "", nil
+	}
+
+	if idea.UserID == userId {
+		return domain.IdeaRoleOwner, nil
+	}
+
+	var role domain.IdeaRole
+	if idea.WorkspaceID != nil {
+		member, err := a.workspaceRepo.GetMember(ctx, *idea.WorkspaceID, userId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("failed to get workspace member: %w", err)
+		}
+		if member != nil {
+			role = member.Role.IdeaRole()
+		}
+	}
+	if role == domain.IdeaRoleOwner {
+		return role, nil
+	}
+
+	member, err := a.memberRepo.GetMember(ctx, idea.ID, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return role, nil
+		}
+		return "", fmt.Errorf("failed to get idea member: %w", err)
+	}
+
+	// the highest of the workspace and collaborator roles
+	if role.Allows(member.Role) {
+		return role, nil
+	}
+	return member.Role, nil
+}
+
+func (a *ideaAuthorizer) AuthorizeWorkspace(ctx context.Context, userId string, workspaceId uuid.UUID, required domain.WorkspaceRole) (*domain.WorkspaceMember, error) {
+	if userId == "" {
+		return nil, ErrWorkspaceAccessDenied
+	}
+
+	member, err := a.workspaceRepo.GetMember(ctx, workspaceId, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceAccessDenied
+		}
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+
+	if !member.Role.Allows(required) {
+		return nil, ErrWorkspaceAccessDenied
+	}
+
+	return member, nil
+}
+
+func (a *ideaAuthorizer) Billing(ctx context.Context, userId string, workspaceId *uuid.UUID) (*Billing, error) {
+	if workspaceId != nil {
+		workspace, err := a.workspaceRepo.GetByID(ctx, *workspaceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+
+		return &Billing{Plan: workspace.Plan, IsPaying: workspace.IsPaying, WorkspaceID: &workspace.ID}, nil
+	}
+
+	user, err := a.userRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return &Billing{Plan: user.Plan, IsPaying: user.IsPaying, UserID: user.ID}, nil
+}
+
+func (a *ideaAuthorizer) BillingFor(ctx context.Context, idea *domain.Idea) (*Billing, error) {
+	return a.Billing(ctx, idea.UserID, idea.WorkspaceID)
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	minComparedIdeas      = 2
+	maxComparedIdeas      = 5
+	defaultComparisonDays = 30
+	maxComparisonDays     = 90
+)
+
+var ErrComparisonSize = fmt.Errorf("between %d and %d ideas can be compared", minComparedIdeas, maxComparedIdeas)
+
+type IdeaComparisonService interface {
+	// Compare measures the ideas over the same window of days after their
+	// launch, the requested days at most and no more than the age of the
+	// youngest idea. An idea is launched the day it first became active.
+	Compare(ctx context.Context, userId string, ideaIds []uuid.UUID, days int) (*response.IdeaComparison, error)
+}
+
+type ideaComparisonService struct {
+	analytics    AnalyticsService
+	signalRepo   repository.SignalRepository
+	audienceRepo repository.AudienceRepository
+	redditRepo   repository.RedditValidationRepository
+	revisionRepo repository.IdeaRevisionRepository
+	access       IdeaAuthorizer
+}
+
+func NewIdeaComparisonService(analytics AnalyticsService, signalRepo repository.SignalRepository, audienceRepo repository.AudienceRepository,
+	redditRepo repository.RedditValidationRepository, revisionRepo repository.IdeaRevisionRepository, access IdeaAuthorizer) *ideaComparisonService {
+	return &ideaComparisonService{
+		analytics:    analytics,
+		signalRepo:   signalRepo,
+		audienceRepo: audienceRepo,
+		redditRepo:   redditRepo,
+		revisionRepo: revisionRepo,
+		access:       access,
+	}
+}
+
+func (s *ideaComparisonService) Compare(ctx context.Context, userId string, ideaIds []uuid.UUID, days int) (*response.IdeaComparison, error) {
+	ideaIds = uniqueIdeaIds(ideaIds)
+	if len(ideaIds) < minComparedIdeas || len(ideaIds) > maxComparedIdeas {
+		return nil, ErrComparisonSize
+	}
+	if days <= 0 {
+		days = defaultComparisonDays
+	}
+	days = min(days, maxComparisonDays)
+
+	ideas := make([]*domain.Idea, 0, len(ideaIds))
+	for _, ideaId := range ideaIds {
+		idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		ideas = append(ideas, idea)
+	}
+
+	launchedAt, err := s.launchTimes(ctx, ideas)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	launches := make([]time.Time, 0, len(ideas))
+	for _, idea := range ideas {
+		launch := launchDay(launchedAt[idea.ID])
+		days = min(days, int(now.Sub(launch)/(24*time.Hour))+1)
+		launches = append(launches, launch)
+	}
+
+	from, to := launches[0], launches[0]
+	for _, launch := range launches[1:] {
+		if launch.Before(from) {
+			from = launch
+		}
+		if launch.After(to) {
+			to = launch
+		}
+	}
+	to = windowEnd(to, days)
+
+	dailyViews, err := s.signalRepo.GetDailyViewsByIdeaIDs(ctx, ideaIds, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily views: %w", err)
+	}
+	dailySignups, err := s.audienceRepo.GetSignupsByIdeaIds(ctx, ideaIds, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily signups: %w", err)
+	}
+
+	comparison := &response.IdeaComparison{
+		Days:  days,
+		Ideas: make([]response.ComparedIdea, 0, len(ideas)),
+	}
+	for i, idea := range ideas {
+		launch := launches[i]
+
+		data, err := s.analytics.GetIdeaReportAnalytics(ctx, idea.ID, launch, windowEnd(launch, days))
+		if err != nil {
+			return nil, err
+		}
+
+		metrics := response.ComparisonMetrics{
+			Views:          float64(data.Views),
+			Signups:        float64(data.Signups),
+			ConversionRate: conversionRate(data.Signups, data.Views),
+			EngagementRate: data.EngagementRate,
+		}
+		if data.Feedbacks > 0 {
+			metrics.Sentiment = &data.Sentiment
+		}
+		if metrics.ValidationScore, err = s.validationScore(ctx, idea); err != nil {
+			return nil, err
+		}
+
+		series := make([]response.ComparisonPoint, 0, days)
+		for day := 0; day < days; day++ {
+			date := launch.AddDate(0, 0, day)
+			dayStr := date.Format(time.RFC3339) // same keys as the daily counts
+
+			views := dailyViews[idea.ID][dayStr]
+			signups := dailySignups[idea.ID][dayStr]
+			series = append(series, response.ComparisonPoint{
+				Day:            day,
+				Date:           date.Format(time.DateOnly),
+				Views:          views,
+				Signups:        signups,
+				ConversionRate: conversionRate(int64(signups), int64(views)),
+			})
+		}
+
+		comparison.Ideas = append(comparison.Ideas, response.ComparedIdea{
+			IdeaID:     idea.ID,
+			Title:      idea.Title,
+			Status:     idea.Status,
+			LaunchedAt: launchedAt[idea.ID],
+			Metrics:    metrics,
+			Series:     series,
+		})
+	}
+
+	normalizeComparison(comparison.Ideas)
+	return comparison, nil
+}
+
+// validationScore is the score of the completed Reddit validation of the idea, if any
+func (s *ideaComparisonService) validationScore(ctx context.Context, idea *domain.Idea) (*float64, error) {
+	var validation *domain.RedditValidation
+	var err error
+	if idea.RedditValidationID != uuid.Nil {
+		validation, err = s.redditRepo.GetByID(ctx, idea.RedditValidationID)
+	} else {
+		validation, err = s.redditRepo.GetByIdeaID(ctx, idea.ID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reddit validation: %w", err)
+	}
+
+	if validation.Status != domain.ValidationStatusCompleted {
+		return nil, nil
+	}
+	return &validation.ValidationScore, nil
+}
+
+// comparisonMetrics reads and writes each compared metric, a metric the idea
+// does not have is left out of its normalization and of its score.
+var comparisonMetrics = []struct {
+	value func(m *response.ComparisonMetrics) (float64, bool)
+	set   func(m *response.ComparisonMetrics, v float64)
+}{
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) { return m.Views, true },
+		set:   func(m *response.ComparisonMetrics, v float64) { m.Views = v },
+	},
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) { return m.Signups, true },
+		set:   func(m *response.ComparisonMetrics, v float64) { m.Signups = v },
+	},
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) { return m.ConversionRate, true },
+		set:   func(m *response.ComparisonMetrics, v float64) { m.ConversionRate = v },
+	},
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) { return m.EngagementRate, true },
+		set:   func(m *response.ComparisonMetrics, v float64) { m.EngagementRate = v },
+	},
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) {
+			if m.Sentiment == nil {
+				return 0, false
+			}
+			return *m.Sentiment, true
+		},
+		set: func(m *response.ComparisonMetrics, v float64) { m.Sentiment = &v },
+	},
+	{
+		value: func(m *response.ComparisonMetrics) (float64, bool) {
+			if m.ValidationScore == nil {
+				return 0, false
+			}
+			return *m.ValidationScore, true
+		},
+		set: func(m *response.ComparisonMetrics, v float64) { m.ValidationScore = &v },
+	},
+}
+
+// normalizeComparison scales every metric to 0-100, the best of the ideas
+// getting 100, and scores each idea with the average of its normalized metrics.
+func normalizeComparison(ideas []response.ComparedIdea) {
+	for _, metric := range comparisonMetrics {
+		best := 0.0
+		for i := range ideas {
+			if v, ok := metric.value(&ideas[i].Metrics); ok && v > best {
+				best = v
+			}
+		}
+
+		for i := range ideas {
+			v, ok := metric.value(&ideas[i].Metrics)
+			if !ok {
+				continue
+			}
+
+			normalized := 0.0
+			if best > 0 && v > 0 {
+				normalized = v / best * 100
+			}
+			metric.set(&ideas[i].Normalized, normalized)
+		}
+	}
+
+	for i := range ideas {
+		var total float64
+		var count int
+		for _, metric := range comparisonMetrics {
+			if v, ok := metric.value(&ideas[i].Normalized); ok {
+				total += v
+				count++
+			}
+		}
+		if count > 0 {
+			ideas[i].Score = total / float64(count)
+		}
+	}
+}
+
+// launchTimes returns when each idea first became active, read from the
+// status changes of its history. An idea created active, or never activated,
+// is launched when it was created.
+func (s *ideaComparisonService) launchTimes(ctx context.Context, ideas []*domain.Idea) (map[uuid.UUID]time.Time, error) {
+	ideaIds := make([]uuid.UUID, 0, len(ideas))
+	launchedAt := make(map[uuid.UUID]time.Time, len(ideas))
+	for _, idea := range ideas {
+		ideaIds = append(ideaIds, idea.ID)
+		launchedAt[idea.ID] = idea.CreatedAt
+	}
+
+	revisions, err := s.revisionRepo.GetStatusChanges(ctx, ideaIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status changes: %w", err)
+	}
+
+	// the first status change tells whether the idea was created active
+	decided := make(map[uuid.UUID]bool, len(ideas))
+	for _, revision := range revisions {
+		for _, change := range revision.Changes {
+			if change.Field != domain.IdeaFieldStatus || decided[revision.IdeaID] {
+				continue
+			}
+			if change.Before == string(domain.IdeaStatusActive) {
+				decided[revision.IdeaID] = true
+			} else if change.After == string(domain.IdeaStatusActive) {
+				launchedAt[revision.IdeaID] = revision.CreatedAt
+				decided[revision.IdeaID] = true
+			}
+		}
+	}
+
+	return launchedAt, nil
+}
+
+// launchDay is the start, in UTC, of the day of the launch
+func launchDay(launchedAt time.Time) time.Time {
+	year, month, day := launchedAt.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// windowEnd is the last instant of a window of days starting at start
+func windowEnd(start time.Time, days int) time.Time {
+	return start.AddDate(0, 0, days).Add(-time.Microsecond)
+}
+
+func conversionRate(signups, views int64) float64 {
+	if views == 0 {
+		return 0
+	}
+	return float64(signups) / float64(views) * 100
+}
+
+func uniqueIdeaIds(ideaIds []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ideaIds))
+	unique := make([]uuid.UUID, 0, len(ideaIds))
+	for _, id := range ideaIds {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// diffIdea lists the fields the update changes, fields that are not sent or
+// keep their value are left out.
+func diffIdea(existing *domain.Idea, req request.UpdateIdea) []domain.IdeaFieldChange {
+	var changes []domain.IdeaFieldChange
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, domain.IdeaFieldChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	if req.Title != nil {
+		add(domain.IdeaFieldTitle, existing.Title, *req.Title)
+	}
+	if req.Description != nil {
+		add(domain.IdeaFieldDescription, existing.Description, *req.Description)
+	}
+	if req.TargetAudience != nil {
+		add(domain.IdeaFieldTargetAudience, existing.TargetAudience, *req.TargetAudience)
+	}
+	if req.Status != nil {
+		add(domain.IdeaFieldStatus, existing.Status, *req.Status)
+	}
+	if req.Stage != nil {
+		add(domain.IdeaFieldStage, existing.Stage, *req.Stage)
+	}
+	if req.TargetSignups != nil {
+		add(domain.IdeaFieldTargetSignups, strconv.Itoa(existing.TargetSignups), strconv.Itoa(*req.TargetSignups))
+	}
+	if req.ImageURL != nil {
+		add(domain.IdeaFieldImageURL, existing.ImageURL, *req.ImageURL)
+	}
+	if req.IsPrivate != nil {
+		isPrivate := existing.IsPrivate != nil && *existing.IsPrivate
+		add(domain.IdeaFieldIsPrivate, strconv.FormatBool(isPrivate), strconv.FormatBool(*req.IsPrivate))
+	}
+	if req.Category != nil {
+		categorySlug := ""
+		if existing.Category != nil {
+			categorySlug = existing.Category.Slug
+		}
+		add(domain.IdeaFieldCategory, categorySlug, *req.Category)
+	}
+	if req.Tags != nil {
+		add(domain.IdeaFieldTags, strings.Join(existing.Tags, ", "), strings.Join(normalizeTags(*req.Tags, maxIdeaTags), ", "))
+	}
+
+	return changes
+}
+
+// recordRevision saves the changes as the next version of the idea, an
+// empty userId marks a change made by the system. The idea is already
+// updated so a failure is only logged.
+func recordRevision(ctx context.Context, revisionRepo repository.IdeaRevisionRepository, userId string, ideaId uuid.UUID, changes []domain.IdeaFieldChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	revision := &domain.IdeaRevision{
+		IdeaID:  ideaId,
+		UserID:  userId,
+		Changes: changes,
+	}
+	if err := revisionRepo.Create(ctx, revision); err != nil {
+		log.Printf("WARN: Failed to record revision of idea %s: %v", ideaId, err)
+	}
+}
+
+func (s *dashboardService) GetIdeaHistory(ctx context.Context, userId string, ideaId uuid.UUID, queryParams domain.QueryParams) (*response.IdeaHistory, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	revisions, total, err := s.revisionRepo.GetByIdea(ctx, ideaId, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idea history: %w", err)
+	}
+
+	history := &response.IdeaHistory{
+		Revisions:  make([]response.IdeaRevision, 0, len(revisions)),
+		Total:      total,
+		NextCursor: repository.NextRevisionCursor(queryParams, revisions),
+	}
+	for _, revision := range revisions {
+		history.Revisions = append(history.Revisions, response.IdeaRevision{
+			Version:   revision.Version,
+			UserID:    revision.UserID,
+			Changes:   revision.Changes,
+			CreatedAt: revision.CreatedAt,
+		})
+	}
+
+	return history, nil
+}
+
+// getChangeMarkers returns the edits of the ideas in the range keyed by idea,
+// dated like the data points of the analytics series.
+func (s *dashboardService) getChangeMarkers(ctx context.Context, ideaIds []uuid.UUID, from, to time.Time) (map[uuid.UUID][]response.ChangeMarker, error) {
+	revisions, err := s.revisionRepo.GetForIdeas(ctx, ideaIds, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	markers := make(map[uuid.UUID][]response.ChangeMarker)
+	for _, revision := range revisions {
+		fields := make([]string, 0, len(revision.Changes))
+		for _, change := range revision.Changes {
+			fields = append(fields, change.Field)
+		}
+
+		markers[revision.IdeaID] = append(markers[revision.IdeaID], response.ChangeMarker{
+			Date:    revision.CreatedAt.In(from.Location()).Format("Jan 02"),
+			Version: revision.Version,
+			Fields:  fields,
+		})
+	}
+
+	return markers, nil
+}
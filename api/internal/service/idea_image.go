@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/cloudflare"
+	"foundersignal/internal/pkg/imageproc"
+	"foundersignal/internal/repository"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const imageCleanupTimeout = 30 * time.Second
+
+type IdeaImageService interface {
+	// Upload processes the image, stores its variants and makes it the image
+	// of the idea. The files of the previous image are deleted.
+	Upload(ctx context.Context, userId string, ideaId uuid.UUID, data []byte) (*response.IdeaImage, error)
+	Remove(ctx context.Context, userId string, ideaId uuid.UUID) error
+
+	// DeleteFiles removes the stored files of an image that was replaced, in the background
+	DeleteFiles(variants []domain.IdeaImageVariant)
+}
+
+type ideaImageService struct {
+	ideaRepo     repository.IdeaRepository
+	revisionRepo repository.IdeaRevisionRepository
+	r2Client     cloudflare.R2Bucket
+	access       IdeaAuthorizer
+}
+
+func NewIdeaImageService(ideaRepo repository.IdeaRepository, revisionRepo repository.IdeaRevisionRepository, r2Client cloudflare.R2Bucket, access IdeaAuthorizer) *ideaImageService {
+	return &ideaImageService{
+		ideaRepo:     ideaRepo,
+		revisionRepo: revisionRepo,
+		r2Client:     r2Client,
+		access:       access,
+	}
+}
+
+func (s *ideaImageService) Upload(ctx context.Context, userId string, ideaId uuid.UUID, data []byte) (*response.IdeaImage, error) {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := imageproc.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// every upload gets its own folder so a replaced image is never served from a cache
+	uploadId := uuid.New()
+	variants := make([]domain.IdeaImageVariant, 0, len(processed))
+	var imageUrl string
+	for _, p := range processed {
+		key := fmt.Sprintf("%s/images/%s/%s.%s", ideaId, uploadId, p.Name, p.Ext())
+		url, err := s.r2Client.Upload(ctx, key, p.ContentType, p.Data)
+		if err != nil {
+			s.DeleteFiles(variants)
+			return nil, fmt.Errorf("failed to upload image: %w", err)
+		}
+
+		variants = append(variants, domain.IdeaImageVariant{
+			Name:   p.Name,
+			Format: p.Format,
+			Width:  p.Width,
+			Height: p.Height,
+			URL:    url,
+			Key:    key,
+		})
+		if p.Name == imageproc.Sizes[len(imageproc.Sizes)-1].Name && p.Format != "webp" {
+			imageUrl = url
+		}
+	}
+
+	if err := s.ideaRepo.SetImage(ctx, ideaId, imageUrl, variants); err != nil {
+		s.DeleteFiles(variants)
+		return nil, fmt.Errorf("failed to set idea image: %w", err)
+	}
+	s.recordImageChange(ctx, userId, idea, imageUrl)
+
+	s.DeleteFiles(idea.ImageVariants)
+
+	return &response.IdeaImage{
+		ImageURL: imageUrl,
+		Variants: dto.ToImageVariants(variants),
+	}, nil
+}
+
+func (s *ideaImageService) Remove(ctx context.Context, userId string, ideaId uuid.UUID) error {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ideaRepo.SetImage(ctx, ideaId, "", nil); err != nil {
+		return fmt.Errorf("failed to remove idea image: %w", err)
+	}
+	s.recordImageChange(ctx, userId, idea, "")
+
+	s.DeleteFiles(idea.ImageVariants)
+	return nil
+}
+
+// recordImageChange adds the new image to the history of the idea, like the
+// image URLs set by an update
+func (s *ideaImageService) recordImageChange(ctx context.Context, userId string, idea *domain.Idea, imageUrl string) {
+	if idea.ImageURL == imageUrl {
+		return
+	}
+
+	recordRevision(ctx, s.revisionRepo, userId, idea.ID, []domain.IdeaFieldChange{
+		{Field: domain.IdeaFieldImageURL, Before: idea.ImageURL, After: imageUrl},
+	})
+}
+
+func (s *ideaImageService) DeleteFiles(variants []domain.IdeaImageVariant) {
+	if len(variants) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), imageCleanupTimeout)
+		defer cancel()
+
+		for _, variant := range variants {
+			if err := s.r2Client.Delete(ctx, variant.Key); err != nil {
+				log.Printf("WARN: Failed to delete image file %s: %v", variant.Key, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/repository"
+	"foundersignal/internal/websocket"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrActivationInPast = errors.New("the activation has to be scheduled in the future")
+
+type IdeaLifecycleService interface {
+	GetRules(ctx context.Context, userId string, ideaId uuid.UUID) (*domain.IdeaLifecycleRules, error)
+	UpdateRules(ctx context.Context, userId string, ideaId uuid.UUID, req request.UpdateLifecycleRules) (*domain.IdeaLifecycleRules, error)
+
+	// RunRules applies the rules of every idea once
+	RunRules(ctx context.Context) error
+	Start(ctx context.Context)
+}
+
+type IdeaLifecycleConfig struct {
+	Interval time.Duration
+}
+
+type ideaLifecycleService struct {
+	repo         repository.IdeaLifecycleRepository
+	ideaRepo     repository.IdeaRepository
+	revisionRepo repository.IdeaRevisionRepository
+	activityRepo repository.ActivityRepository
+	broadcaster  websocket.ActivityBroadcaster
+	statuses     IdeaStatusMachine
+	access       IdeaAuthorizer
+	config       IdeaLifecycleConfig
+}
+
+func NewIdeaLifecycleService(repo repository.IdeaLifecycleRepository, ideaRepo repository.IdeaRepository, revisionRepo repository.IdeaRevisionRepository,
+	activityRepo repository.ActivityRepository, broadcaster websocket.ActivityBroadcaster, statuses IdeaStatusMachine, access IdeaAuthorizer,
+	config IdeaLifecycleConfig) *ideaLifecycleService {
+	return &ideaLifecycleService{
+		repo:         repo,
+		ideaRepo:     ideaRepo,
+		revisionRepo: revisionRepo,
+		activityRepo: activityRepo,
+		broadcaster:  broadcaster,
+		statuses:     statuses,
+		access:       access,
+		config:       config,
+	}
+}
+
+// GetRules returns the rules of the idea, all of them off when none were saved
+func (s *ideaLifecycleService) GetRules(ctx context.Context, userId string, ideaId uuid.UUID) (*domain.IdeaLifecycleRules, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.GetByIdea(ctx, ideaId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.IdeaLifecycleRules{IdeaID: ideaId}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lifecycle rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (s *ideaLifecycleService) UpdateRules(ctx context.Context, userId string, ideaId uuid.UUID, req request.UpdateLifecycleRules) (*domain.IdeaLifecycleRules, error) {
+	if req.ActivateAt != nil && !req.ActivateAt.After(time.Now()) {
+		return nil, ErrActivationInPast
+	}
+
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	rules := &domain.IdeaLifecycleRules{
+		IdeaID:             ideaId,
+		AutoComplete:       req.AutoComplete,
+		AutoPauseAfterDays: req.AutoPauseAfterDays,
+		AutoArchive:        req.AutoArchive,
+		ActivateAt:         req.ActivateAt,
+	}
+	if err := s.repo.Save(ctx, rules); err != nil {
+		return nil, fmt.Errorf("failed to save lifecycle rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// RunRules activates the scheduled ideas first so that the other rules see
+// their new status. A failing rule does not stop the others.
+func (s *ideaLifecycleService) RunRules(ctx context.Context) error {
+	now := time.Now()
+	var errs []error
+
+	if err := s.activateScheduled(ctx, now); err != nil {
+		errs = append(errs, fmt.Errorf("failed to activate scheduled ideas: %w", err))
+	}
+
+	ideas, err := s.repo.FindToComplete(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to find ideas to complete: %w", err))
+	}
+	for i := range ideas {
+		s.transition(ctx, &ideas[i], domain.IdeaStatusCompleted, domain.LifecycleReasonTargetReached)
+	}
+
+	ideas, err = s.repo.FindInactive(ctx, now)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to find inactive ideas: %w", err))
+	}
+	for i := range ideas {
+		s.transition(ctx, &ideas[i], domain.IdeaStatusPaused, domain.LifecycleReasonNoTraffic)
+	}
+
+	ideas, err = s.repo.FindToArchive(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to find ideas to archive: %w", err))
+	}
+	for i := range ideas {
+		s.transition(ctx, &ideas[i], domain.IdeaStatusArchived, domain.LifecycleReasonFinalReport)
+	}
+
+	return errors.Join(errs...)
+}
+
+// activateScheduled activates the ideas due for activation that are still
+// draft or paused, the founder is told when a guard of the status machine
+// refuses it. The schedule is cleared either way.
+func (s *ideaLifecycleService) activateScheduled(ctx context.Context, now time.Time) error {
+	ideas, err := s.repo.FindDueActivation(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range ideas {
+		idea := &ideas[i]
+		if err := s.repo.ClearActivation(ctx, idea.ID); err != nil {
+			log.Printf("WARN: Failed to clear the scheduled activation of idea %s: %v", idea.ID, err)
+			continue
+		}
+
+		if idea.Status != string(domain.IdeaStatusDraft) && idea.Status != string(domain.IdeaStatusPaused) {
+			continue
+		}
+
+		if err := s.transition(ctx, idea, domain.IdeaStatusActive, domain.LifecycleReasonScheduled); IsStatusTransitionError(err) {
+			s.notify(ctx, idea, fmt.Sprintf("Your idea '%s' could not be activated as scheduled: %v", idea.Title, err))
+		}
+	}
+
+	return nil
+}
+
+// transition moves the idea to the status when the status machine allows it
+// and it was not changed since it was loaded, records it in the history of the
+// idea as a change of the system and tells the founder.
+func (s *ideaLifecycleService) transition(ctx context.Context, idea *domain.Idea, status domain.IdeaStatus, reason string) error {
+	if err := s.statuses.CanTransition(ctx, idea, status); err != nil {
+		log.Printf("WARN: Lifecycle rule could not move idea %s to %s: %v", idea.ID, status, err)
+		return err
+	}
+
+	// the rules run on every instance, only the one whose update still finds
+	// the previous status records and announces the change
+	previous := idea.Status
+	moved, err := s.ideaRepo.SetStatus(ctx, idea.ID, domain.IdeaStatus(previous), status)
+	if err != nil {
+		log.Printf("WARN: Failed to change the status of idea %s to %s: %v", idea.ID, status, err)
+		return err
+	}
+	if !moved {
+		return nil
+	}
+	idea.Status = string(status)
+
+	recordRevision(ctx, s.revisionRepo, "", idea.ID, []domain.IdeaFieldChange{
+		{Field: domain.IdeaFieldStatus, Before: previous, After: string(status)},
+	})
+
+	s.notify(ctx, idea, fmt.Sprintf("Your idea '%s' %s and is now %s.", idea.Title, reason, status))
+	return nil
+}
+
+func (s *ideaLifecycleService) notify(ctx context.Context, idea *domain.Idea, message string) {
+	activity := &domain.Activity{
+		UserID:  idea.UserID,
+		IdeaID:  idea.ID,
+		Message: message,
+		Type:    domain.ActivityTypeIdeaStatusChanged,
+	}
+	if err := s.activityRepo.Create(ctx, activity); err != nil {
+		log.Printf("WARN: Failed to create the status activity of idea %s: %v", idea.ID, err)
+	}
+
+	s.broadcaster.FormatAndBroadcastStatusChange(idea.UserID, *activity, idea.Title)
+}
+
+// Start runs the rules right away and then on every interval until the context is cancelled.
+func (s *ideaLifecycleService) Start(ctx context.Context) {
+	if err := s.RunRules(ctx); err != nil {
+		log.Printf("WARN: %v", err)
+	}
+
+	if s.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunRules(ctx); err != nil {
+				log.Printf("WARN: %v", err)
+			}
+		}
+	}
+}
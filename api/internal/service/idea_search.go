@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/repository"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	embeddingBackfillBatch  = 100 // matches the batch size of the embeddings API
+	maxCachedQueryEmbedding = 256
+)
+
+type IdeaSearchService interface {
+	RefreshIdea(ctx context.Context, ideaId uuid.UUID) error
+	BackfillMissing(ctx context.Context) error
+	Rank(ctx context.Context, search string, spec repository.IdeaQuerySpec) ([]repository.ScoredIdea, error)
+	Similar(ctx context.Context, ideaId uuid.UUID, spec repository.IdeaQuerySpec, minSimilarity float64, limit int) ([]repository.ScoredIdea, error)
+}
+
+type IdeaSearchConfig struct {
+	EmbeddingModel string
+	VectorWeight   float64 // share of the vector similarity in the hybrid score, the rest goes to ts_rank
+	MinSimilarity  float64 // ideas without a keyword match need at least this similarity
+	CandidateLimit int     // max ideas taken from each of the keyword and vector searches
+}
+
+type ideaSearchService struct {
+	repo      repository.IdeaEmbeddingRepository
+	ideaRepo  repository.IdeaRepository
+	aiService AIService
+	config    IdeaSearchConfig
+
+	// search queries repeat a lot (typing, paging), keep their vectors around
+	queryMu    sync.Mutex
+	queryCache map[string][]float32
+}
+
+func NewIdeaSearchService(repo repository.IdeaEmbeddingRepository, ideaRepo repository.IdeaRepository, aiService AIService, config IdeaSearchConfig) *ideaSearchService {
+	return &ideaSearchService{
+		repo:       repo,
+		ideaRepo:   ideaRepo,
+		aiService:  aiService,
+		config:     config,
+		queryCache: make(map[string][]float32),
+	}
+}
+
+// embeddingText is the text the idea vector is computed from, the same
+// fields as the full text search vector.
+func embeddingText(idea *domain.Idea) string {
+	return strings.Join([]string{idea.Title, idea.Description, idea.TargetAudience}, "\n")
+}
+
+func (s *ideaSearchService) contentHash(text string) string {
+	sum := sha256.Sum256([]byte(s.config.EmbeddingModel + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshIdea re-embeds the idea when its searchable text changed since the last run.
+func (s *ideaSearchService) RefreshIdea(ctx context.Context, ideaId uuid.UUID) error {
+	ideas, err := s.ideaRepo.GetByIds(ctx, []uuid.UUID{ideaId})
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if len(ideas) == 0 {
+		return nil
+	}
+
+	text := embeddingText(ideas[0])
+	hash := s.contentHash(text)
+
+	existing, err := s.repo.GetByIdeaID(ctx, ideaId)
+	if err != nil {
+		return fmt.Errorf("failed to get idea embedding: %w", err)
+	}
+	if existing != nil && existing.ContentHash == hash {
+		return nil
+	}
+
+	embeddings, err := s.aiService.CreateEmbeddings(ctx, []string{text})
+	if err != nil {
+		return fmt.Errorf("failed to create embedding: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return fmt.Errorf("expected 1 embedding, got %d", len(embeddings))
+	}
+
+	return s.repo.Upsert(ctx, &domain.IdeaEmbedding{
+		IdeaID:      ideaId,
+		Model:       s.config.EmbeddingModel,
+		ContentHash: hash,
+	}, embeddings[0])
+}
+
+// BackfillMissing embeds the ideas created before semantic search existed
+// and the ones embedded with a previous model.
+func (s *ideaSearchService) BackfillMissing(ctx context.Context) error {
+	total := 0
+	for {
+		ids, err := s.repo.GetIdeaIDsWithoutEmbedding(ctx, s.config.EmbeddingModel, embeddingBackfillBatch)
+		if err != nil {
+			return fmt.Errorf("failed to find ideas without embedding: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		ideas, err := s.ideaRepo.GetByIds(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to get ideas: %w", err)
+		}
+
+		texts := make([]string, len(ideas))
+		for i, idea := range ideas {
+			texts[i] = embeddingText(idea)
+		}
+
+		embeddings, err := s.aiService.CreateEmbeddings(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("failed to create embeddings: %w", err)
+		}
+		if len(embeddings) != len(ideas) {
+			return fmt.Errorf("expected %d embeddings, got %d", len(ideas), len(embeddings))
+		}
+
+		for i, idea := range ideas {
+			if err := s.repo.Upsert(ctx, &domain.IdeaEmbedding{
+				IdeaID:      idea.ID,
+				Model:       s.config.EmbeddingModel,
+				ContentHash: s.contentHash(texts[i]),
+			}, embeddings[i]); err != nil {
+				return fmt.Errorf("failed to save embedding of idea %s: %w", idea.ID, err)
+			}
+		}
+
+		total += len(ideas)
+		// ideas that could not be loaded would be returned again, stop instead of looping
+		if len(ideas) < len(ids) {
+			break
+		}
+	}
+
+	if total > 0 {
+		fmt.Printf("Embedded %d ideas for semantic search\n", total)
+	}
+	return nil
+}
+
+func (s *ideaSearchService) embedQuery(ctx context.Context, search string) ([]float32, error) {
+	key := strings.ToLower(strings.Join(strings.Fields(search), " "))
+
+	s.queryMu.Lock()
+	cached, ok := s.queryCache[key]
+	s.queryMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	embeddings, err := s.aiService.CreateEmbeddings(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("expected 1 embedding, got %d", len(embeddings))
+	}
+
+	s.queryMu.Lock()
+	if len(s.queryCache) >= maxCachedQueryEmbedding {
+		s.queryCache = make(map[string][]float32)
+	}
+	s.queryCache[key] = embeddings[0]
+	s.queryMu.Unlock()
+
+	return embeddings[0], nil
+}
+
+// Rank combines the vector similarity of the ideas with their keyword rank,
+// the ts_rank scores are normalized by the best one so both are in [0, 1].
+func (s *ideaSearchService) Rank(ctx context.Context, search string, spec repository.IdeaQuerySpec) ([]repository.ScoredIdea, error) {
+	queryVector, err := s.embedQuery(ctx, search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed search query: %w", err)
+	}
+
+	nearest, err := s.repo.Nearest(ctx, queryVector, s.config.EmbeddingModel, spec, s.config.CandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar ideas: %w", err)
+	}
+
+	keywordRanks, err := s.ideaRepo.GetSearchRanks(ctx, search, spec, s.config.CandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank ideas: %w", err)
+	}
+
+	var maxRank float64
+	for _, r := range keywordRanks {
+		maxRank = max(maxRank, r.Score)
+	}
+
+	similarity := make(map[uuid.UUID]float64, len(nearest))
+	for _, n := range nearest {
+		similarity[n.IdeaID] = n.Score
+	}
+	keyword := make(map[uuid.UUID]float64, len(keywordRanks))
+	for _, r := range keywordRanks {
+		if maxRank > 0 {
+			keyword[r.IdeaID] = r.Score / maxRank
+		} else {
+			keyword[r.IdeaID] = 0
+		}
+	}
+
+	var ranked []repository.ScoredIdea
+	for id, sim := range similarity {
+		if _, matched := keyword[id]; !matched && sim < s.config.MinSimilarity {
+			continue
+		}
+		ranked = append(ranked, repository.ScoredIdea{IdeaID: id, Score: s.config.VectorWeight*sim + (1-s.config.VectorWeight)*keyword[id]})
+	}
+	for id, rank := range keyword {
+		if _, done := similarity[id]; done {
+			continue
+		}
+		// keyword match outside the vector candidates, e.g. an idea not embedded yet
+		ranked = append(ranked, repository.ScoredIdea{IdeaID: id, Score: (1 - s.config.VectorWeight) * rank})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].IdeaID.String() < ranked[j].IdeaID.String()
+	})
+
+	return ranked, nil
+}
+
+// Similar returns the ideas closest to the stored embedding of the given idea,
+// the idea itself excluded. Ideas that are not embedded yet have no matches.
+func (s *ideaSearchService) Similar(ctx context.Context, ideaId uuid.UUID, spec repository.IdeaQuerySpec, minSimilarity float64, limit int) ([]repository.ScoredIdea, error) {
+	embedding, err := s.repo.GetByIdeaID(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idea embedding: %w", err)
+	}
+	if embedding == nil || embedding.Model != s.config.EmbeddingModel {
+		return nil, nil
+	}
+
+	var values []float32
+	if err := json.Unmarshal(embedding.Vector, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode idea embedding: %w", err)
+	}
+
+	nearest, err := s.repo.Nearest(ctx, values, s.config.EmbeddingModel, spec, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar ideas: %w", err)
+	}
+
+	similar := make([]repository.ScoredIdea, 0, limit)
+	for _, n := range nearest {
+		if n.IdeaID == ideaId || n.Score < minSimilarity {
+			continue
+		}
+		similar = append(similar, n)
+		if len(similar) == limit {
+			break
+		}
+	}
+
+	return similar, nil
+}
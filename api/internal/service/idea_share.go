@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaShareService interface {
+	Create(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateShareLink) (*response.ShareLink, error)
+	GetLinks(ctx context.Context, userId string, ideaId uuid.UUID) ([]response.ShareLink, error)
+	Revoke(ctx context.Context, userId string, ideaId, linkId uuid.UUID) error
+
+	// CanView checks that the user, or the holder of the share token, can view
+	// the idea. Active public ideas are seen by everyone, the others by the team
+	// of the idea and with a share link of the idea. The link is returned when
+	// it is what granted the access, an idea that cannot be seen is not found.
+	CanView(ctx context.Context, idea *domain.Idea, userId, shareToken string) (*domain.IdeaShareLink, error)
+	// RecordAccess counts an opening of the idea with the link, in the background
+	RecordAccess(link *domain.IdeaShareLink)
+}
+
+type IdeaShareConfig struct {
+	AppUrl string
+	Secret string // signs the share tokens, changing it invalidates every link
+}
+
+type ideaShareService struct {
+	repo   repository.IdeaShareRepository
+	access IdeaAuthorizer
+	cfg    IdeaShareConfig
+}
+
+func NewIdeaShareService(repo repository.IdeaShareRepository, access IdeaAuthorizer, cfg IdeaShareConfig) *ideaShareService {
+	return &ideaShareService{
+		repo:   repo,
+		access: access,
+		cfg:    cfg,
+	}
+}
+
+func (s *ideaShareService) Create(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateShareLink) (*response.ShareLink, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	link := &domain.IdeaShareLink{
+		IdeaID:    ideaId,
+		Label:     strings.TrimSpace(req.Label),
+		CreatedBy: userId,
+		// the token only holds the expiry to the second
+		ExpiresAt: time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour).Truncate(time.Second),
+	}
+	if err := s.repo.Create(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	res := s.toShareLink(link)
+	return &res, nil
+}
+
+func (s *ideaShareService) GetLinks(ctx context.Context, userId string, ideaId uuid.UUID) ([]response.ShareLink, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.GetByIdea(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+
+	res := make([]response.ShareLink, 0, len(links))
+	for i := range links {
+		res = append(res, s.toShareLink(&links[i]))
+	}
+	return res, nil
+}
+
+func (s *ideaShareService) Revoke(ctx context.Context, userId string, ideaId, linkId uuid.UUID) error {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return err
+	}
+
+	return s.repo.Revoke(ctx, ideaId, linkId)
+}
+
+func (s *ideaShareService) CanView(ctx context.Context, idea *domain.Idea, userId, shareToken string) (*domain.IdeaShareLink, error) {
+	isPrivate := idea.IsPrivate != nil && *idea.IsPrivate
+	if idea.Status == string(domain.IdeaStatusActive) && !isPrivate {
+		return nil, nil
+	}
+
+	if userId != "" && s.access.AuthorizeIdea(ctx, userId, idea, domain.IdeaRoleViewer) == nil {
+		return nil, nil
+	}
+
+	if shareToken != "" {
+		link, err := s.verify(ctx, idea.ID, shareToken)
+		if err != nil {
+			return nil, err
+		}
+		if link != nil {
+			return link, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *ideaShareService) RecordAccess(link *domain.IdeaShareLink) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.repo.RecordAccess(ctx, link.ID); err != nil {
+			log.Printf("WARN: Failed to record the access of share link %s: %v", link.ID, err)
+		}
+	}()
+}
+
+// verify returns the link of a valid token of the idea, nil when the token is
+// forged, expired, revoked or of another idea. The signature is checked before
+// the link is looked up.
+func (s *ideaShareService) verify(ctx context.Context, ideaId uuid.UUID, token string) (*domain.IdeaShareLink, error) {
+	linkId, expiresAt, ok := s.parseToken(token)
+	if !ok || !time.Now().Before(expiresAt) {
+		return nil, nil
+	}
+
+	link, err := s.repo.GetByID(ctx, linkId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	if link.IdeaID != ideaId || !link.IsUsable(time.Now()) || link.ExpiresAt.Unix() != expiresAt.Unix() {
+		return nil, nil
+	}
+	return link, nil
+}
+
+// token signs the id and the expiry of the link, "<payload>.<signature>" in
+// unpadded base64url.
+func (s *ideaShareService) token(link *domain.IdeaShareLink) string {
+	payload := make([]byte, 24)
+	copy(payload, link.ID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(link.ExpiresAt.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+}
+
+func (s *ideaShareService) parseToken(token string) (uuid.UUID, time.Time, bool) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return uuid.Nil, time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 24 {
+		return uuid.Nil, time.Time{}, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(payload)) {
+		return uuid.Nil, time.Time{}, false
+	}
+
+	linkId, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return uuid.Nil, time.Time{}, false
+	}
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+
+	return linkId, expiresAt, true
+}
+
+func (s *ideaShareService) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (s *ideaShareService) toShareLink(link *domain.IdeaShareLink) response.ShareLink {
+	return response.ShareLink{
+		ID:             link.ID,
+		Label:          link.Label,
+		URL:            fmt.Sprintf("%s/explore/%s?share=%s", strings.TrimRight(s.cfg.AppUrl, "/"), link.IdeaID, s.token(link)),
+		Active:         link.IsUsable(time.Now()),
+		ExpiresAt:      link.ExpiresAt,
+		RevokedAt:      link.RevokedAt,
+		AccessCount:    link.AccessCount,
+		LastAccessedAt: link.LastAccessedAt,
+		CreatedAt:      link.CreatedAt,
+	}
+}
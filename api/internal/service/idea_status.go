@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// a reported idea cannot be activated while the report is reviewed
+const reportReviewPeriod = 7 * 24 * time.Hour
+
+var (
+	ErrIdeaLimitReached = errors.New("idea limit reached")
+	ErrNoActiveMVP      = errors.New("the idea needs an active landing page to be activated")
+	ErrIdeaUnderReview  = errors.New("the idea was reported recently and cannot be activated until the report is reviewed")
+)
+
+// IdeaLimitError is returned when activating an idea goes over the idea limit of its plan
+type IdeaLimitError struct {
+	Plan domain.UserPlan
+}
+
+func (e *IdeaLimitError) Error() string {
+	return fmt.Sprintf("you have reached your idea limit for the %s plan. please upgrade your plan or deactivate an idea to activate more", e.Plan)
+}
+
+func (e *IdeaLimitError) Unwrap() error {
+	return ErrIdeaLimitReached
+}
+
+// IdeaStatusMachine is the only way the status of an idea is changed, by the
+// founder or by the lifecycle rules.
+type IdeaStatusMachine interface {
+	// CanTransition checks the move against the transition graph and then the
+	// guards of the new status, it returns nil when the idea can move.
+	CanTransition(ctx context.Context, idea *domain.Idea, to domain.IdeaStatus) error
+}
+
+type ideaStatusMachine struct {
+	ideaRepo     repository.IdeaRepository
+	mvpRepo      repository.MVPRepository
+	activityRepo repository.ActivityRepository
+	access       IdeaAuthorizer
+}
+
+func NewIdeaStatusMachine(ideaRepo repository.IdeaRepository, mvpRepo repository.MVPRepository, activityRepo repository.ActivityRepository,
+	access IdeaAuthorizer) *ideaStatusMachine {
+	return &ideaStatusMachine{
+		ideaRepo:     ideaRepo,
+		mvpRepo:      mvpRepo,
+		activityRepo: activityRepo,
+		access:       access,
+	}
+}
+
+func (m *ideaStatusMachine) CanTransition(ctx context.Context, idea *domain.Idea, to domain.IdeaStatus) error {
+	from := domain.IdeaStatus(idea.Status)
+	if err := from.CanTransitionTo(to); err != nil {
+		return err
+	}
+
+	if from == to || to != domain.IdeaStatusActive {
+		return nil
+	}
+	return m.guardActivation(ctx, idea)
+}
+
+// guardActivation lets an idea go live when it has a landing page to show,
+// fits in the idea limit of its plan and is not under review.
+func (m *ideaStatusMachine) guardActivation(ctx context.Context, idea *domain.Idea) error {
+	if _, err := m.mvpRepo.GetByIdea(ctx, idea.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoActiveMVP
+		}
+		return fmt.Errorf("failed to get active MVP: %w", err)
+	}
+
+	reports, err := m.activityRepo.CountIdeaReports(ctx, idea.ID, time.Now().Add(-reportReviewPeriod))
+	if err != nil {
+		return fmt.Errorf("failed to check idea reports: %w", err)
+	}
+	if reports > 0 {
+		return ErrIdeaUnderReview
+	}
+
+	billing, err := m.access.BillingFor(ctx, idea)
+	if err != nil {
+		return err
+	}
+
+	spec := billing.IdeaSpec()
+	spec.Status = domain.IdeaStatusActive
+	activeCount, err := m.ideaRepo.GetCount(ctx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to check idea count: %w", err)
+	}
+	if int(activeCount) >= billing.Limits().IdeaLimit {
+		return &IdeaLimitError{Plan: billing.Plan}
+	}
+
+	return nil
+}
+
+// IsStatusTransitionError reports whether the status change was refused by
+// the transition graph or one of its guards.
+func IsStatusTransitionError(err error) bool {
+	return errors.Is(err, domain.ErrIllegalStatusTransition) || errors.Is(err, ErrIdeaLimitReached) ||
+		errors.Is(err, ErrNoActiveMVP) || errors.Is(err, ErrIdeaUnderReview)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/prompts"
+	"foundersignal/internal/repository"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxIdeaTags          = 8
+	maxSuggestedTags     = 5
+	maxTagLength         = 30
+	maxTagFacets         = 20
+	taxonomyAITimeout    = 30 * time.Second
+	uncategorizedCatSlug = "other"
+)
+
+// normalizeTags turns free-form tags into lowercase slugs, dropping empty and duplicate ones.
+func normalizeTags(tags []string, limit int) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = slug.Make(tag)
+		if tag == "" || len(tag) > maxTagLength || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+		if len(normalized) == limit {
+			break
+		}
+	}
+	return normalized
+}
+
+// resolveCategory returns the id of the category with the given slug, nil for an empty slug.
+func (s *ideaService) resolveCategory(ctx context.Context, categorySlug string) (*uuid.UUID, error) {
+	if categorySlug == "" {
+		return nil, nil
+	}
+
+	category, _, err := s.categories.Resolve(ctx, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+	return &category.ID, nil
+}
+
+// applyTaxonomyFilters copies the category, tag and stage filters of the query into the spec.
+func (s *ideaService) applyTaxonomyFilters(ctx context.Context, queryParams domain.QueryParams, spec *repository.IdeaQuerySpec) error {
+	if queryParams.Category != "" {
+		_, ids, err := s.categories.Resolve(ctx, queryParams.Category)
+		if err != nil {
+			return err
+		}
+		spec.CategoryIDs = ids
+	}
+
+	if len(queryParams.Tags) > 0 {
+		spec.Tags = normalizeTags(queryParams.Tags, maxIdeaTags)
+	}
+	spec.Stage = queryParams.Stage
+
+	return nil
+}
+
+// getFacets counts the ideas of the listing per category, tag and stage. Each
+// facet ignores its own filter so the other values stay visible, and the
+// search query is not applied.
+func (s *ideaService) getFacets(ctx context.Context, spec repository.IdeaQuerySpec) (*response.IdeaFacets, error) {
+	facets := &response.IdeaFacets{
+		Categories: []response.FacetCount{},
+		Tags:       []response.FacetCount{},
+		Stages:     []response.FacetCount{},
+	}
+
+	categorySpec := spec
+	categorySpec.CategoryIDs = nil
+	categoryCounts, err := s.repo.GetFacetCounts(ctx, repository.IdeaFacetCategory, categorySpec, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+	categories, err := s.categories.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	facets.Categories = rollUpCategoryCounts(categories, categoryCounts)
+
+	tagSpec := spec
+	tagSpec.Tags = nil
+	tagCounts, err := s.repo.GetFacetCounts(ctx, repository.IdeaFacetTag, tagSpec, maxTagFacets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	for _, c := range tagCounts {
+		facets.Tags = append(facets.Tags, response.FacetCount{Value: c.Value, Label: c.Value, Count: c.Count})
+	}
+
+	stageSpec := spec
+	stageSpec.Stage = ""
+	stageCounts, err := s.repo.GetFacetCounts(ctx, repository.IdeaFacetStage, stageSpec, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stages: %w", err)
+	}
+	titleCase := cases.Title(language.English)
+	for _, c := range stageCounts {
+		facets.Stages = append(facets.Stages, response.FacetCount{Value: c.Value, Label: titleCase.String(c.Value), Count: c.Count})
+	}
+
+	return facets, nil
+}
+
+// rollUpCategoryCounts adds the counts of subcategories to their parents, so
+// "SaaS (42)" includes the ideas filed under "Developer Tools".
+func rollUpCategoryCounts(categories []domain.Category, counts []repository.FacetCount) []response.FacetCount {
+	byId := make(map[uuid.UUID]domain.Category, len(categories))
+	for _, c := range categories {
+		byId[c.ID] = c
+	}
+
+	totals := make(map[uuid.UUID]int64)
+	for _, count := range counts {
+		id, err := uuid.Parse(count.Value)
+		if err != nil {
+			continue
+		}
+		// walk up the tree, the seen guard protects against a cycle edited into the database
+		seen := make(map[uuid.UUID]bool)
+		category, ok := byId[id]
+		for ok && !seen[category.ID] {
+			seen[category.ID] = true
+			totals[category.ID] += count.Count
+			if category.ParentID == nil {
+				break
+			}
+			category, ok = byId[*category.ParentID]
+		}
+	}
+
+	facets := []response.FacetCount{}
+	for id, total := range totals {
+		facets = append(facets, response.FacetCount{Value: byId[id].Slug, Label: byId[id].Name, Count: total})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Label < facets[j].Label
+	})
+
+	return facets
+}
+
+// suggestTaxonomy lets the AI fill in the category and tags the founder left
+// empty at creation. Parts that are set by the time the idea is loaded are kept.
+func (s *ideaService) suggestTaxonomy(ideaId uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), taxonomyAITimeout)
+	defer cancel()
+
+	ideas, err := s.repo.GetByIds(ctx, []uuid.UUID{ideaId})
+	if err != nil || len(ideas) == 0 {
+		log.Printf("WARN: Failed to load idea %s for taxonomy suggestions: %v", ideaId, err)
+		return
+	}
+	idea := ideas[0]
+
+	categories, err := s.categories.GetAll(ctx)
+	if err != nil {
+		log.Printf("WARN: Failed to load categories for idea %s: %v", ideaId, err)
+		return
+	}
+
+	var categoryLines []string
+	bySlug := make(map[string]domain.Category, len(categories))
+	for _, c := range categories {
+		bySlug[c.Slug] = c
+		categoryLines = append(categoryLines, fmt.Sprintf("%s: %s", c.Slug, c.Name))
+	}
+
+	aiResponse, err := s.aiService.Generate(ctx, prompts.BuildTaxonomyPrompt(idea.Title, idea.Description, idea.TargetAudience, strings.Join(categoryLines, "\n")))
+	if err != nil {
+		log.Printf("WARN: Failed to suggest taxonomy for idea %s: %v", ideaId, err)
+		return
+	}
+
+	cleanedResponse := strings.TrimSpace(aiResponse)
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
+	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
+
+	var suggestion struct {
+		Category string   `json:"category"`
+		Tags     []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleanedResponse)), &suggestion); err != nil {
+		log.Printf("WARN: Failed to parse taxonomy suggestion for idea %s: %v", ideaId, err)
+		return
+	}
+
+	categoryId := idea.CategoryID
+	if categoryId == nil {
+		category, ok := bySlug[suggestion.Category]
+		if !ok {
+			category, ok = bySlug[uncategorizedCatSlug]
+		}
+		if ok {
+			categoryId = &category.ID
+		}
+	}
+
+	tags := []string(idea.Tags)
+	if len(tags) == 0 {
+		tags = normalizeTags(suggestion.Tags, maxSuggestedTags)
+	}
+
+	if err := s.repo.SetTaxonomy(ctx, ideaId, categoryId, tags); err != nil {
+		log.Printf("WARN: Failed to save taxonomy of idea %s: %v", ideaId, err)
+	}
+}
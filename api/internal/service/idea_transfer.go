@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"foundersignal/pkg/validator"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	MaxIdeaImportBytes = 2 << 20 // 2MB
+	maxImportedIdeas   = 100
+)
+
+var ErrInvalidImportFile = errors.New("the import file is invalid")
+
+// ideaImportColumns maps the normalized header of a CSV column to the field it
+// fills, "Target Audience" and "target_audience" both being targetaudience.
+var ideaImportColumns = map[string]func(row *request.CreateIdea, value string){
+	"title":          func(row *request.CreateIdea, value string) { row.Title = value },
+	"description":    func(row *request.CreateIdea, value string) { row.Description = value },
+	"targetaudience": func(row *request.CreateIdea, value string) { row.TargetAudience = value },
+	"ctabuttontext":  func(row *request.CreateIdea, value string) { row.CTAButton = value },
+	"htmlurl":        func(row *request.CreateIdea, value string) { row.HTMLURL = value },
+	"category":       func(row *request.CreateIdea, value string) { row.Category = value },
+	"tags": func(row *request.CreateIdea, value string) {
+		row.Tags = strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' })
+	},
+}
+
+var requiredImportColumns = []string{"title", "description", "targetAudience"}
+
+func (s *ideaService) Import(ctx context.Context, userId string, workspaceId *uuid.UUID, req request.ImportIdeas, filename string, data []byte) (*response.IdeaImport, error) {
+	rows, err := parseIdeaRows(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: it holds no idea", ErrInvalidImportFile)
+	}
+	if len(rows) > maxImportedIdeas {
+		return nil, fmt.Errorf("%w: at most %d ideas can be imported at once", ErrInvalidImportFile, maxImportedIdeas)
+	}
+
+	user, err := s.u.FindByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	// ideas imported in a workspace are billed to it
+	if workspaceId != nil {
+		if _, err := s.access.AuthorizeWorkspace(ctx, userId, *workspaceId, domain.WorkspaceRoleMember); err != nil {
+			return nil, err
+		}
+	}
+	billing, err := s.access.Billing(ctx, userId, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	activeCount, err := s.countActiveIdeas(ctx, billing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check idea count: %w", err)
+	}
+	ideaLimit := billing.Limits().IdeaLimit
+	// same as Create, drafts can still be added in the first days of the starter plan
+	canDraft := time.Now().Before(user.CreatedAt.AddDate(0, 0, s.config.StarterPlanIdeaCreationDays))
+
+	// titles are unique per user, a taken one is reported instead of failing on the index
+	owned, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{}, repository.IdeaQuerySpec{ByUserId: userId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the ideas of the user: %w", err)
+	}
+	titles := make(map[string]bool, len(owned)+len(rows))
+	for _, idea := range owned {
+		titles[idea.Title] = true
+	}
+
+	result := &response.IdeaImport{
+		Total:  len(rows),
+		Ideas:  []response.ImportedIdea{},
+		Errors: []response.ImportRowError{},
+	}
+	rowError := func(number int, row *request.CreateIdea, errs map[string]string) {
+		result.Errors = append(result.Errors, response.ImportRowError{Row: number, Title: row.Title, Errors: errs})
+	}
+
+	for i := range rows {
+		row, number := &rows[i], i+1
+		row.WorkspaceID = workspaceId
+
+		if errs := validator.ValidateRequest(row); errs != nil {
+			rowError(number, row, errs)
+			continue
+		}
+		if titles[row.Title] {
+			rowError(number, row, map[string]string{"title": "an idea with this title already exists"})
+			continue
+		}
+
+		status := domain.IdeaStatusActive
+		if int(activeCount) >= ideaLimit {
+			if !canDraft {
+				rowError(number, row, map[string]string{
+					"general": fmt.Sprintf("you have reached your idea limit for the %s plan. please upgrade your plan to create more", billing.Plan),
+				})
+				continue
+			}
+			status = domain.IdeaStatusDraft
+		}
+		if !req.WithMVPs {
+			// without a landing page the idea cannot go live, see guardActivation
+			status = domain.IdeaStatusDraft
+		}
+
+		idea, mvp, restored, err := s.insert(ctx, userId, row, status, req.WithMVPs)
+		if idea == nil {
+			field := "general"
+			if errors.Is(err, ErrUnknownCategory) {
+				field = "category"
+			}
+			rowError(number, row, map[string]string{field: err.Error()})
+			continue
+		}
+		if err != nil {
+			// the idea is there, only its MVP is missing
+			rowError(number, row, map[string]string{"mvp": err.Error()})
+		}
+		if status == domain.IdeaStatusActive && mvp == nil && !s.hasActiveMVP(ctx, idea.ID) {
+			// the MVP failed or the restored idea has none, keep it as a draft
+			if _, err := s.repo.SetStatus(ctx, idea.ID, domain.IdeaStatusActive, domain.IdeaStatusDraft); err != nil {
+				log.Printf("WARN: Failed to keep imported idea %s without MVP as a draft: %v", idea.ID, err)
+			} else {
+				status = domain.IdeaStatusDraft
+			}
+		}
+
+		titles[row.Title] = true
+		if status == domain.IdeaStatusActive {
+			activeCount++
+		}
+		if !restored {
+			go s.refreshEmbedding(idea.ID)
+		}
+
+		imported := response.ImportedIdea{
+			Row:      number,
+			ID:       idea.ID,
+			Title:    idea.Title,
+			Status:   string(status),
+			Restored: restored,
+		}
+		if mvp != nil {
+			imported.MVPID = &mvp.ID
+		}
+		result.Ideas = append(result.Ideas, imported)
+	}
+
+	result.Created = len(result.Ideas)
+	result.Failed = result.Total - result.Created
+	if result.Created > 0 {
+		s.markFreeTrialUsed(ctx, user, billing)
+	}
+
+	return result, nil
+}
+
+// hasActiveMVP reports whether the idea has a landing page to go live with, a
+// failed lookup counts as one so that the import does not change the idea
+func (s *ideaService) hasActiveMVP(ctx context.Context, ideaId uuid.UUID) bool {
+	_, err := s.mvpRepo.GetByIdea(ctx, ideaId)
+	return !errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+func (s *ideaService) Export(ctx context.Context, userId string, workspaceId *uuid.UUID) (*response.IdeaExport, error) {
+	spec := repository.IdeaQuerySpec{ByUserId: userId, WithCounts: true}
+	if workspaceId != nil {
+		// the switcher checked the membership
+		spec = repository.IdeaQuerySpec{WorkspaceID: workspaceId, WithCounts: true}
+	}
+
+	ideas, _, err := s.repo.GetIdeas(ctx, domain.QueryParams{SortBy: "oldest"}, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ideas: %w", err)
+	}
+
+	export := &response.IdeaExport{
+		ExportedAt: time.Now(),
+		Ideas:      make([]response.ExportedIdea, 0, len(ideas)),
+	}
+	for _, idea := range ideas {
+		mvps, err := s.mvpRepo.GetAllByIdea(ctx, idea.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the MVPs of idea %s: %w", idea.ID, err)
+		}
+		reports, err := s.reportRepo.GetForIdea(ctx, idea.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the reports of idea %s: %w", idea.ID, err)
+		}
+
+		export.Ideas = append(export.Ideas, toExportedIdea(idea, mvps, reports))
+	}
+
+	return export, nil
+}
+
+// IdeaExportCSV writes an export as CSV, one line per idea. The first columns
+// are the ones read by Import so that the file can be imported back.
+func IdeaExportCSV(export *response.IdeaExport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"title", "description", "targetAudience", "category", "tags",
+		"id", "slug", "status", "stage", "isPrivate", "targetSignups", "imageUrl", "views", "signups",
+		"mvps", "activeMvp", "activeMvpUrl", "reports", "lastReportDate", "createdAt", "updatedAt"}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, idea := range export.Ideas {
+		var activeName, activeUrl string
+		for _, mvp := range idea.MVPs {
+			if mvp.IsActive {
+				activeName, activeUrl = mvp.Name, mvp.HTMLURL
+				break
+			}
+		}
+
+		var lastReportDate string
+		for _, report := range idea.Reports {
+			if date := report.Date.Format(time.DateOnly); date > lastReportDate {
+				lastReportDate = date
+			}
+		}
+
+		record := []string{idea.Title, idea.Description, idea.TargetAudience, idea.Category, strings.Join(idea.Tags, ";"),
+			idea.ID.String(), idea.Slug, idea.Status, idea.Stage, strconv.FormatBool(idea.IsPrivate), strconv.Itoa(idea.TargetSignups),
+			idea.ImageURL, strconv.Itoa(idea.Views), strconv.Itoa(idea.Signups),
+			strconv.Itoa(len(idea.MVPs)), activeName, activeUrl, strconv.Itoa(len(idea.Reports)), lastReportDate,
+			idea.CreatedAt.Format(time.RFC3339), idea.UpdatedAt.Format(time.RFC3339)}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func toExportedIdea(idea *domain.Idea, mvps []domain.MVPSimulator, reports []domain.Report) response.ExportedIdea {
+	exported := response.ExportedIdea{
+		ID:             idea.ID,
+		Title:          idea.Title,
+		Description:    idea.Description,
+		TargetAudience: idea.TargetAudience,
+		Tags:           idea.Tags,
+		Slug:           idea.Slug,
+		Status:         idea.Status,
+		Stage:          idea.Stage,
+		IsPrivate:      idea.IsPrivate != nil && *idea.IsPrivate,
+		TargetSignups:  idea.TargetSignups,
+		ImageURL:       idea.ImageURL,
+		Views:          idea.Views,
+		Signups:        idea.Signups,
+		CreatedAt:      idea.CreatedAt,
+		UpdatedAt:      idea.UpdatedAt,
+		MVPs:           make([]response.ExportedMVP, 0, len(mvps)),
+		Reports:        make([]response.ExportedReport, 0, len(reports)),
+	}
+	if exported.Tags == nil {
+		exported.Tags = []string{}
+	}
+	if idea.Category != nil {
+		exported.Category = idea.Category.Slug
+	}
+
+	for _, mvp := range mvps {
+		exported.MVPs = append(exported.MVPs, response.ExportedMVP{
+			ID:            mvp.ID,
+			Name:          mvp.Name,
+			IsActive:      mvp.IsActive,
+			HTMLURL:       mvp.HTMLURL,
+			Locale:        mvp.Locale,
+			AIGenerations: mvp.AIGenerations,
+			ShareImageURL: mvp.ShareImageURL,
+			CreatedAt:     mvp.CreatedAt,
+			UpdatedAt:     mvp.UpdatedAt,
+		})
+	}
+
+	for _, report := range reports {
+		exported.Reports = append(exported.Reports, response.ExportedReport{
+			ID:             report.ID,
+			Type:           string(report.Type),
+			Date:           report.Date,
+			Views:          report.Views,
+			Signups:        report.Signups,
+			EngagementRate: report.EngagementRate,
+			Sentiment:      report.Sentiment,
+			Validated:      report.Validated,
+		})
+	}
+
+	return exported
+}
+
+// parseIdeaRows reads the ideas of an import file, by its extension. A JSON
+// file holds an array of ideas or an export, whose ideas are read; a CSV file
+// has a header row naming its columns.
+func parseIdeaRows(filename string, data []byte) ([]request.CreateIdea, error) {
+	if len(data) > MaxIdeaImportBytes {
+		return nil, fmt.Errorf("%w: it is larger than %dMB", ErrInvalidImportFile, MaxIdeaImportBytes>>20)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")) // byte order mark of spreadsheet exports
+
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".json":
+		return parseIdeaJSON(data)
+	case ".csv":
+		return parseIdeaCSV(data)
+	default:
+		return nil, fmt.Errorf("%w: only .json and .csv files can be imported", ErrInvalidImportFile)
+	}
+}
+
+func parseIdeaJSON(data []byte) ([]request.CreateIdea, error) {
+	data = bytes.TrimSpace(data)
+
+	var rows []request.CreateIdea
+	if bytes.HasPrefix(data, []byte("{")) {
+		var export struct {
+			Ideas []request.CreateIdea `json:"ideas"`
+		}
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		rows = export.Ideas
+	} else if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	return rows, nil
+}
+
+func parseIdeaCSV(data []byte) ([]request.CreateIdea, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1 // short rows leave their last fields empty
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	setters := make([]func(row *request.CreateIdea, value string), len(header))
+	found := make(map[string]bool, len(header))
+	for i, name := range header {
+		column := normalizeImportColumn(name)
+		setters[i] = ideaImportColumns[column] // nil for the columns that are not imported
+		found[column] = true
+	}
+	for _, column := range requiredImportColumns {
+		if !found[normalizeImportColumn(column)] {
+			return nil, fmt.Errorf("%w: the %s column is missing", ErrInvalidImportFile, column)
+		}
+	}
+
+	var rows []request.CreateIdea
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+
+		var row request.CreateIdea
+		blank := true
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				blank = false
+			}
+			if i < len(setters) && setters[i] != nil {
+				setters[i](&row, value)
+			}
+		}
+		if !blank { // spreadsheets often end with empty lines
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func normalizeImportColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
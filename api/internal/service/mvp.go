@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"foundersignal/internal/domain"
 	"foundersignal/internal/dto/request"
@@ -12,10 +14,12 @@ import (
 	"foundersignal/internal/websocket"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/gosimple/slug"
 	"gorm.io/gorm"
 )
 
@@ -23,21 +27,42 @@ type MVPService interface {
 	Create(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateMVP) (uuid.UUID, error)
 	GenerateAndSave(ctx context.Context, userId string, req request.GenerateLandingPage) error
 	GetAllByIdea(ctx context.Context, userId string, ideaId uuid.UUID) ([]domain.MVPSimulator, error)
-	GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string) (*domain.MVPSimulator, error)
+	GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, shareToken, lang, acceptLanguage string) (*domain.MVPSimulator, error)
 	Update(ctx context.Context, ideaId uuid.UUID, userId string, mvpId uuid.UUID, req request.UpdateMVP) error
 	GetByID(ctx context.Context, userId string, ideaId, id uuid.UUID) (*domain.MVPSimulator, error)
 	Delete(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) error
 	SetActive(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) error
 	GenerateLandingPage(ctx context.Context, mvpId, ideaId uuid.UUID, userId, prompt string) (string, error)
+	Audit(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.AuditLandingPage) (*response.LandingPageAudit, error)
+	UpdatePricingTest(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.UpdatePricingTest) (*validation.PricingBlock, error)
+	GetPricingAnalytics(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) (*response.PricingAnalytics, error)
+	CreateLocaleVariant(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.CreateLocaleVariant) (*domain.MVPLocale, error)
+	GetLocaleVariants(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) ([]domain.MVPLocale, error)
+	DeleteLocaleVariant(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, locale string) error
+	GetLocaleAnalytics(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) ([]response.LocaleAnalytics, error)
+	Import(ctx context.Context, userId string, ideaId uuid.UUID, req request.ImportMVP, filename string, data []byte) (*response.ImportedMVP, error)
+	UpdateSurvey(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.UpdateSurvey) (*validation.Survey, error)
 }
 
+var (
+	ErrPricingTiersRequired   = errors.New("at least one pricing tier is required to enable the pricing test")
+	ErrSurveyQuestionRequired = errors.New("at least one question is required to enable the survey")
+	ErrSurveyOptionsRequired  = errors.New("choice questions need at least two options")
+	ErrExternalLandingPage    = errors.New("the landing page is hosted outside FounderSignal and cannot be rebuilt, generate or import it here first")
+)
+
 type mvpService struct {
-	repo        repository.MVPRepository
-	ideaRepo    repository.IdeaRepository
-	userRepo    repository.UserRepository
-	aiService   AIService
-	r2Client    cloudflare.R2Bucket
-	broadcaster websocket.ActivityBroadcaster
+	repo         repository.MVPRepository
+	ideaRepo     repository.IdeaRepository
+	signalRepo   repository.SignalRepository
+	audienceRepo repository.AudienceRepository
+	localeRepo   repository.MVPLocaleRepository
+	aiService    AIService
+	shareCards   ShareCardService
+	r2Client     cloudflare.R2Bucket
+	broadcaster  websocket.ActivityBroadcaster
+	shares       IdeaShareService
+	access       IdeaAuthorizer
 
 	cfg MVPConfig
 }
@@ -46,40 +71,34 @@ type MVPConfig struct {
 	HTMLValidator validation.HTMLValidatorConfig
 }
 
-func NewMVPService(repo repository.MVPRepository, ideaRepo repository.IdeaRepository, userRepo repository.UserRepository, aiService AIService, r2Client cloudflare.R2Bucket, broadcaster websocket.ActivityBroadcaster, cfg MVPConfig) *mvpService {
+func NewMVPService(repo repository.MVPRepository, ideaRepo repository.IdeaRepository, signalRepo repository.SignalRepository,
+	audienceRepo repository.AudienceRepository, localeRepo repository.MVPLocaleRepository, aiService AIService, shareCards ShareCardService, r2Client cloudflare.R2Bucket, broadcaster websocket.ActivityBroadcaster, shares IdeaShareService, access IdeaAuthorizer, cfg MVPConfig) *mvpService {
 	return &mvpService{
-		repo:        repo,
-		ideaRepo:    ideaRepo,
-		userRepo:    userRepo,
-		aiService:   aiService,
-		r2Client:    r2Client,
-		broadcaster: broadcaster,
+		repo:         repo,
+		ideaRepo:     ideaRepo,
+		signalRepo:   signalRepo,
+		audienceRepo: audienceRepo,
+		localeRepo:   localeRepo,
+		aiService:    aiService,
+		shareCards:   shareCards,
+		r2Client:     r2Client,
+		broadcaster:  broadcaster,
+		shares:       shares,
+		access:       access,
 
 		cfg: cfg,
 	}
 }
 
 func (s *mvpService) Create(ctx context.Context, userId string, ideaId uuid.UUID, req request.CreateMVP) (uuid.UUID, error) {
-	if _, err := s.checkOwner(ctx, userId, ideaId); err != nil {
-		return uuid.Nil, err
-	}
-
-	user, err := s.userRepo.FindByID(ctx, userId)
-	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to get user: %w", err)
-	}
-	if user == nil {
-		return uuid.Nil, gorm.ErrRecordNotFound
-	}
-
-	mvpLimit := domain.GetMVPLimitForPlan(user.Plan)
-	currentMVPCount, err := s.repo.GetCountByIdea(ctx, ideaId)
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to get current MVP count: %w", err)
+		return uuid.Nil, err
 	}
 
-	if currentMVPCount >= int64(mvpLimit) {
-		return uuid.Nil, fmt.Errorf("you have reached the MVP limit of %d for the %s plan", mvpLimit, user.Plan)
+	// collaborators work within the plan of the owner
+	if err := s.checkMVPLimit(ctx, idea); err != nil {
+		return uuid.Nil, err
 	}
 
 	mvp := &domain.MVPSimulator{
@@ -104,8 +123,27 @@ func (s *mvpService) Create(ctx context.Context, userId string, ideaId uuid.UUID
 	return id, nil
 }
 
+func (s *mvpService) checkMVPLimit(ctx context.Context, idea *domain.Idea) error {
+	billing, err := s.access.BillingFor(ctx, idea)
+	if err != nil {
+		return err
+	}
+
+	mvpLimit := billing.Limits().MVPLimit
+	currentMVPCount, err := s.repo.GetCountByIdea(ctx, idea.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get current MVP count: %w", err)
+	}
+
+	if currentMVPCount >= int64(mvpLimit) {
+		return fmt.Errorf("you have reached the MVP limit of %d for the %s plan", mvpLimit, billing.Plan)
+	}
+
+	return nil
+}
+
 func (s *mvpService) GenerateAndSave(ctx context.Context, userId string, req request.GenerateLandingPage) error {
-	idea, err := s.checkOwner(ctx, userId, req.IdeaID)
+	idea, err := s.access.Authorize(ctx, userId, req.IdeaID, domain.IdeaRoleEditor)
 	if err != nil {
 		return err
 	}
@@ -123,26 +161,30 @@ func (s *mvpService) GenerateAndSave(ctx context.Context, userId string, req req
 	return nil
 }
 
-// GetAllByIdea retrieves all MVPs for a specific idea, ensuring the user is the owner.
+// GetAllByIdea retrieves all MVPs for a specific idea, ensuring the user can view the idea.
 func (s *mvpService) GetAllByIdea(ctx context.Context, userId string, ideaId uuid.UUID) ([]domain.MVPSimulator, error) {
-	if _, err := s.checkOwner(ctx, userId, ideaId); err != nil {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
 		return nil, err
 	}
 
 	return s.repo.GetAllByIdea(ctx, ideaId)
 }
 
-// GetByIdea retrieves the MVP for a specific idea, ensuring the user is the owner or the MVP is active.
-func (s *mvpService) GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string) (*domain.MVPSimulator, error) {
-	idea, _, err := s.ideaRepo.GetByID(ctx, ideaId, nil, nil)
+// GetByIdea retrieves the MVP for a specific idea, ensuring the user can view the idea or the MVP is active.
+// The locale variant matching lang or the Accept-Language header is served when one exists.
+func (s *mvpService) GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *string, shareToken, lang, acceptLanguage string) (*domain.MVPSimulator, error) {
+	idea, err := s.ideaRepo.GetByID(ctx, ideaId, nil)
 	if err != nil || idea == nil {
 		return nil, gorm.ErrRecordNotFound
 	}
 
-	// if idea.Status is not active and the user is not the owner, return
-	isOwner := userId != nil && *userId == idea.UserID
-	if idea.Status != string(domain.IdeaStatusActive) && !isOwner {
-		return nil, gorm.ErrRecordNotFound
+	// private and non-active ideas are shown to their team and with a share link
+	var viewerId string
+	if userId != nil {
+		viewerId = *userId
+	}
+	if _, err := s.shares.CanView(ctx, idea, viewerId, shareToken); err != nil {
+		return nil, err
 	}
 
 	mvp, err := s.repo.GetByIdea(ctx, ideaId)
@@ -154,13 +196,14 @@ func (s *mvpService) GetByIdea(ctx context.Context, ideaId uuid.UUID, userId *st
 		return nil, nil
 	}
 
+	s.localizeMVP(ctx, mvp, lang, acceptLanguage)
 	mvp.Idea = *idea
 
 	return mvp, nil
 }
 
 func (s *mvpService) Update(ctx context.Context, ideaId uuid.UUID, userId string, mvpId uuid.UUID, req request.UpdateMVP) error {
-	if _, err := s.checkOwner(ctx, userId, ideaId); err != nil {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
 		return err
 	}
 
@@ -180,7 +223,7 @@ func (s *mvpService) Update(ctx context.Context, ideaId uuid.UUID, userId string
 }
 
 func (s *mvpService) SetActive(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) error {
-	if _, err := s.checkOwner(ctx, userId, ideaId); err != nil {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
 		return err
 	}
 
@@ -192,9 +235,9 @@ func (s *mvpService) SetActive(ctx context.Context, userId string, ideaId, mvpId
 	return s.repo.SetActive(ctx, ideaId, mvpId)
 }
 
-// GetByID retrieves an MVP by its ID, ensuring the user is the owner of the idea.
+// GetByID retrieves an MVP by its ID, ensuring the user can view the idea.
 func (s *mvpService) GetByID(ctx context.Context, userId string, ideaId, id uuid.UUID) (*domain.MVPSimulator, error) {
-	idea, err := s.checkOwner(ctx, userId, ideaId)
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer)
 	if err != nil {
 		return nil, err
 	}
@@ -211,9 +254,9 @@ func (s *mvpService) GetByID(ctx context.Context, userId string, ideaId, id uuid
 	return mvp, nil
 }
 
-// Delete removes an MVP by its ID, ensuring the user is the owner of the idea.
+// Delete removes an MVP by its ID, ensuring the user can edit the idea.
 func (s *mvpService) Delete(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) error {
-	idea, err := s.checkOwner(ctx, userId, ideaId)
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
 	if err != nil {
 		return err
 	}
@@ -228,11 +271,6 @@ func (s *mvpService) Delete(ctx context.Context, userId string, ideaId, mvpId uu
 
 // GenerateLandingPage generates a landing page for an MVP using AI, ensuring the user has not exceeded their AI generation limit.
 func (s *mvpService) GenerateLandingPage(ctx context.Context, mvpId, ideaId uuid.UUID, userId, prompt string) (string, error) {
-	user, err := s.userRepo.FindByID(ctx, userId)
-	if err != nil {
-		return "", fmt.Errorf("failed to find user: %w", err)
-	}
-
 	mvp, err := s.repo.GetByID(ctx, mvpId)
 	if err != nil {
 		fmt.Printf("WARNING: failed to get MVP by ID %s: %v", mvpId, err)
@@ -245,9 +283,19 @@ func (s *mvpService) GenerateLandingPage(ctx context.Context, mvpId, ideaId uuid
 		}
 	}
 
-	aiGenLimit := domain.GetAIGenLimitForPlan(user.Plan)
+	// the generations are billed to the idea, its workspace or its owner
+	idea, err := s.ideaRepo.GetByID(ctx, mvp.IdeaID, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get idea: %w", err)
+	}
+	billing, err := s.access.BillingFor(ctx, idea)
+	if err != nil {
+		return "", err
+	}
+
+	aiGenLimit := billing.Limits().AIGenLimit
 	if mvp.AIGenerations >= aiGenLimit {
-		return "", fmt.Errorf("you have reached the AI generation limit of %d for the %s plan", aiGenLimit, user.Plan)
+		return "", fmt.Errorf("you have reached the AI generation limit of %d for the %s plan", aiGenLimit, billing.Plan)
 	}
 
 	htmlContent, err := s.aiService.Generate(ctx, prompt)
@@ -263,6 +311,35 @@ func (s *mvpService) GenerateLandingPage(ctx context.Context, mvpId, ideaId uuid
 	return htmlContent, nil
 }
 
+// Audit sanitizes and audits the given landing page content. The content is a
+// draft that may never be published, the reports stored on the MVP stay the
+// ones of the live page.
+func (s *mvpService) Audit(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.AuditLandingPage) (*response.LandingPageAudit, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	fullHTML, sanitization, err := validation.GetValidatedHTMLWithReport(req.HTMLContent, req.MetaTitle, req.MetaDescription, ideaId.String(), mvpId.String(), s.validatorConfigFor(mvp))
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := validation.AuditHTML(fullHTML, s.cfg.HTMLValidator.CTAButtonID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.LandingPageAudit{
+		Quality:      report,
+		Sanitization: sanitization,
+	}, nil
+}
+
 func (s *mvpService) generateAndSaveMVPWorkflow(idea *domain.Idea, mvp *domain.MVPSimulator, req request.GenerateLandingPage, userId string) {
 	aiGenerationTimeout := 2 * time.Minute
 
@@ -284,7 +361,22 @@ func (s *mvpService) generateAndSaveMVPWorkflow(idea *domain.Idea, mvp *domain.M
 		return
 	}
 
-	validatedHtml, err := validation.GetValidatedHTML(generatedHTML, *req.MetaTitle, *req.MetaDescription, req.IdeaID.String(), req.MVPId.String(), s.cfg.HTMLValidator)
+	if pricing := pricingBlockFor(mvp); pricing != nil {
+		if withPricing, err := validation.InjectPricingBlock(generatedHTML, *pricing); err != nil {
+			fmt.Printf("WARNING: failed to insert pricing block for MVP %s: %v\n", req.MVPId, err)
+		} else {
+			generatedHTML = withPricing
+		}
+	}
+
+	// The card URL is stable per MVP, so it can be referenced before the page is uploaded
+	if shareUrl, err := s.shareCards.GenerateMVPCard(ctx, idea, mvp); err != nil {
+		fmt.Printf("WARNING: failed to generate share card for MVP %s: %v\n", req.MVPId, err)
+	} else {
+		mvp.ShareImageURL = shareUrl
+	}
+
+	validatedHtml, sanitization, err := validation.GetValidatedHTMLWithReport(generatedHTML, *req.MetaTitle, *req.MetaDescription, req.IdeaID.String(), req.MVPId.String(), s.validatorConfigFor(mvp))
 	if err != nil {
 		fmt.Printf("ERROR: failed to validate HTML for MVP %s: %v\n", req.MVPId, err)
 		activityItem.Message = "Generated HTML is invalid. Please try again."
@@ -294,42 +386,25 @@ func (s *mvpService) generateAndSaveMVPWorkflow(idea *domain.Idea, mvp *domain.M
 	}
 
 	// upload the validated HTML to R2
-	contentType := "text/html"
-	key := fmt.Sprintf("%s/mvp/%s", req.IdeaID.String(), req.MVPId.String())
-	signedUrl, htmlUrl, err := s.r2Client.GetSignedURL(ctx, key, contentType)
+	htmlUrl, err := s.uploadHTML(ctx, mvpHTMLKey(req.IdeaID, req.MVPId, ""), validatedHtml)
 	if err != nil {
-		fmt.Printf("ERROR: failed to get signed URL for MVP %s: %v\n", req.MVPId, err)
+		fmt.Printf("ERROR: failed to upload HTML to R2 for MVP %s: %v\n", req.MVPId, err)
 		activityItem.Message = "Failed to save the generated HTML. Please try again later."
 		s.broadcaster.BroadcastActivity(userId, activityItem)
 		// send notification to the user using websocket / email
 		return
 	}
 
-	// upload the HTML to R2
-	reqPut, err := http.NewRequestWithContext(ctx, "PUT", signedUrl, strings.NewReader(validatedHtml))
-	if err != nil {
-		fmt.Printf("ERROR: failed to create PUT request for MVP %s: %v\n", req.MVPId, err)
-		activityItem.Message = "Failed to save the generated HTML. Please try again later."
-		s.broadcaster.BroadcastActivity(userId, activityItem)
-		return
+	if sanitizationJSON, err := json.Marshal(sanitization); err == nil {
+		mvp.Sanitization = sanitizationJSON
 	}
-	reqPut.Header.Set("Content-Type", contentType)
 
-	resp, err := http.DefaultClient.Do(reqPut)
+	// Audit the generated page, a failing audit should not fail the generation
+	audit, err := validation.AuditHTML(validatedHtml, s.cfg.HTMLValidator.CTAButtonID)
 	if err != nil {
-		fmt.Printf("ERROR: failed to upload HTML to R2 for MVP %s: %v\n", req.MVPId, err)
-		activityItem.Message = "Failed to save the generated HTML. Please try again later."
-		s.broadcaster.BroadcastActivity(userId, activityItem)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		fmt.Printf("ERROR: failed to upload HTML to R2 for MVP %s: status %s\n%s\n", req.MVPId, resp.Status, string(bodyBytes))
-		activityItem.Message = "Failed to save the generated HTML. Please try again later."
-		s.broadcaster.BroadcastActivity(userId, activityItem)
-		return
+		fmt.Printf("WARNING: failed to audit HTML for MVP %s: %v\n", req.MVPId, err)
+	} else if auditJSON, err := json.Marshal(audit); err == nil {
+		mvp.QualityAudit = auditJSON
 	}
 
 	// Update the MVP with the HTML URL
@@ -345,24 +420,437 @@ func (s *mvpService) generateAndSaveMVPWorkflow(idea *domain.Idea, mvp *domain.M
 	activityItem.ID = mvp.ID.String()
 	activityItem.Type = "mvp_generated"
 	activityItem.Message = "Landing page generation has been completed successfully."
+	if audit != nil {
+		activityItem.Message = fmt.Sprintf("Landing page generation has been completed successfully. Quality score: %d/100.", audit.Score)
+	}
+	if sanitization.TotalRemoved > 0 {
+		activityItem.Message += fmt.Sprintf(" %d unsafe element(s) were removed from the page.", sanitization.TotalRemoved)
+	}
 	activityItem.ReferenceURL = fmt.Sprintf("/mvp/%s?mvpId=%s", req.IdeaID.String(), mvp.ID.String())
 	s.broadcaster.BroadcastActivity(userId, activityItem)
 }
 
-func (s *mvpService) checkOwner(ctx context.Context, userId string, ideaId uuid.UUID) (*domain.Idea, error) {
-	idea, _, err := s.ideaRepo.GetByID(ctx, ideaId, nil, nil)
+// validatorConfigFor returns the HTML validator config with the page specific share image, locale and survey of the MVP.
+func (s *mvpService) validatorConfigFor(mvp *domain.MVPSimulator) validation.HTMLValidatorConfig {
+	cfg := s.cfg.HTMLValidator
+	cfg.ShareImageURL = mvp.ShareImageURL
+	cfg.Locale = mvp.Locale
+	cfg.Survey = surveyFor(mvp)
+	return cfg
+}
 
+// mvpHTMLKey is the storage key of a landing page, locale variants are stored next to the original.
+func mvpHTMLKey(ideaId, mvpId uuid.UUID, locale string) string {
+	if locale == "" {
+		return fmt.Sprintf("%s/mvp/%s", ideaId.String(), mvpId.String())
+	}
+	return fmt.Sprintf("%s/mvp/%s/%s", ideaId.String(), mvpId.String(), locale)
+}
+
+// uploadHTML stores a landing page document in R2 and returns its public URL.
+func (s *mvpService) uploadHTML(ctx context.Context, key, htmlContent string) (string, error) {
+	contentType := "text/html"
+	signedUrl, htmlUrl, err := s.r2Client.GetSignedURL(ctx, key, contentType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get idea: %w", err)
+		return "", fmt.Errorf("failed to get signed URL: %w", err)
 	}
 
-	if idea == nil {
+	reqPut, err := http.NewRequestWithContext(ctx, "PUT", signedUrl, strings.NewReader(htmlContent))
+	if err != nil {
+		return "", fmt.Errorf("failed to create PUT request: %w", err)
+	}
+	reqPut.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(reqPut)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload HTML: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to upload HTML: status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	return htmlUrl, nil
+}
+
+// fetchHTML reads back a previously uploaded landing page from the bucket,
+// pages hosted anywhere else are refused instead of fetched.
+func (s *mvpService) fetchHTML(ctx context.Context, htmlUrl string) (string, error) {
+	if !s.r2Client.Owns(htmlUrl) {
+		return "", ErrExternalLandingPage
+	}
+
+	body, err := s.r2Client.Download(ctx, htmlUrl)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch HTML: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// UpdatePricingTest stores the fake-door pricing configuration of an MVP and,
+// when the page was already published, rebuilds it with the new pricing block.
+func (s *mvpService) UpdatePricingTest(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.UpdatePricingTest) (*validation.PricingBlock, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
 		return nil, gorm.ErrRecordNotFound
 	}
 
-	if idea.UserID != userId {
-		return nil, fmt.Errorf("user is not the owner of the idea")
+	if req.Enabled && len(req.Tiers) == 0 {
+		return nil, ErrPricingTiersRequired
+	}
+
+	block := validation.PricingBlock{
+		Enabled:    req.Enabled,
+		Heading:    req.Heading,
+		Subheading: req.Subheading,
+		Tiers:      make([]validation.PricingTier, 0, len(req.Tiers)),
+	}
+	if block.Heading == "" {
+		block.Heading = "Pricing"
+	}
+
+	usedIds := make(map[string]bool)
+	for _, tier := range req.Tiers {
+		tierId := slug.Make(tier.ID)
+		if tierId == "" {
+			tierId = slug.Make(tier.Name)
+		}
+		for base, i := tierId, 2; usedIds[tierId]; i++ {
+			tierId = fmt.Sprintf("%s-%d", base, i)
+		}
+		usedIds[tierId] = true
+
+		block.Tiers = append(block.Tiers, validation.PricingTier{
+			ID:            tierId,
+			Name:          tier.Name,
+			Price:         *tier.Price,
+			Currency:      strings.ToUpper(tier.Currency),
+			BillingPeriod: tier.BillingPeriod,
+			Features:      tier.Features,
+			Highlighted:   tier.Highlighted,
+		})
+	}
+
+	blockJSON, err := json.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pricing test: %w", err)
+	}
+	mvp.PricingTest = blockJSON
+
+	if mvp.HTMLURL != "" {
+		if err := s.rebuildPage(ctx, mvp); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, mvp); err != nil {
+		return nil, fmt.Errorf("failed to save pricing test: %w", err)
+	}
+
+	return &block, nil
+}
+
+// rebuildPage re-renders an already published page with the current pricing block and survey of the MVP.
+func (s *mvpService) rebuildPage(ctx context.Context, mvp *domain.MVPSimulator) error {
+	currentHTML, err := s.fetchHTML(ctx, mvp.HTMLURL)
+	if err != nil {
+		return fmt.Errorf("failed to load current landing page: %w", err)
+	}
+
+	bodyContent, metaTitle, metaDescription, err := validation.ExtractPage(currentHTML)
+	if err != nil {
+		return err
+	}
+
+	if pricing := pricingBlockFor(mvp); pricing != nil {
+		bodyContent, err = validation.InjectPricingBlock(bodyContent, *pricing)
+		if err != nil {
+			return fmt.Errorf("failed to insert pricing block: %w", err)
+		}
+	}
+
+	fullHTML, err := validation.GetValidatedHTML(bodyContent, metaTitle, metaDescription, mvp.IdeaID.String(), mvp.ID.String(), s.validatorConfigFor(mvp))
+	if err != nil {
+		return err
+	}
+
+	htmlUrl, err := s.uploadHTML(ctx, mvpHTMLKey(mvp.IdeaID, mvp.ID, ""), fullHTML)
+	if err != nil {
+		return err
+	}
+	mvp.HTMLURL = htmlUrl
+
+	// keep the stored audit in line with the page that is now live
+	if audit, err := validation.AuditHTML(fullHTML, s.cfg.HTMLValidator.CTAButtonID); err != nil {
+		fmt.Printf("WARNING: failed to audit HTML for MVP %s: %v\n", mvp.ID, err)
+	} else if auditJSON, err := json.Marshal(audit); err == nil {
+		mvp.QualityAudit = auditJSON
+	}
+
+	return nil
+}
+
+// UpdateSurvey stores the post-signup survey of an MVP and rebuilds the published
+// page, the tracking script renders the survey after the CTA click.
+func (s *mvpService) UpdateSurvey(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.UpdateSurvey) (*validation.Survey, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if req.Enabled && len(req.Questions) == 0 {
+		return nil, ErrSurveyQuestionRequired
+	}
+
+	survey := validation.Survey{
+		Enabled:   req.Enabled,
+		Title:     req.Title,
+		Questions: make([]validation.SurveyQuestion, 0, len(req.Questions)),
+	}
+	if survey.Title == "" {
+		survey.Title = "One more thing"
+	}
+
+	usedIds := make(map[string]bool)
+	for i, q := range req.Questions {
+		question := validation.SurveyQuestion{
+			Prompt:   strings.TrimSpace(q.Prompt),
+			Type:     validation.SurveyQuestionType(q.Type),
+			Required: q.Required,
+		}
+
+		if question.Type == validation.SurveyQuestionChoice {
+			seen := make(map[string]bool)
+			for _, option := range q.Options {
+				option = strings.TrimSpace(option)
+				if option != "" && !seen[option] {
+					seen[option] = true
+					question.Options = append(question.Options, option)
+				}
+			}
+			if len(question.Options) < 2 {
+				return nil, ErrSurveyOptionsRequired
+			}
+		}
+
+		// ids are kept stable across edits so earlier answers stay attached to their question
+		questionId := slug.Make(q.ID)
+		if questionId == "" {
+			questionId = fmt.Sprintf("q%d", i+1)
+		}
+		for base, n := questionId, 2; usedIds[questionId]; n++ {
+			questionId = fmt.Sprintf("%s-%d", base, n)
+		}
+		usedIds[questionId] = true
+		question.ID = questionId
+
+		survey.Questions = append(survey.Questions, question)
+	}
+
+	surveyJSON, err := json.Marshal(survey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal survey: %w", err)
+	}
+	mvp.Survey = surveyJSON
+
+	if mvp.HTMLURL != "" {
+		if err := s.rebuildPage(ctx, mvp); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, mvp); err != nil {
+		return nil, fmt.Errorf("failed to save survey: %w", err)
+	}
+
+	return &survey, nil
+}
+
+// GetPricingAnalytics reports click-through per pricing tier and price point
+// alongside the regular CTA conversion of the MVP.
+func (s *mvpService) GetPricingAnalytics(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) (*response.PricingAnalytics, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	signals, err := s.signalRepo.GetByMVPId(ctx, mvpId, []domain.EventType{domain.EventTypePageView, domain.EventTypePricing})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signals: %w", err)
+	}
+
+	signups, err := s.audienceRepo.GetCountByMVPId(ctx, mvpId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signups: %w", err)
+	}
+
+	type clickStats struct {
+		clicks   int
+		sessions map[string]bool
+	}
+	newClickStats := func() *clickStats { return &clickStats{sessions: make(map[string]bool)} }
+
+	type pricePointKey struct {
+		price         float64
+		currency      string
+		billingPeriod string
+	}
+
+	visitors := make(map[string]bool)
+	pricingClickers := make(map[string]bool)
+	tierStats := make(map[string]*clickStats)
+	tierNames := make(map[string]string)
+	pricePointStats := make(map[pricePointKey]*clickStats)
+
+	result := &response.PricingAnalytics{
+		MVPID:       mvpId,
+		Signups:     signups,
+		Tiers:       []response.PricingTierStats{},
+		PricePoints: []response.PricePointStats{},
+	}
+
+	for _, signal := range signals {
+		sessionKey := signalSessionKey(&signal)
+
+		if signal.EventType == string(domain.EventTypePageView) {
+			result.Views++
+			visitors[sessionKey] = true
+			continue
+		}
+
+		var meta struct {
+			TierID        string  `json:"tierId"`
+			TierName      string  `json:"tierName"`
+			Price         float64 `json:"price"`
+			Currency      string  `json:"currency"`
+			BillingPeriod string  `json:"billingPeriod"`
+		}
+		if signal.Metadata == nil || json.Unmarshal(signal.Metadata, &meta) != nil || meta.TierID == "" {
+			continue
+		}
+
+		result.PricingClicks++
+		pricingClickers[sessionKey] = true
+
+		if _, ok := tierStats[meta.TierID]; !ok {
+			tierStats[meta.TierID] = newClickStats()
+		}
+		tierStats[meta.TierID].clicks++
+		tierStats[meta.TierID].sessions[sessionKey] = true
+		tierNames[meta.TierID] = meta.TierName
+
+		key := pricePointKey{price: meta.Price, currency: meta.Currency, billingPeriod: meta.BillingPeriod}
+		if _, ok := pricePointStats[key]; !ok {
+			pricePointStats[key] = newClickStats()
+		}
+		pricePointStats[key].clicks++
+		pricePointStats[key].sessions[sessionKey] = true
+	}
+
+	result.UniqueVisitors = len(visitors)
+	rate := func(part, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return float64(part) / float64(total) * 100
+	}
+
+	if result.Views > 0 {
+		result.CTAConversionRate = float64(signups) / float64(result.Views) * 100
+	}
+	result.PricingClickThroughRate = rate(len(pricingClickers), result.UniqueVisitors)
+
+	// Configured tiers are always listed, even without clicks, in their configured order
+	if pricing := pricingBlockFor(mvp); pricing != nil {
+		result.Enabled = pricing.Enabled
+		for _, tier := range pricing.Tiers {
+			if _, ok := tierStats[tier.ID]; !ok {
+				tierStats[tier.ID] = newClickStats()
+			}
+			tierNames[tier.ID] = tier.Name
+		}
+		for _, tier := range pricing.Tiers {
+			stats := tierStats[tier.ID]
+			result.Tiers = append(result.Tiers, response.PricingTierStats{
+				TierID:           tier.ID,
+				Name:             tier.Name,
+				Clicks:           stats.clicks,
+				UniqueClickers:   len(stats.sessions),
+				ClickThroughRate: rate(len(stats.sessions), result.UniqueVisitors),
+			})
+			delete(tierStats, tier.ID)
+		}
+	}
+
+	// Tiers that were removed from the configuration but still have clicks,
+	// after the configured ones by tier id
+	removedTiers := make([]string, 0, len(tierStats))
+	for tierId := range tierStats {
+		removedTiers = append(removedTiers, tierId)
+	}
+	sort.Strings(removedTiers)
+	for _, tierId := range removedTiers {
+		stats := tierStats[tierId]
+		result.Tiers = append(result.Tiers, response.PricingTierStats{
+			TierID:           tierId,
+			Name:             tierNames[tierId],
+			Clicks:           stats.clicks,
+			UniqueClickers:   len(stats.sessions),
+			ClickThroughRate: rate(len(stats.sessions), result.UniqueVisitors),
+		})
+	}
+
+	for key, stats := range pricePointStats {
+		result.PricePoints = append(result.PricePoints, response.PricePointStats{
+			Label:            validation.FormatPrice(key.price, key.currency, key.billingPeriod),
+			Price:            key.price,
+			Currency:         key.currency,
+			BillingPeriod:    key.billingPeriod,
+			Clicks:           stats.clicks,
+			UniqueClickers:   len(stats.sessions),
+			ClickThroughRate: rate(len(stats.sessions), result.UniqueVisitors),
+		})
+	}
+	sort.Slice(result.PricePoints, func(i, j int) bool {
+		a, b := result.PricePoints[i], result.PricePoints[j]
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.BillingPeriod < b.BillingPeriod
+	})
+
+	return result, nil
+}
+
+// pricingBlockFor decodes the pricing test stored on the MVP, nil when none is configured.
+func pricingBlockFor(mvp *domain.MVPSimulator) *validation.PricingBlock {
+	if len(mvp.PricingTest) == 0 {
+		return nil
+	}
+
+	var block validation.PricingBlock
+	if err := json.Unmarshal(mvp.PricingTest, &block); err != nil {
+		fmt.Printf("WARNING: invalid pricing test for MVP %s: %v\n", mvp.ID, err)
+		return nil
 	}
 
-	return idea, nil
+	return &block
 }
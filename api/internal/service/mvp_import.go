@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/bundle"
+	"foundersignal/internal/pkg/validation"
+	"html"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	mvpCleanupTimeout = 30 * time.Second
+	// inlineStylesFile holds the <style> blocks of an imported page
+	inlineStylesFile = "_inline.css"
+)
+
+var ErrInvalidLandingPage = errors.New("the uploaded landing page is invalid")
+
+// Import creates an MVP from a founder built landing page. The assets of the
+// bundle are hosted next to the page and the document goes through the same
+// validation as generated pages, so the tracking script is injected.
+func (s *mvpService) Import(ctx context.Context, userId string, ideaId uuid.UUID, req request.ImportMVP, filename string, data []byte) (*response.ImportedMVP, error) {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMVPLimit(ctx, idea); err != nil {
+		return nil, err
+	}
+
+	b, err := bundle.Read(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	mvp := &domain.MVPSimulator{
+		Base:   domain.Base{ID: uuid.New()},
+		IdeaID: ideaId,
+		Name:   req.Name,
+	}
+	if mvp.Name == "" {
+		mvp.Name = strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	}
+
+	// Validate the page before any asset is stored, a page without the CTA button is rejected as a whole
+	draft, err := bundle.ParsePage(b.HTML, b.EntryPath, func(string) (string, bool) { return "", false })
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLandingPage, err)
+	}
+	metaTitle, metaDescription := importMeta(req, draft, idea)
+	if _, err := validation.GetValidatedHTML(draft.Body, metaTitle, metaDescription, ideaId.String(), mvp.ID.String(), s.validatorConfigFor(mvp)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLandingPage, err)
+	}
+
+	assetURLs, uploadedKeys, err := s.uploadAssets(ctx, ideaId, mvp.ID, b)
+	if err != nil {
+		s.deleteAssets(uploadedKeys)
+		return nil, err
+	}
+
+	page, err := bundle.ParsePage(b.HTML, b.EntryPath, func(assetPath string) (string, bool) {
+		u, ok := assetURLs[assetPath]
+		return u, ok
+	})
+	if err != nil {
+		s.deleteAssets(uploadedKeys)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLandingPage, err)
+	}
+
+	body := page.Body
+	if page.InlineCSS != "" {
+		key := mvpAssetKey(ideaId, mvp.ID, inlineStylesFile)
+		cssUrl, err := s.r2Client.Upload(ctx, key, "text/css", []byte(page.InlineCSS))
+		if err != nil {
+			s.deleteAssets(uploadedKeys)
+			return nil, fmt.Errorf("failed to upload inline styles: %w", err)
+		}
+		uploadedKeys = append(uploadedKeys, key)
+		body = fmt.Sprintf(`<link rel="stylesheet" href="%s">`, html.EscapeString(cssUrl)) + body
+	}
+
+	if shareUrl, err := s.shareCards.GenerateMVPCard(ctx, idea, mvp); err != nil {
+		fmt.Printf("WARNING: failed to generate share card for MVP %s: %v\n", mvp.ID, err)
+	} else {
+		mvp.ShareImageURL = shareUrl
+	}
+
+	validatedHtml, sanitization, err := validation.GetValidatedHTMLWithReport(body, metaTitle, metaDescription, ideaId.String(), mvp.ID.String(), s.validatorConfigFor(mvp))
+	if err != nil {
+		s.deleteAssets(uploadedKeys)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLandingPage, err)
+	}
+
+	htmlUrl, err := s.uploadHTML(ctx, mvpHTMLKey(ideaId, mvp.ID, ""), validatedHtml)
+	if err != nil {
+		s.deleteAssets(uploadedKeys)
+		return nil, err
+	}
+	mvp.HTMLURL = htmlUrl
+
+	if sanitizationJSON, err := json.Marshal(sanitization); err == nil {
+		mvp.Sanitization = sanitizationJSON
+	}
+
+	audit, err := validation.AuditHTML(validatedHtml, s.cfg.HTMLValidator.CTAButtonID)
+	if err != nil {
+		fmt.Printf("WARNING: failed to audit HTML for MVP %s: %v\n", mvp.ID, err)
+	} else if auditJSON, err := json.Marshal(audit); err == nil {
+		mvp.QualityAudit = auditJSON
+	}
+
+	if _, err := s.repo.Create(ctx, mvp); err != nil {
+		s.deleteAssets(append(uploadedKeys, mvpHTMLKey(ideaId, mvp.ID, "")))
+		return nil, fmt.Errorf("failed to create MVP: %w", err)
+	}
+
+	if req.IsActive {
+		if err := s.repo.SetActive(ctx, ideaId, mvp.ID); err != nil {
+			return nil, fmt.Errorf("mvp created but failed to set as active: %w", err)
+		}
+	}
+
+	skipped := b.Skipped
+	if skipped == nil {
+		skipped = []string{}
+	}
+
+	return &response.ImportedMVP{
+		MVPId:         mvp.ID.String(),
+		HTMLURL:       htmlUrl,
+		EntryFile:     b.EntryPath,
+		AssetCount:    len(assetURLs),
+		RewrittenURLs: page.Rewritten,
+		SkippedFiles:  skipped,
+		Audit: &response.LandingPageAudit{
+			Quality:      audit,
+			Sanitization: sanitization,
+		},
+	}, nil
+}
+
+// uploadAssets stores the bundle assets and returns their public URLs by bundle path.
+// Stylesheets are uploaded last so their url() references can point to the hosted files.
+func (s *mvpService) uploadAssets(ctx context.Context, ideaId, mvpId uuid.UUID, b *bundle.Bundle) (map[string]string, []string, error) {
+	paths := make([]string, 0, len(b.Assets))
+	for p := range b.Assets {
+		paths = append(paths, p)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return !isCSS(paths[i]) && isCSS(paths[j])
+	})
+
+	urls := make(map[string]string, len(paths))
+	keys := make([]string, 0, len(paths))
+	resolve := func(assetPath string) (string, bool) {
+		u, ok := urls[assetPath]
+		return u, ok
+	}
+
+	for _, p := range paths {
+		content := b.Assets[p]
+		if isCSS(p) {
+			content = []byte(bundle.RewriteCSS(string(content), p, resolve))
+		}
+
+		key := mvpAssetKey(ideaId, mvpId, p)
+		publicUrl, err := s.r2Client.Upload(ctx, key, bundle.ContentType(p), content)
+		if err != nil {
+			return nil, keys, fmt.Errorf("failed to upload asset %s: %w", p, err)
+		}
+
+		keys = append(keys, key)
+		urls[p] = publicUrl
+	}
+
+	return urls, keys, nil
+}
+
+// deleteAssets removes already uploaded files of an import that failed halfway.
+func (s *mvpService) deleteAssets(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mvpCleanupTimeout)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := s.r2Client.Delete(ctx, key); err != nil {
+			fmt.Printf("WARNING: failed to delete imported asset %s: %v\n", key, err)
+		}
+	}
+}
+
+// mvpAssetKey keeps the bundle path as is, the bucket escapes it in the public URL.
+func mvpAssetKey(ideaId, mvpId uuid.UUID, assetPath string) string {
+	return fmt.Sprintf("%s/assets/%s", mvpHTMLKey(ideaId, mvpId, ""), assetPath)
+}
+
+// importMeta prefers the form values, then the head of the uploaded page, then the idea itself.
+func importMeta(req request.ImportMVP, page *bundle.Page, idea *domain.Idea) (string, string) {
+	title := firstNonEmpty(req.MetaTitle, page.Title, idea.Title)
+	description := firstNonEmpty(req.MetaDescription, page.Description, idea.Description)
+	return title, description
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+func isCSS(p string) bool {
+	return strings.EqualFold(path.Ext(p), ".css")
+}
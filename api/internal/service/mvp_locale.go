@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/prompts"
+	"foundersignal/internal/pkg/validation"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidLocale        = errors.New("invalid locale, expected a BCP 47 language tag such as \"de\" or \"pt-BR\"")
+	ErrLocaleIsOriginal     = errors.New("the landing page is already in this locale")
+	ErrLandingPageNotPublic = errors.New("the landing page has to be generated before it can be translated")
+)
+
+// CreateLocaleVariant starts the AI translation of an MVP landing page into another locale.
+// The variant is returned in pending state, the founder is notified once it is ready.
+func (s *mvpService) CreateLocaleVariant(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, req request.CreateLocaleVariant) (*domain.MVPLocale, error) {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	tag, err := language.Parse(req.Locale)
+	if err != nil {
+		return nil, ErrInvalidLocale
+	}
+	locale := tag.String()
+
+	if strings.EqualFold(locale, mvp.Locale) {
+		return nil, ErrLocaleIsOriginal
+	}
+	if mvp.HTMLURL == "" {
+		return nil, ErrLandingPageNotPublic
+	}
+	if !s.r2Client.Owns(mvp.HTMLURL) {
+		return nil, ErrExternalLandingPage
+	}
+
+	billing, err := s.access.BillingFor(ctx, idea)
+	if err != nil {
+		return nil, err
+	}
+	aiGenLimit := billing.Limits().AIGenLimit
+	if mvp.AIGenerations >= aiGenLimit {
+		return nil, fmt.Errorf("you have reached the AI generation limit of %d for the %s plan", aiGenLimit, billing.Plan)
+	}
+
+	variant := &domain.MVPLocale{
+		MVPSimulatorID: mvpId,
+		IdeaID:         ideaId,
+		Locale:         locale,
+		Status:         string(domain.MVPLocaleStatusPending),
+	}
+	if err := s.localeRepo.Upsert(ctx, variant); err != nil {
+		return nil, fmt.Errorf("failed to create locale variant: %w", err)
+	}
+
+	localMVP := *mvp
+	localVariant := *variant
+	go s.translateMVPWorkflow(idea, &localMVP, &localVariant, userId)
+
+	return variant, nil
+}
+
+func (s *mvpService) GetLocaleVariants(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) ([]domain.MVPLocale, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.localeRepo.GetByMVP(ctx, mvpId)
+}
+
+func (s *mvpService) DeleteLocaleVariant(ctx context.Context, userId string, ideaId, mvpId uuid.UUID, locale string) error {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleEditor); err != nil {
+		return err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return gorm.ErrRecordNotFound
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return ErrInvalidLocale
+	}
+
+	return s.localeRepo.Delete(ctx, mvpId, tag.String())
+}
+
+// GetLocaleAnalytics breaks the views and signups of an MVP down by the locale that was served.
+func (s *mvpService) GetLocaleAnalytics(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) ([]response.LocaleAnalytics, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.repo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	signals, err := s.signalRepo.GetByMVPId(ctx, mvpId, []domain.EventType{domain.EventTypePageView, domain.EventTypeClick})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signals: %w", err)
+	}
+
+	type localeStats struct {
+		views    int
+		visitors map[string]bool
+		signups  map[string]bool
+	}
+	statsByLocale := make(map[string]*localeStats)
+
+	// every ready variant is listed, even before it received traffic
+	statsFor := func(locale string) *localeStats {
+		if _, ok := statsByLocale[locale]; !ok {
+			statsByLocale[locale] = &localeStats{visitors: make(map[string]bool), signups: make(map[string]bool)}
+		}
+		return statsByLocale[locale]
+	}
+	statsFor(mvp.Locale)
+
+	variants, err := s.localeRepo.GetByMVP(ctx, mvpId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch locale variants: %w", err)
+	}
+	for _, variant := range variants {
+		if variant.Status == string(domain.MVPLocaleStatusReady) {
+			statsFor(variant.Locale)
+		}
+	}
+
+	for _, signal := range signals {
+		locale := signal.Locale
+		if locale == "" {
+			locale = mvp.Locale
+		}
+
+		stats := statsFor(locale)
+		sessionKey := signalSessionKey(&signal)
+		switch signal.EventType {
+		case string(domain.EventTypePageView):
+			stats.views++
+			stats.visitors[sessionKey] = true
+		case string(domain.EventTypeClick):
+			stats.signups[sessionKey] = true
+		}
+	}
+
+	result := make([]response.LocaleAnalytics, 0, len(statsByLocale))
+	for locale, stats := range statsByLocale {
+		item := response.LocaleAnalytics{
+			Locale:         locale,
+			IsOriginal:     locale == mvp.Locale,
+			Views:          stats.views,
+			UniqueVisitors: len(stats.visitors),
+			Signups:        len(stats.signups),
+		}
+		if item.UniqueVisitors > 0 {
+			item.ConversionRate = float64(item.Signups) / float64(item.UniqueVisitors) * 100
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Views != result[j].Views {
+			return result[i].Views > result[j].Views
+		}
+		return result[i].Locale < result[j].Locale
+	})
+
+	return result, nil
+}
+
+// localizeMVP swaps the page of the MVP for the locale variant that best matches
+// the explicit lang parameter or, when absent, the Accept-Language header.
+func (s *mvpService) localizeMVP(ctx context.Context, mvp *domain.MVPSimulator, lang, acceptLanguage string) {
+	variants, err := s.localeRepo.GetByMVP(ctx, mvp.ID)
+	if err != nil {
+		fmt.Printf("WARNING: failed to get locale variants for MVP %s: %v\n", mvp.ID, err)
+		return
+	}
+
+	baseTag, err := language.Parse(mvp.Locale)
+	if err != nil {
+		baseTag = language.English
+	}
+
+	supported := []language.Tag{baseTag}
+	ready := []domain.MVPLocale{{Locale: mvp.Locale, HTMLURL: mvp.HTMLURL}}
+	mvp.AvailableLocales = []string{mvp.Locale}
+	for _, variant := range variants {
+		if variant.Status != string(domain.MVPLocaleStatusReady) || variant.HTMLURL == "" {
+			continue
+		}
+		tag, err := language.Parse(variant.Locale)
+		if err != nil {
+			continue
+		}
+		supported = append(supported, tag)
+		ready = append(ready, variant)
+		mvp.AvailableLocales = append(mvp.AvailableLocales, variant.Locale)
+	}
+
+	if len(ready) == 1 {
+		return
+	}
+
+	var preferred []language.Tag
+	if lang != "" {
+		// an explicit choice always wins over the browser preferences
+		if tag, err := language.Parse(lang); err == nil {
+			preferred = append(preferred, tag)
+		}
+	} else if acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+			preferred = append(preferred, tags...)
+		}
+	}
+	if len(preferred) == 0 {
+		return
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if confidence == language.No || index <= 0 {
+		return
+	}
+
+	mvp.HTMLURL = ready[index].HTMLURL
+	mvp.Locale = ready[index].Locale
+}
+
+func (s *mvpService) translateMVPWorkflow(idea *domain.Idea, mvp *domain.MVPSimulator, variant *domain.MVPLocale, userId string) {
+	translationTimeout := 2 * time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), translationTimeout)
+	defer cancel()
+
+	activityItem := &response.ActivityItem{
+		ID:        idea.ID.String(),
+		Type:      "error",
+		IdeaID:    idea.ID.String(),
+		IdeaTitle: idea.Title,
+	}
+
+	fail := func(message string, err error) {
+		fmt.Printf("ERROR: failed to translate MVP %s to %s: %v\n", mvp.ID, variant.Locale, err)
+		if err := s.localeRepo.UpdateStatus(ctx, variant.ID, domain.MVPLocaleStatusFailed, ""); err != nil {
+			fmt.Printf("ERROR: failed to mark locale variant %s as failed: %v\n", variant.ID, err)
+		}
+		activityItem.Message = message
+		s.broadcaster.BroadcastActivity(userId, activityItem)
+	}
+
+	currentHTML, err := s.fetchHTML(ctx, mvp.HTMLURL)
+	if err != nil {
+		fail("Failed to load the landing page for translation. Please try again later.", err)
+		return
+	}
+
+	bodyContent, metaTitle, metaDescription, err := validation.ExtractPage(currentHTML)
+	if err != nil {
+		fail("Failed to read the landing page for translation. Please try again later.", err)
+		return
+	}
+
+	segments, err := validation.ExtractTextSegments(bodyContent)
+	if err != nil {
+		fail("Failed to read the landing page for translation. Please try again later.", err)
+		return
+	}
+
+	// title and description are translated together with the page text
+	texts := append([]string{metaTitle, metaDescription}, segments.Texts()...)
+	translated, err := s.translateTexts(ctx, variant.Locale, texts)
+	if err != nil {
+		fail("Failed to translate the landing page. Please try again.", err)
+		return
+	}
+
+	translatedBody, err := segments.Apply(translated[2:])
+	if err != nil {
+		fail("Failed to translate the landing page. Please try again.", err)
+		return
+	}
+
+	cfg := s.validatorConfigFor(mvp)
+	cfg.Locale = variant.Locale
+	fullHTML, err := validation.GetValidatedHTML(translatedBody, translated[0], translated[1], mvp.IdeaID.String(), mvp.ID.String(), cfg)
+	if err != nil {
+		fail("The translated landing page is invalid. Please try again.", err)
+		return
+	}
+
+	htmlUrl, err := s.uploadHTML(ctx, mvpHTMLKey(mvp.IdeaID, mvp.ID, variant.Locale), fullHTML)
+	if err != nil {
+		fail("Failed to save the translated landing page. Please try again later.", err)
+		return
+	}
+
+	if err := s.localeRepo.UpdateStatus(ctx, variant.ID, domain.MVPLocaleStatusReady, htmlUrl); err != nil {
+		fail("Failed to save the translated landing page. Please try again later.", err)
+		return
+	}
+
+	mvp.AIGenerations++
+	if err := s.repo.Update(ctx, &domain.MVPSimulator{Base: domain.Base{ID: mvp.ID}, IdeaID: mvp.IdeaID, AIGenerations: mvp.AIGenerations}); err != nil {
+		fmt.Printf("WARNING: failed to update AI generations for MVP %s: %v\n", mvp.ID, err)
+	}
+
+	activityItem.ID = variant.ID.String()
+	activityItem.Type = "mvp_translated"
+	activityItem.Message = fmt.Sprintf("The %s version of your landing page is ready.", variant.Locale)
+	activityItem.ReferenceURL = fmt.Sprintf("/mvp/%s?mvpId=%s&lang=%s", mvp.IdeaID.String(), mvp.ID.String(), variant.Locale)
+	s.broadcaster.BroadcastActivity(userId, activityItem)
+}
+
+// translateTexts translates the segments with the AI service, the result has the same length as texts.
+func (s *mvpService) translateTexts(ctx context.Context, locale string, texts []string) ([]string, error) {
+	textsJSON, err := json.Marshal(texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal texts: %w", err)
+	}
+
+	aiResponse, err := s.aiService.Generate(ctx, prompts.BuildTranslationPrompt(locale, string(textsJSON)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate translation: %w", err)
+	}
+
+	cleanedResponse := strings.TrimSpace(aiResponse)
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
+	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
+
+	var translated []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleanedResponse)), &translated); err != nil {
+		return nil, fmt.Errorf("failed to parse translation: %w", err)
+	}
+
+	if len(translated) != len(texts) {
+		return nil, fmt.Errorf("expected %d translated segments, got %d", len(texts), len(translated))
+	}
+
+	return translated, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/PaddleHQ/paddle-go-sdk"
 	"github.com/PaddleHQ/paddle-go-sdk/pkg/paddlenotification"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -32,18 +33,93 @@ type PaddleServiceConfig struct {
 
 type paddleService struct {
 	userRepo           repository.UserRepository
+	workspaceRepo      repository.WorkspaceRepository
 	processedEventRepo repository.PaddleRepository
 	cfg                PaddleServiceConfig
 }
 
-func NewPaddleService(userRepo repository.UserRepository, processedEventRepo repository.PaddleRepository, cfg PaddleServiceConfig) *paddleService {
+func NewPaddleService(userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, processedEventRepo repository.PaddleRepository,
+	cfg PaddleServiceConfig) *paddleService {
 	return &paddleService{
 		userRepo:           userRepo,
+		workspaceRepo:      workspaceRepo,
 		processedEventRepo: processedEventRepo,
 		cfg:                cfg,
 	}
 }
 
+// subscriber is who a subscription is attached to, a user or a workspace
+type subscriber struct {
+	user      *domain.User
+	workspace *domain.Workspace
+}
+
+func (sub *subscriber) paddlePlanID() *string {
+	if sub.workspace != nil {
+		return sub.workspace.PaddlePlanID
+	}
+	return sub.user.PaddlePlanID
+}
+
+func (s *paddleService) findSubscriber(ctx context.Context, paddleSubscriptionID string) (*subscriber, error) {
+	workspace, err := s.workspaceRepo.FindByPaddleSubscriptionID(ctx, paddleSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace != nil {
+		return &subscriber{workspace: workspace}, nil
+	}
+
+	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, paddleSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		return &subscriber{user: user}, nil
+	}
+
+	return nil, nil
+}
+
+// updateSubscriber writes the subscription fields of updates to the user or
+// the workspace of the subscription.
+func (s *paddleService) updateSubscriber(ctx context.Context, sub *subscriber, updates *domain.User) error {
+	if sub.workspace == nil {
+		return s.userRepo.Update(ctx, sub.user.ID, updates)
+	}
+
+	return s.workspaceRepo.UpdateSubscription(ctx, sub.workspace.ID, &domain.Workspace{
+		Plan:                    updates.Plan,
+		IsPaying:                updates.IsPaying,
+		PaddleSubscriptionID:    updates.PaddleSubscriptionID,
+		PaddleCustomerID:        updates.PaddleCustomerID,
+		PaddlePlanID:            updates.PaddlePlanID,
+		SubscriptionStatus:      updates.SubscriptionStatus,
+		SubscriptionExpiresAt:   updates.SubscriptionExpiresAt,
+		SubscriptionCancelledAt: updates.SubscriptionCancelledAt,
+		SubscriptionPausedAt:    updates.SubscriptionPausedAt,
+	})
+}
+
+// findWorkspaceForOwner returns the workspace a checkout was made for, only
+// its owner can attach a subscription to it.
+func (s *paddleService) findWorkspaceForOwner(ctx context.Context, workspaceID, userID string) (*domain.Workspace, error) {
+	id, err := uuid.Parse(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID %s: %w", workspaceID, err)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error finding workspace by ID %s: %w", workspaceID, err)
+	}
+	if workspace.OwnerID != userID {
+		return nil, fmt.Errorf("user %s is not the owner of workspace %s", userID, workspaceID)
+	}
+
+	return workspace, nil
+}
+
 func (s *paddleService) ProcessEvent(ctx context.Context, eventID string, eventType paddlenotification.EventTypeName, requestBody []byte) error {
 	exists, err := s.processedEventRepo.Exists(ctx, eventID)
 	if err != nil {
@@ -136,6 +212,7 @@ func (s *paddleService) handleSubscriptionCreated(ctx context.Context, evt paddl
 
 	var userID string
 	var userEmail string
+	var workspaceID string // set when the checkout was for a workspace instead of the user
 	if data.CustomData != nil {
 		if id, ok := data.CustomData["userId"].(string); ok && id != "" {
 			userID = id
@@ -144,6 +221,10 @@ func (s *paddleService) handleSubscriptionCreated(ctx context.Context, evt paddl
 		if email, ok := data.CustomData["userEmail"].(string); ok && email != "" {
 			userEmail = email
 		}
+
+		if id, ok := data.CustomData["workspaceId"].(string); ok && id != "" {
+			workspaceID = id
+		}
 	}
 
 	var user *domain.User
@@ -206,6 +287,15 @@ func (s *paddleService) handleSubscriptionCreated(ctx context.Context, evt paddl
 	// For now, we assume PaddlePlanID is sufficient.
 	// user.Plan = s.mapPaddlePlanToInternalPlan(updates.PaddlePlanID)
 
+	if workspaceID != "" {
+		workspace, err := s.findWorkspaceForOwner(ctx, workspaceID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.updateSubscriber(ctx, &subscriber{workspace: workspace}, &updates)
+	}
+
 	return s.userRepo.Update(ctx, user.ID, &updates)
 }
 
@@ -214,12 +304,12 @@ func (s *paddleService) handleSubscriptionUpdated(ctx context.Context, evt paddl
 
 	log.Printf("Handling SubscriptionUpdated: PaddleSubscriptionID=%s, Status=%s", data.ID, data.Status)
 
-	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, data.ID)
+	sub, err := s.findSubscriber(ctx, data.ID)
 	if err != nil {
-		return fmt.Errorf("error finding user by PaddleSubscriptionID %s: %w", data.ID, err)
+		return fmt.Errorf("error finding subscriber by PaddleSubscriptionID %s: %w", data.ID, err)
 	}
-	if user == nil {
-		return fmt.Errorf("user not found for PaddleSubscriptionID %s", data.ID)
+	if sub == nil {
+		return fmt.Errorf("no user or workspace found for PaddleSubscriptionID %s", data.ID)
 	}
 
 	updates := domain.User{
@@ -227,7 +317,7 @@ func (s *paddleService) handleSubscriptionUpdated(ctx context.Context, evt paddl
 		IsPaying:           data.Status == "active",
 	}
 
-	if len(data.Items) > 0 && user.PaddlePlanID == nil || (user.PaddlePlanID != nil && *user.PaddlePlanID != data.Items[0].Price.ID) {
+	if currentPlanID := sub.paddlePlanID(); len(data.Items) > 0 && currentPlanID == nil || (currentPlanID != nil && *currentPlanID != data.Items[0].Price.ID) {
 		updates.PaddlePlanID = &data.Items[0].Price.ID
 	}
 
@@ -264,7 +354,7 @@ func (s *paddleService) handleSubscriptionUpdated(ctx context.Context, evt paddl
 		}
 	}
 
-	return s.userRepo.Update(ctx, user.ID, &updates)
+	return s.updateSubscriber(ctx, sub, &updates)
 }
 
 func (s *paddleService) handleSubscriptionCanceled(ctx context.Context, evt paddle.SubscriptionCanceledEvent) error {
@@ -272,12 +362,12 @@ func (s *paddleService) handleSubscriptionCanceled(ctx context.Context, evt padd
 
 	log.Printf("Handling SubscriptionCanceled: PaddleSubscriptionID=%s", data.ID)
 
-	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, data.ID)
+	sub, err := s.findSubscriber(ctx, data.ID)
 	if err != nil {
-		return fmt.Errorf("error finding user by PaddleSubscriptionID %s: %w", data.ID, err)
+		return fmt.Errorf("error finding subscriber by PaddleSubscriptionID %s: %w", data.ID, err)
 	}
-	if user == nil {
-		return fmt.Errorf("user not found for PaddleSubscriptionID %s", data.ID)
+	if sub == nil {
+		return fmt.Errorf("no user or workspace found for PaddleSubscriptionID %s", data.ID)
 	}
 
 	updates := domain.User{
@@ -297,7 +387,7 @@ func (s *paddleService) handleSubscriptionCanceled(ctx context.Context, evt padd
 	// Paddle's `ends_at` on the subscription object (if available in this event data) or `scheduled_change.effective_at`
 	// would indicate when access truly ends. For now, we rely on the status.
 
-	return s.userRepo.Update(ctx, user.ID, &updates)
+	return s.updateSubscriber(ctx, sub, &updates)
 }
 
 func (s *paddleService) handleSubscriptionActivated(ctx context.Context, evt paddle.SubscriptionActivatedEvent) error {
@@ -305,13 +395,12 @@ func (s *paddleService) handleSubscriptionActivated(ctx context.Context, evt pad
 
 	log.Printf("Handling SubscriptionActivated: PaddleSubscriptionID=%s", data.ID)
 
-	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, data.ID)
+	sub, err := s.findSubscriber(ctx, data.ID)
 	if err != nil {
-		return fmt.Errorf("error finding user by PaddleSubscriptionID %s: %w", data.ID, err)
+		return fmt.Errorf("error finding subscriber by PaddleSubscriptionID %s: %w", data.ID, err)
 	}
-	if user == nil {
-		log.Printf("User not found for PaddleSubscriptionID %s during SubscriptionActivated. This might be an issue.", data.ID)
-		return fmt.Errorf("user not found for PaddleSubscriptionID %s", data.ID)
+	if sub == nil {
+		return fmt.Errorf("no user or workspace found for PaddleSubscriptionID %s", data.ID)
 	}
 
 	updates := domain.User{
@@ -338,7 +427,7 @@ func (s *paddleService) handleSubscriptionActivated(ctx context.Context, evt pad
 		}
 	}
 
-	return s.userRepo.Update(ctx, user.ID, &updates)
+	return s.updateSubscriber(ctx, sub, &updates)
 }
 
 func (s *paddleService) handleSubscriptionPaused(ctx context.Context, evt paddle.SubscriptionPausedEvent) error {
@@ -346,12 +435,12 @@ func (s *paddleService) handleSubscriptionPaused(ctx context.Context, evt paddle
 
 	log.Printf("Handling SubscriptionPaused: PaddleSubscriptionID=%s", data.ID)
 
-	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, data.ID)
+	sub, err := s.findSubscriber(ctx, data.ID)
 	if err != nil {
-		return fmt.Errorf("error finding user by PaddleSubscriptionID %s: %w", data.ID, err)
+		return fmt.Errorf("error finding subscriber by PaddleSubscriptionID %s: %w", data.ID, err)
 	}
-	if user == nil {
-		return fmt.Errorf("user not found for PaddleSubscriptionID %s", data.ID)
+	if sub == nil {
+		return fmt.Errorf("no user or workspace found for PaddleSubscriptionID %s", data.ID)
 	}
 
 	updates := domain.User{
@@ -369,7 +458,7 @@ func (s *paddleService) handleSubscriptionPaused(ctx context.Context, evt paddle
 		}
 	}
 
-	return s.userRepo.Update(ctx, user.ID, &updates)
+	return s.updateSubscriber(ctx, sub, &updates)
 }
 
 func (s *paddleService) handleSubscriptionResumed(ctx context.Context, evt paddle.SubscriptionResumedEvent) error {
@@ -377,12 +466,12 @@ func (s *paddleService) handleSubscriptionResumed(ctx context.Context, evt paddl
 
 	log.Printf("Handling SubscriptionResumed: PaddleSubscriptionID=%s", data.ID)
 
-	user, err := s.userRepo.FindByPaddleSubscriptionID(ctx, data.ID)
+	sub, err := s.findSubscriber(ctx, data.ID)
 	if err != nil {
-		return fmt.Errorf("error finding user by PaddleSubscriptionID %s: %w", data.ID, err)
+		return fmt.Errorf("error finding subscriber by PaddleSubscriptionID %s: %w", data.ID, err)
 	}
-	if user == nil {
-		return fmt.Errorf("user not found for PaddleSubscriptionID %s", data.ID)
+	if sub == nil {
+		return fmt.Errorf("no user or workspace found for PaddleSubscriptionID %s", data.ID)
 	}
 
 	updates := domain.User{
@@ -404,7 +493,7 @@ func (s *paddleService) handleSubscriptionResumed(ctx context.Context, evt paddl
 		}
 	}
 
-	return s.userRepo.Update(ctx, user.ID, &updates)
+	return s.updateSubscriber(ctx, sub, &updates)
 }
 
 func (s *paddleService) mapPaddlePlanToInternalPlan(paddlePlanID *string) domain.UserPlan {
@@ -25,56 +25,50 @@ type RedditValidationService interface {
 type redditValidationService struct {
 	validationRepo repository.RedditValidationRepository
 	ideaRepo       repository.IdeaRepository
-	userRepo       repository.UserRepository
 	redditClient   *reddit.RedditClient
 	analyzer       *ValidationAnalyzer
+	access         IdeaAuthorizer
 	sampleID       uuid.UUID
 }
 
 func NewRedditValidationService(
 	validationRepo repository.RedditValidationRepository,
 	ideaRepo repository.IdeaRepository,
-	userRepo repository.UserRepository,
 	redditClient *reddit.RedditClient,
 	analyzer *ValidationAnalyzer,
+	access IdeaAuthorizer,
 	sampleID uuid.UUID,
 ) RedditValidationService {
 	return &redditValidationService{
 		validationRepo: validationRepo,
 		ideaRepo:       ideaRepo,
-		userRepo:       userRepo,
 		redditClient:   redditClient,
 		analyzer:       analyzer,
+		access:         access,
 		sampleID:       sampleID,
 	}
 }
 
 func (s *redditValidationService) GenerateValidation(ctx context.Context, userID string, ideaId uuid.UUID) (uuid.UUID, error) {
-	user, err := s.userRepo.FindByID(ctx, userID)
+	idea, err := s.access.Authorize(ctx, userID, ideaId, domain.IdeaRoleEditor)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to find user: %w", err)
+		return uuid.Nil, err
 	}
-	if user == nil {
-		return uuid.Nil, fmt.Errorf("user not found")
+
+	// collaborators use the plan of the owner or the workspace
+	billing, err := s.access.BillingFor(ctx, idea)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
-	if user.Plan == domain.StarterPlan {
+	if billing.Plan == domain.StarterPlan {
 		return uuid.Nil, fmt.Errorf("reddit validation generation is not available on the Starter plan")
 	}
 
-	if !user.IsPaying {
+	if !billing.IsPaying {
 		return uuid.Nil, fmt.Errorf("reddit validation generation is only available for paying users")
 	}
 
-	// Verify idea ownership
-	idea, _, err := s.ideaRepo.GetByID(ctx, ideaId, nil, nil)
-	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to get idea: %w", err)
-	}
-	if idea.UserID != userID {
-		return uuid.Nil, fmt.Errorf("unauthorized access to idea")
-	}
-
 	// Check if validation already exists
 	existingValidation, err := s.validationRepo.GetByIdeaID(ctx, ideaId)
 	if err == nil && existingValidation != nil {
@@ -85,7 +79,7 @@ func (s *redditValidationService) GenerateValidation(ctx context.Context, userID
 	validation := &domain.RedditValidation{
 		IdeaID:    ideaId,
 		IdeaTitle: idea.Title,
-		UserID:    userID,
+		UserID:    idea.UserID,
 		Status:    domain.ValidationStatusProcessing,
 	}
 
@@ -119,7 +113,7 @@ func (s *redditValidationService) ProcessValidationAsync(ctx context.Context, va
 	}
 
 	// Get idea details
-	idea, _, err := s.ideaRepo.GetByID(ctx, validation.IdeaID, nil, nil)
+	idea, err := s.ideaRepo.GetByID(ctx, validation.IdeaID, nil)
 	if err != nil {
 		s.updateValidationError(validation, fmt.Sprintf("Failed to get idea: %v", err))
 		return
@@ -229,6 +223,7 @@ func (s *redditValidationService) GetValidationsForUser(ctx context.Context, use
 	return &response.RedditValidationListResponse{
 		Validations: responses,
 		Total:       total,
+		NextCursor:  repository.NextValidationCursor(queryParams, validations),
 	}, nil
 }
 
@@ -23,7 +23,7 @@ import (
 type ReportService interface {
 	GenerateReports(ctx context.Context, userId string, req request.GenerateReportRequest) error
 	GetReportsList(ctx context.Context, userID string, queryParams domain.QueryParams, specs ReportSpecs) (*response.ReportListResponse, error)
-	GetByID(ctx context.Context, reportId uuid.UUID) (*response.ReportPageResponse, error)
+	GetByID(ctx context.Context, userId string, reportId uuid.UUID) (*response.ReportPageResponse, error)
 	// GetReportsForIdea(ctx context.Context, ideaID uuid.UUID) ([]domain.Report, error) // to be implemented later
 
 	// SubmitContentReport sends a report, either for an idea or a comment, to the system for review.
@@ -43,6 +43,7 @@ type reportService struct {
 	activityRepo repository.ActivityRepository
 	analytics    AnalyticsService
 	broadcaster  websocket.ActivityBroadcaster
+	access       IdeaAuthorizer
 
 	ReportConfig ReportServiceConfig
 }
@@ -79,7 +80,7 @@ type ReportServiceConfig struct {
 
 func NewReportService(reportRepo repository.ReportRepository, ideaRepository repository.IdeaRepository, feedbackRepo repository.FeedbackRepository,
 	activityRepo repository.ActivityRepository, analyticsService AnalyticsService, broadcaster websocket.ActivityBroadcaster,
-	cfg ReportServiceConfig) *reportService {
+	access IdeaAuthorizer, cfg ReportServiceConfig) *reportService {
 	return &reportService{
 		repo:         reportRepo,
 		ideaRepo:     ideaRepository,
@@ -87,6 +88,7 @@ func NewReportService(reportRepo repository.ReportRepository, ideaRepository rep
 		activityRepo: activityRepo,
 		analytics:    analyticsService,
 		broadcaster:  broadcaster,
+		access:       access,
 		ReportConfig: cfg,
 	}
 }
@@ -95,9 +97,9 @@ func (s *reportService) GenerateReports(ctx context.Context, userId string, req
 	var ideas []*domain.Idea
 
 	if req.IdeaID != uuid.Nil {
-		idea, _, err := s.ideaRepo.GetByID(ctx, req.IdeaID, nil, nil)
+		idea, err := s.access.Authorize(ctx, userId, req.IdeaID, domain.IdeaRoleEditor)
 		if err != nil {
-			return fmt.Errorf("failed to get idea: %w", err)
+			return err
 		}
 		ideas = append(ideas, idea)
 	} else if req.IdeaStatus != "" {
@@ -136,9 +138,10 @@ func (s *reportService) GetReportsList(ctx context.Context, userID string, query
 	}
 
 	res := dto.ToReportListResponse(userReports)
+	res.NextCursor = repository.NextReportCursor(queryParams, userReports)
 
 	if specs.WithStats {
-		reports, _, err := s.repo.GetAll(ctx, userID)
+		reports, _, err := s.repo.GetAll(ctx, userID, queryParams.WorkspaceID)
 		if err != nil {
 			fmt.Println("Error fetching all reports:", err)
 			return nil, fmt.Errorf("failed to fetch reports: %w", err)
@@ -175,12 +178,16 @@ func (s *reportService) GetReportsList(ctx context.Context, userID string, query
 	return &res, nil
 }
 
-func (s *reportService) GetByID(ctx context.Context, reportId uuid.UUID) (*response.ReportPageResponse, error) {
+func (s *reportService) GetByID(ctx context.Context, userId string, reportId uuid.UUID) (*response.ReportPageResponse, error) {
 	report, err := s.repo.GetByID(ctx, reportId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get report: %w", err)
 	}
 
+	if err := s.access.AuthorizeIdea(ctx, userId, &report.Idea, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
 	thresholds := s.getValidationThresholds(report, report.Idea.TargetSignups)
 	res := dto.ToReportPageResponse(report, thresholds)
 
@@ -298,7 +305,7 @@ func (s *reportService) SubmitContentReport(ctx context.Context, reporterId stri
 
 	switch req.ContentType {
 	case "idea":
-		idea, _, err = s.ideaRepo.GetByID(ctx, contentId, nil, nil)
+		idea, err = s.ideaRepo.GetByID(ctx, contentId, nil)
 		if err != nil {
 			return fmt.Errorf("failed to get idea: %w", err)
 		}
@@ -309,7 +316,7 @@ func (s *reportService) SubmitContentReport(ctx context.Context, reporterId stri
 			return fmt.Errorf("failed to get feedback: %w", err)
 		}
 		if feedback != nil {
-			idea, _, err = s.ideaRepo.GetByID(ctx, feedback.IdeaID, nil, nil)
+			idea, err = s.ideaRepo.GetByID(ctx, feedback.IdeaID, nil)
 			if err != nil {
 				return fmt.Errorf("failed to get idea from feedback: %w", err)
 			}
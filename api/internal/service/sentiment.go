@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/pkg/prompts"
+	"foundersignal/internal/pkg/sentiment"
+	"log"
+	"strings"
+)
+
+// maxSentimentTextLength caps the characters of a comment sent to the AI,
+// the start of a long comment carries its tone
+const maxSentimentTextLength = 2000
+
+// Sentiment is the score of a text from 0, negative, to 1, positive, 0.5
+// being neutral, and the analyzer it comes from.
+type Sentiment struct {
+	Score  float64
+	Source string
+}
+
+type SentimentAnalyzer interface {
+	// Analyze scores each text, the sentiments come back in the order of the texts
+	Analyze(ctx context.Context, texts []string) ([]Sentiment, error)
+}
+
+type aiSentimentAnalyzer struct {
+	aiService AIService
+	fallback  SentimentAnalyzer
+}
+
+// NewSentimentAnalyzer scores with the AI and falls back to the lexicon when
+// the AI fails or returns something that cannot be used.
+func NewSentimentAnalyzer(aiService AIService) SentimentAnalyzer {
+	return &aiSentimentAnalyzer{
+		aiService: aiService,
+		fallback:  NewLexiconSentimentAnalyzer(),
+	}
+}
+
+func (a *aiSentimentAnalyzer) Analyze(ctx context.Context, texts []string) ([]Sentiment, error) {
+	if len(texts) == 0 {
+		return []Sentiment{}, nil
+	}
+
+	scores, err := a.score(ctx, texts)
+	if err != nil {
+		log.Printf("WARN: Failed to score sentiment with AI, using the lexicon: %v", err)
+		return a.fallback.Analyze(ctx, texts)
+	}
+
+	sentiments := make([]Sentiment, len(scores))
+	for i, score := range scores {
+		sentiments[i] = Sentiment{Score: min(max(score, 0), 1), Source: domain.SentimentSourceAI}
+	}
+	return sentiments, nil
+}
+
+func (a *aiSentimentAnalyzer) score(ctx context.Context, texts []string) ([]float64, error) {
+	comments := make([]string, len(texts))
+	for i, text := range texts {
+		if runes := []rune(text); len(runes) > maxSentimentTextLength {
+			text = string(runes[:maxSentimentTextLength])
+		}
+		comments[i] = text
+	}
+
+	aiResponse, err := a.aiService.Generate(ctx, prompts.BuildSentimentPrompt(comments))
+	if err != nil {
+		return nil, err
+	}
+
+	cleanedResponse := strings.TrimSpace(aiResponse)
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
+	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
+
+	var result struct {
+		Scores []float64 `json:"scores"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleanedResponse)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse AI response: %w", err)
+	}
+	if len(result.Scores) != len(texts) {
+		return nil, fmt.Errorf("expected %d scores, got %d", len(texts), len(result.Scores))
+	}
+
+	return result.Scores, nil
+}
+
+type lexiconSentimentAnalyzer struct{}
+
+// NewLexiconSentimentAnalyzer scores with a word list, the same text always
+// gets the same score
+func NewLexiconSentimentAnalyzer() SentimentAnalyzer {
+	return lexiconSentimentAnalyzer{}
+}
+
+func (lexiconSentimentAnalyzer) Analyze(_ context.Context, texts []string) ([]Sentiment, error) {
+	sentiments := make([]Sentiment, len(texts))
+	for i, text := range texts {
+		sentiments[i] = Sentiment{Score: sentiment.Score(text), Source: domain.SentimentSourceLexicon}
+	}
+	return sentiments, nil
+}
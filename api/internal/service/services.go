@@ -2,6 +2,7 @@ package service
 
 import (
 	"foundersignal/internal/pkg/cloudflare"
+	"foundersignal/internal/pkg/mailer"
 	"foundersignal/internal/pkg/reddit"
 	"foundersignal/internal/repository"
 	"foundersignal/internal/websocket"
@@ -20,6 +21,20 @@ type Services struct {
 	Paddle    PaddleService
 	AI        AIService
 	Reddit    RedditValidationService
+	Waitlist  WaitlistService
+	Survey    SurveyService
+	Search    IdeaSearchService
+	Category  CategoryService
+	Trending  TrendingService
+
+	Collaborator CollaboratorService
+	Workspace    WorkspaceService
+	Lifecycle    IdeaLifecycleService
+	Follow       FollowService
+	Profile      FounderProfileService
+	Image        IdeaImageService
+	Share        IdeaShareService
+	Comparison   IdeaComparisonService
 
 	// Broadcaster for WebSocket events
 	Broadcaster websocket.ActivityBroadcaster
@@ -30,24 +45,57 @@ type ServicesConfig struct {
 	Paddle                   PaddleServiceConfig
 	Report                   ReportServiceConfig
 	Idea                     IdeaServiceConfig
+	Waitlist                 WaitlistConfig
+	IdeaSearch               IdeaSearchConfig
+	Trending                 TrendingConfig
+	Collaborator             CollaboratorConfig
+	Lifecycle                IdeaLifecycleConfig
+	Follow                   FollowConfig
+	Share                    IdeaShareConfig
 	CloudflareR2             cloudflare.R2Config
+	Mailer                   mailer.Config
 	SampleRedditValidationID uuid.UUID // ID for the sample Reddit validation
 }
 
 func NewServices(repos *repository.Repositories, broadcaster websocket.ActivityBroadcaster, aiService AIService, redditClient *reddit.RedditClient, cfg ServicesConfig) *Services {
 	analyticsService := NewAnalyticsService(repos.Idea, repos.Signal, repos.Audience, repos.Feedback, repos.Report)
 	r2Client := cloudflare.NewR2Bucket(cfg.CloudflareR2)
+	mailClient := mailer.NewMailer(cfg.Mailer)
+	ideaAuthorizer := NewIdeaAuthorizer(repos.Idea, repos.Member, repos.Workspace, repos.User)
+	ideaShareService := NewIdeaShareService(repos.Share, ideaAuthorizer, cfg.Share)
+	shareCardService := NewShareCardService(repos.Idea, repos.MVP, repos.Audience, r2Client)
+	waitlistService := NewWaitlistService(repos.Audience, repos.Idea, ideaAuthorizer, ideaShareService, shareCardService, cfg.Waitlist)
+	surveyService := NewSurveyService(repos.Survey, repos.Idea, repos.MVP, repos.Audience, aiService, ideaAuthorizer)
+	ideaStatusMachine := NewIdeaStatusMachine(repos.Idea, repos.MVP, repos.Activity, ideaAuthorizer)
+	ideaSearchService := NewIdeaSearchService(repos.Embedding, repos.Idea, aiService, cfg.IdeaSearch)
+	categoryService := NewCategoryService(repos.Category)
+	ideaImageService := NewIdeaImageService(repos.Idea, repos.Revision, r2Client, ideaAuthorizer)
 
 	return &Services{
-		User:        NewUserService(repos.User, repos.Idea),
-		Paddle:      NewPaddleService(repos.User, repos.Paddle, cfg.Paddle),
-		Idea:        NewIdeasService(repos.Idea, repos.MVP, repos.User, repos.Signal, repos.Audience, aiService, cfg.Idea),
-		Feedback:    NewFeedbackService(repos.Feedback, repos.Idea, broadcaster),
-		Reaction:    NewReactionService(repos.Reaction),
-		MVP:         NewMVPService(repos.MVP, repos.Idea, repos.User, aiService, r2Client, broadcaster, cfg.MVP),
-		Report:      NewReportService(repos.Report, repos.Idea, repos.Feedback, repos.Activity, analyticsService, broadcaster, cfg.Report),
-		Dashboard:   NewDashboardService(repos.Idea, repos.MVP, repos.Feedback, repos.Signal, repos.Audience, repos.Reaction, repos.Activity),
-		Reddit:      NewRedditValidationService(repos.Reddit, repos.Idea, repos.User, redditClient, NewValidationAnalyzer(aiService), cfg.SampleRedditValidationID),
+		User:      NewUserService(repos.User, repos.Idea),
+		Paddle:    NewPaddleService(repos.User, repos.Workspace, repos.Paddle, cfg.Paddle),
+		Idea:      NewIdeasService(repos.Idea, repos.MVP, repos.User, repos.Signal, repos.Audience, repos.Revision, repos.Report, aiService, shareCardService, waitlistService, surveyService, ideaSearchService, categoryService, ideaImageService, ideaShareService, ideaStatusMachine, ideaAuthorizer, broadcaster, cfg.Idea),
+		Feedback:  NewFeedbackService(repos.Feedback, repos.Idea, broadcaster, ideaShareService, ideaAuthorizer, NewSentimentAnalyzer(aiService)),
+		Reaction:  NewReactionService(repos.Reaction),
+		MVP:       NewMVPService(repos.MVP, repos.Idea, repos.Signal, repos.Audience, repos.Locale, aiService, shareCardService, r2Client, broadcaster, ideaShareService, ideaAuthorizer, cfg.MVP),
+		Report:    NewReportService(repos.Report, repos.Idea, repos.Feedback, repos.Activity, analyticsService, broadcaster, ideaAuthorizer, cfg.Report),
+		Dashboard: NewDashboardService(repos.Idea, repos.MVP, repos.Feedback, repos.Signal, repos.Audience, repos.Reaction, repos.Activity, repos.Revision, ideaAuthorizer),
+		Reddit:    NewRedditValidationService(repos.Reddit, repos.Idea, redditClient, NewValidationAnalyzer(aiService), ideaAuthorizer, cfg.SampleRedditValidationID),
+		Waitlist:  waitlistService,
+		Survey:    surveyService,
+		Search:    ideaSearchService,
+		Category:  categoryService,
+		Trending:  NewTrendingService(repos.Idea, cfg.Trending),
+
+		Collaborator: NewCollaboratorService(repos.Member, repos.User, ideaAuthorizer, mailClient, cfg.Collaborator),
+		Workspace:    NewWorkspaceService(repos.Workspace, repos.Idea, repos.User, ideaAuthorizer),
+		Follow:       NewFollowService(repos.Follow, repos.Update, repos.Idea, repos.User, repos.Activity, broadcaster, mailClient, ideaShareService, ideaAuthorizer, cfg.Follow),
+		Profile:      NewFounderProfileService(repos.Profile, repos.User, repos.Idea),
+		Image:        ideaImageService,
+		Share:        ideaShareService,
+		Comparison:   NewIdeaComparisonService(analyticsService, repos.Signal, repos.Audience, repos.Reddit, repos.Revision, ideaAuthorizer),
+		Lifecycle:    NewIdeaLifecycleService(repos.Lifecycle, repos.Idea, repos.Revision, repos.Activity, broadcaster, ideaStatusMachine, ideaAuthorizer, cfg.Lifecycle),
+
 		Broadcaster: broadcaster,
 		AI:          aiService,
 	}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/pkg/cloudflare"
+	"foundersignal/internal/pkg/sharecard"
+	"foundersignal/internal/repository"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	shareCardBrand = "FounderSignal"
+
+	// signups are batched so that a busy waitlist does not render a card per signup
+	shareCardRefreshDelay = 5 * time.Minute
+)
+
+// ShareCardService renders the Open Graph images of ideas and their landing pages.
+type ShareCardService interface {
+	RefreshIdeaCards(ctx context.Context, ideaId uuid.UUID) error
+	// ScheduleRefresh refreshes the idea cards after shareCardRefreshDelay,
+	// the calls made in the meantime are merged into that refresh.
+	ScheduleRefresh(ideaId uuid.UUID)
+	GenerateMVPCard(ctx context.Context, idea *domain.Idea, mvp *domain.MVPSimulator) (string, error)
+}
+
+type shareCardService struct {
+	ideaRepo     repository.IdeaRepository
+	mvpRepo      repository.MVPRepository
+	audienceRepo repository.AudienceRepository
+	r2Client     cloudflare.R2Bucket
+
+	pendingMu sync.Mutex
+	pending   map[uuid.UUID]bool
+}
+
+func NewShareCardService(ideaRepo repository.IdeaRepository, mvpRepo repository.MVPRepository, audienceRepo repository.AudienceRepository, r2Client cloudflare.R2Bucket) *shareCardService {
+	return &shareCardService{
+		ideaRepo:     ideaRepo,
+		mvpRepo:      mvpRepo,
+		audienceRepo: audienceRepo,
+		r2Client:     r2Client,
+		pending:      make(map[uuid.UUID]bool),
+	}
+}
+
+func (s *shareCardService) ScheduleRefresh(ideaId uuid.UUID) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	if s.pending[ideaId] {
+		return
+	}
+	s.pending[ideaId] = true
+
+	time.AfterFunc(shareCardRefreshDelay, func() {
+		s.pendingMu.Lock()
+		delete(s.pending, ideaId)
+		s.pendingMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.RefreshIdeaCards(ctx, ideaId); err != nil {
+			log.Printf("WARN: Failed to refresh share cards for idea %s: %v", ideaId, err)
+		}
+	})
+}
+
+// RefreshIdeaCards regenerates the idea card and the cards of its published landing pages.
+func (s *shareCardService) RefreshIdeaCards(ctx context.Context, ideaId uuid.UUID) error {
+	idea, err := s.ideaRepo.GetByID(ctx, ideaId, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get idea: %w", err)
+	}
+	if idea == nil {
+		return fmt.Errorf("idea not found")
+	}
+
+	signups, err := s.audienceRepo.GetCountByIdeaId(ctx, ideaId, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to count signups: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/share/idea.png", ideaId.String())
+	shareUrl, err := s.render(ctx, key, sharecard.Card{
+		Title:   idea.Title,
+		Tagline: idea.Description,
+		Signups: int(signups),
+		Brand:   shareCardBrand,
+	})
+	if err != nil {
+		return err
+	}
+
+	if idea.ShareImageURL != shareUrl {
+		if err := s.ideaRepo.Update(ctx, &domain.Idea{Base: domain.Base{ID: ideaId}, ShareImageURL: shareUrl}); err != nil {
+			return fmt.Errorf("failed to save share image: %w", err)
+		}
+	}
+
+	mvps, err := s.mvpRepo.GetAllByIdea(ctx, ideaId)
+	if err != nil {
+		return fmt.Errorf("failed to get MVPs: %w", err)
+	}
+
+	for i := range mvps {
+		mvp := &mvps[i]
+		if mvp.HTMLURL == "" {
+			continue
+		}
+
+		mvpShareUrl, err := s.GenerateMVPCard(ctx, idea, mvp)
+		if err != nil {
+			fmt.Printf("WARNING: failed to refresh share card for MVP %s: %v\n", mvp.ID, err)
+			continue
+		}
+
+		if mvp.ShareImageURL != mvpShareUrl {
+			mvp.ShareImageURL = mvpShareUrl
+			if err := s.mvpRepo.Update(ctx, mvp); err != nil {
+				fmt.Printf("WARNING: failed to save share card for MVP %s: %v\n", mvp.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GenerateMVPCard renders the card of a single landing page and returns its URL.
+// The URL is stable per MVP so already published pages keep pointing to the latest card.
+func (s *shareCardService) GenerateMVPCard(ctx context.Context, idea *domain.Idea, mvp *domain.MVPSimulator) (string, error) {
+	signups, err := s.audienceRepo.GetCountByMVPId(ctx, mvp.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to count signups: %w", err)
+	}
+
+	key := fmt.Sprintf("%s/share/mvp-%s.png", idea.ID.String(), mvp.ID.String())
+	return s.render(ctx, key, sharecard.Card{
+		Title:   idea.Title,
+		Tagline: idea.Description,
+		Signups: int(signups),
+		Label:   mvp.Name,
+		Brand:   shareCardBrand,
+	})
+}
+
+func (s *shareCardService) render(ctx context.Context, key string, card sharecard.Card) (string, error) {
+	png, err := sharecard.Render(card)
+	if err != nil {
+		return "", fmt.Errorf("failed to render share card: %w", err)
+	}
+
+	url, err := s.r2Client.Upload(ctx, key, "image/png", png)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload share card: %w", err)
+	}
+
+	return url, nil
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/pkg/prompts"
+	"foundersignal/internal/pkg/validation"
+	"foundersignal/internal/repository"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxSurveyTextLength    = 500
+	minAnswersForThemes    = 3
+	maxAnswersForThemes    = 200
+	surveyRecentAnswers    = 10
+	surveyThemesAITimeout  = 30 * time.Second
+	surveyThemeMaxExamples = 3
+	maxCachedSurveyThemes  = 512
+)
+
+var ErrSurveyNotEnabled = errors.New("the survey of this MVP is not enabled")
+
+type SurveyService interface {
+	RecordResponse(ctx context.Context, ideaId, mvpId uuid.UUID, userId, fingerprint string, metadata map[string]interface{}) error
+	GetResults(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) (*response.SurveyResults, error)
+}
+
+type surveyService struct {
+	repo         repository.SurveyRepository
+	ideaRepo     repository.IdeaRepository
+	mvpRepo      repository.MVPRepository
+	audienceRepo repository.AudienceRepository
+	aiService    AIService
+	access       IdeaAuthorizer
+
+	// themes are clustered in the background and kept until a new answer comes in
+	themesMu      sync.Mutex
+	themesCache   map[string]surveyThemes
+	themesPending map[string]bool
+}
+
+type surveyThemes struct {
+	answers int
+	themes  []response.SurveyTheme
+}
+
+func NewSurveyService(repo repository.SurveyRepository, ideaRepo repository.IdeaRepository, mvpRepo repository.MVPRepository,
+	audienceRepo repository.AudienceRepository, aiService AIService, access IdeaAuthorizer) *surveyService {
+	return &surveyService{
+		repo:          repo,
+		ideaRepo:      ideaRepo,
+		mvpRepo:       mvpRepo,
+		audienceRepo:  audienceRepo,
+		aiService:     aiService,
+		access:        access,
+		themesCache:   make(map[string]surveyThemes),
+		themesPending: make(map[string]bool),
+	}
+}
+
+// surveyFor decodes the survey configured on an MVP, nil when there is none.
+func surveyFor(mvp *domain.MVPSimulator) *validation.Survey {
+	if len(mvp.Survey) == 0 {
+		return nil
+	}
+
+	var survey validation.Survey
+	if err := json.Unmarshal(mvp.Survey, &survey); err != nil {
+		fmt.Printf("WARNING: failed to decode survey of MVP %s: %v\n", mvp.ID, err)
+		return nil
+	}
+	return &survey
+}
+
+// RecordResponse stores the answers reported by the tracking script. The
+// respondent is the audience member created by the preceding CTA click.
+func (s *surveyService) RecordResponse(ctx context.Context, ideaId, mvpId uuid.UUID, userId, fingerprint string, metadata map[string]interface{}) error {
+	mvp, err := s.mvpRepo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return gorm.ErrRecordNotFound
+	}
+
+	survey := surveyFor(mvp)
+	if survey == nil || !survey.Enabled {
+		return ErrSurveyNotEnabled
+	}
+
+	var member *domain.AudienceMember
+	switch {
+	case userId != "":
+		member, err = s.audienceRepo.GetMember(ctx, mvpId, userId)
+	case fingerprint != "":
+		member, err = s.audienceRepo.GetLatestByFingerprint(ctx, mvpId, fingerprint)
+	default:
+		err = gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find the audience member of the survey response: %w", err)
+	}
+
+	var submitted struct {
+		Answers []struct {
+			QuestionID string `json:"questionId"`
+			Choice     string `json:"choice"`
+			Text       string `json:"text"`
+		} `json:"answers"`
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal survey response: %w", err)
+	}
+	if err := json.Unmarshal(raw, &submitted); err != nil {
+		return fmt.Errorf("failed to parse survey response: %w", err)
+	}
+
+	answers := make([]domain.SurveyAnswer, 0, len(submitted.Answers))
+	seen := make(map[string]bool)
+	for _, a := range submitted.Answers {
+		question, ok := survey.Question(a.QuestionID)
+		if !ok || seen[question.ID] {
+			continue
+		}
+
+		answer := domain.SurveyAnswer{
+			IdeaID:         ideaId,
+			MVPSimulatorID: mvpId,
+			AudienceUserID: member.UserID,
+			QuestionID:     question.ID,
+		}
+
+		switch question.Type {
+		case validation.SurveyQuestionChoice:
+			if !question.HasOption(a.Choice) {
+				continue
+			}
+			answer.Choice = a.Choice
+		case validation.SurveyQuestionText:
+			text := strings.TrimSpace(a.Text)
+			if text == "" {
+				continue
+			}
+			if utf8.RuneCountInString(text) > maxSurveyTextLength {
+				text = string([]rune(text)[:maxSurveyTextLength])
+			}
+			answer.Text = text
+		default:
+			continue
+		}
+
+		seen[question.ID] = true
+		answers = append(answers, answer)
+	}
+
+	if err := s.repo.UpsertAnswers(ctx, answers); err != nil {
+		return fmt.Errorf("failed to save survey answers: %w", err)
+	}
+
+	return nil
+}
+
+// GetResults aggregates the answers per question, choice questions get their
+// distribution and free text answers are clustered into themes.
+func (s *surveyService) GetResults(ctx context.Context, userId string, ideaId, mvpId uuid.UUID) (*response.SurveyResults, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	mvp, err := s.mvpRepo.GetByID(ctx, mvpId)
+	if err != nil || mvp == nil || mvp.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	result := &response.SurveyResults{
+		MVPID:     mvpId,
+		Questions: []response.SurveyQuestionResults{},
+	}
+
+	survey := surveyFor(mvp)
+	if survey == nil {
+		return result, nil
+	}
+	result.Enabled = survey.Enabled
+
+	answers, err := s.repo.GetByMVP(ctx, mvpId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get survey answers: %w", err)
+	}
+
+	signups, err := s.audienceRepo.GetCountByMVPId(ctx, mvpId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signups: %w", err)
+	}
+	result.Signups = signups
+
+	respondents := make(map[string]bool)
+	byQuestion := make(map[string][]domain.SurveyAnswer)
+	for _, answer := range answers {
+		respondents[answer.AudienceUserID] = true
+		byQuestion[answer.QuestionID] = append(byQuestion[answer.QuestionID], answer)
+	}
+	result.Respondents = len(respondents)
+	if signups > 0 {
+		result.ResponseRate = float64(result.Respondents) / float64(signups) * 100
+	}
+
+	for _, question := range survey.Questions {
+		questionAnswers := byQuestion[question.ID]
+		stats := response.SurveyQuestionResults{
+			ID:     question.ID,
+			Prompt: question.Prompt,
+			Type:   string(question.Type),
+		}
+
+		switch question.Type {
+		case validation.SurveyQuestionChoice:
+			counts := make(map[string]int)
+			for _, answer := range questionAnswers {
+				if question.HasOption(answer.Choice) {
+					counts[answer.Choice]++
+					stats.Answers++
+				}
+			}
+			for _, option := range question.Options {
+				choiceStats := response.SurveyChoiceStats{Choice: option, Count: counts[option]}
+				if stats.Answers > 0 {
+					choiceStats.Percentage = float64(counts[option]) / float64(stats.Answers) * 100
+				}
+				stats.Distribution = append(stats.Distribution, choiceStats)
+			}
+		case validation.SurveyQuestionText:
+			texts := make([]string, 0, len(questionAnswers))
+			for _, answer := range questionAnswers {
+				if answer.Text != "" {
+					texts = append(texts, answer.Text)
+				}
+			}
+			stats.Answers = len(texts)
+			stats.RecentAnswers = texts[:min(len(texts), surveyRecentAnswers)]
+
+			if len(texts) >= minAnswersForThemes {
+				stats.Themes, stats.ThemesPending = s.themes(mvpId, question, texts)
+			}
+		}
+
+		result.Questions = append(result.Questions, stats)
+	}
+
+	return result, nil
+}
+
+// themes returns the clustered themes of the answers to a question. When the
+// answers changed since the last clustering the previous themes, if any, are
+// returned as pending while the answers are clustered again in the background.
+func (s *surveyService) themes(mvpId uuid.UUID, question validation.SurveyQuestion, texts []string) ([]response.SurveyTheme, bool) {
+	key := fmt.Sprintf("%s|%s|%s", mvpId, question.ID, question.Prompt)
+
+	s.themesMu.Lock()
+	defer s.themesMu.Unlock()
+
+	cached, ok := s.themesCache[key]
+	if ok && cached.answers == len(texts) {
+		return cached.themes, false
+	}
+
+	if !s.themesPending[key] {
+		s.themesPending[key] = true
+		answers := append([]string(nil), texts[:min(len(texts), maxAnswersForThemes)]...)
+		go s.refreshThemes(key, mvpId, question.Prompt, answers, len(texts))
+	}
+	return cached.themes, true
+}
+
+func (s *surveyService) refreshThemes(key string, mvpId uuid.UUID, prompt string, texts []string, answers int) {
+	themes, err := s.clusterThemes(context.Background(), prompt, texts)
+
+	s.themesMu.Lock()
+	defer s.themesMu.Unlock()
+
+	delete(s.themesPending, key)
+	if err != nil {
+		fmt.Printf("WARNING: failed to cluster survey answers of MVP %s: %v\n", mvpId, err)
+		return
+	}
+
+	if len(s.themesCache) >= maxCachedSurveyThemes {
+		s.themesCache = make(map[string]surveyThemes)
+	}
+	s.themesCache[key] = surveyThemes{answers: answers, themes: themes}
+}
+
+func (s *surveyService) clusterThemes(ctx context.Context, question string, texts []string) ([]response.SurveyTheme, error) {
+	ctx, cancel := context.WithTimeout(ctx, surveyThemesAITimeout)
+	defer cancel()
+
+	textsJSON, err := json.Marshal(texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal answers: %w", err)
+	}
+
+	aiResponse, err := s.aiService.Generate(ctx, prompts.BuildSurveyThemesPrompt(question, string(textsJSON)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate themes: %w", err)
+	}
+
+	cleanedResponse := strings.TrimSpace(aiResponse)
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
+	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
+	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
+
+	var themes []response.SurveyTheme
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleanedResponse)), &themes); err != nil {
+		return nil, fmt.Errorf("failed to parse themes: %w", err)
+	}
+
+	// the model output is not trusted, drop empty themes and cap counts and examples
+	valid := themes[:0]
+	for _, theme := range themes {
+		theme.Theme = strings.TrimSpace(theme.Theme)
+		if theme.Theme == "" || theme.Count <= 0 {
+			continue
+		}
+		theme.Count = min(theme.Count, len(texts))
+		if len(theme.Examples) > surveyThemeMaxExamples {
+			theme.Examples = theme.Examples[:surveyThemeMaxExamples]
+		}
+		if theme.Examples == nil {
+			theme.Examples = []string{}
+		}
+		valid = append(valid, theme)
+	}
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Count > valid[j].Count })
+
+	return valid, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"foundersignal/internal/repository"
+	"log"
+	"time"
+)
+
+type TrendingService interface {
+	RefreshScores(ctx context.Context) error
+	Start(ctx context.Context)
+}
+
+type TrendingConfig struct {
+	RefreshInterval time.Duration
+	HalfLifeHours   float64
+	WindowDays      int // events older than this no longer count
+}
+
+type trendingService struct {
+	ideaRepo repository.IdeaRepository
+	config   TrendingConfig
+}
+
+func NewTrendingService(ideaRepo repository.IdeaRepository, config TrendingConfig) *trendingService {
+	return &trendingService{
+		ideaRepo: ideaRepo,
+		config:   config,
+	}
+}
+
+// RefreshScores recomputes the trending score of the ideas. A signup weighs
+// as much as five views, comments and likes sit in between.
+func (s *trendingService) RefreshScores(ctx context.Context) error {
+	updated, err := s.ideaRepo.RefreshTrendingScores(ctx, repository.TrendingParams{
+		Since:          time.Now().AddDate(0, 0, -s.config.WindowDays),
+		HalfLifeHours:  s.config.HalfLifeHours,
+		ViewWeight:     1,
+		SignupWeight:   5,
+		ReactionWeight: 2,
+		CommentWeight:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh trending scores: %w", err)
+	}
+
+	if updated > 0 {
+		log.Printf("Refreshed trending scores of %d ideas", updated)
+	}
+	return nil
+}
+
+// Start refreshes the scores right away and then on every interval until the context is cancelled.
+func (s *trendingService) Start(ctx context.Context) {
+	if err := s.RefreshScores(ctx); err != nil {
+		log.Printf("WARN: %v", err)
+	}
+
+	if s.config.RefreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RefreshScores(ctx); err != nil {
+				log.Printf("WARN: %v", err)
+			}
+		}
+	}
+}
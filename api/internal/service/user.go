@@ -39,8 +39,12 @@ func (s *userService) FindById(ctx context.Context, userId string) (*domain.User
 		return nil, err
 	}
 
-	status := domain.IdeaStatusActive
-	activeIdeaCount, err := s.ideaRepo.GetCountForUser(ctx, userId, nil, nil, &status)
+	// the ideas of workspaces count towards the limits of their workspace
+	activeIdeaCount, err := s.ideaRepo.GetCount(ctx, repository.IdeaQuerySpec{
+		ByUserId: userId,
+		Personal: true,
+		Status:   domain.IdeaStatusActive,
+	})
 	if err != nil {
 		log.Printf("Error counting active ideas for user %s: %v", userId, err)
 		activeIdeaCount = 0 // Default to 0 if there's an error
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	referralCodeLength   = 8
+	referralCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ" // no 0/O or 1/I to keep codes readable
+
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+type WaitlistService interface {
+	Join(ctx context.Context, ideaId, mvpId uuid.UUID, userId, userEmail, referralCode, fingerprint string) (*response.WaitlistPosition, error)
+	GetPosition(ctx context.Context, ideaId uuid.UUID, userId, shareToken, referralCode string) (*response.WaitlistPosition, error)
+	GetLeaderboard(ctx context.Context, userId string, ideaId uuid.UUID, limit int) (*response.WaitlistLeaderboard, error)
+}
+
+type WaitlistConfig struct {
+	AppUrl string
+	// PositionBoost is the number of spots a member moves up per successful referral
+	PositionBoost int
+}
+
+type waitlistService struct {
+	audienceRepo repository.AudienceRepository
+	ideaRepo     repository.IdeaRepository
+	access       IdeaAuthorizer
+	shares       IdeaShareService
+	shareCards   ShareCardService
+
+	cfg WaitlistConfig
+}
+
+func NewWaitlistService(audienceRepo repository.AudienceRepository, ideaRepo repository.IdeaRepository, access IdeaAuthorizer, shares IdeaShareService, shareCards ShareCardService, cfg WaitlistConfig) *waitlistService {
+	return &waitlistService{
+		audienceRepo: audienceRepo,
+		ideaRepo:     ideaRepo,
+		access:       access,
+		shares:       shares,
+		shareCards:   shareCards,
+		cfg:          cfg,
+	}
+}
+
+// Join adds the visitor to the waitlist of the idea and credits the referrer
+// of the given code when the visitor is signed in, unless the referral turns
+// out to be a self-referral.
+func (s *waitlistService) Join(ctx context.Context, ideaId, mvpId uuid.UUID, userId, userEmail, referralCode, fingerprint string) (*response.WaitlistPosition, error) {
+	code, err := generateReferralCode()
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &domain.AudienceMember{
+		IdeaID:            ideaId,
+		MVPSimulatorID:    mvpId,
+		UserID:            userId,
+		UserEmail:         userEmail,
+		ReferralCode:      code,
+		SignupFingerprint: fingerprint,
+	}
+	candidate.ReferredBy = s.resolveReferrer(ctx, candidate, referralCode)
+
+	member, err := s.audienceRepo.Upsert(ctx, candidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert audience member: %w", err)
+	}
+
+	// the share cards show the signup count
+	s.shareCards.ScheduleRefresh(ideaId)
+
+	// members who signed up before referrals existed get their code on their next visit
+	if member.ReferralCode == "" {
+		if err := s.audienceRepo.SetReferralCode(ctx, mvpId, member.UserID, code); err != nil {
+			return nil, fmt.Errorf("failed to set referral code: %w", err)
+		}
+		member.ReferralCode = code
+	}
+
+	return s.position(ctx, ideaId, member)
+}
+
+// resolveReferrer returns the user id of the referring member, invalid codes
+// and self-referrals are ignored so the signup itself still goes through.
+// Anonymous signups are not credited, the visitor id and the address seen by
+// the API can be changed at will so only an account makes a referral count.
+func (s *waitlistService) resolveReferrer(ctx context.Context, candidate *domain.AudienceMember, referralCode string) *string {
+	referralCode = strings.ToUpper(strings.TrimSpace(referralCode))
+	if referralCode == "" {
+		return nil
+	}
+
+	if !isRealEmail(candidate.UserEmail) {
+		return nil
+	}
+
+	referrer, err := s.audienceRepo.GetByReferralCode(ctx, referralCode)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("WARN: Failed to look up referral code %s: %v", referralCode, err)
+		}
+		return nil
+	}
+
+	if referrer.IdeaID != candidate.IdeaID {
+		return nil
+	}
+
+	if referrer.UserID == candidate.UserID ||
+		strings.EqualFold(referrer.UserEmail, candidate.UserEmail) ||
+		(referrer.SignupFingerprint != "" && referrer.SignupFingerprint == candidate.SignupFingerprint) {
+		log.Printf("WARN: Ignoring self-referral with code %s for idea %s", referralCode, candidate.IdeaID)
+		return nil
+	}
+
+	return &referrer.UserID
+}
+
+// GetPosition is the public lookup of a member by its referral code. Like the
+// idea itself, the waitlist of a private or non-active idea is only shown to
+// its team and the holders of a share link.
+func (s *waitlistService) GetPosition(ctx context.Context, ideaId uuid.UUID, userId, shareToken, referralCode string) (*response.WaitlistPosition, error) {
+	idea, err := s.ideaRepo.Get(ctx, ideaId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.shares.CanView(ctx, idea, userId, shareToken); err != nil {
+		return nil, err
+	}
+
+	member, err := s.audienceRepo.GetByReferralCode(ctx, strings.ToUpper(strings.TrimSpace(referralCode)))
+	if err != nil {
+		return nil, err
+	}
+	if member.IdeaID != ideaId {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.position(ctx, ideaId, member)
+}
+
+func (s *waitlistService) GetLeaderboard(ctx context.Context, userId string, ideaId uuid.UUID, limit int) (*response.WaitlistLeaderboard, error) {
+	if _, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleViewer); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	limit = min(limit, maxLeaderboardLimit)
+
+	entries, err := s.audienceRepo.GetReferralLeaderboard(ctx, ideaId, s.cfg.PositionBoost, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referral leaderboard: %w", err)
+	}
+
+	size, err := s.audienceRepo.GetWaitlistSize(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist size: %w", err)
+	}
+
+	leaderboard := &response.WaitlistLeaderboard{
+		IdeaID:        ideaId,
+		WaitlistSize:  size,
+		PositionBoost: s.cfg.PositionBoost,
+		Entries:       make([]response.WaitlistLeaderboardEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		leaderboard.Entries = append(leaderboard.Entries, response.WaitlistLeaderboardEntry{
+			UserID:        entry.UserID,
+			UserEmail:     entry.UserEmail,
+			ReferralCode:  entry.ReferralCode,
+			ReferralCount: entry.ReferralCount,
+			Position:      entry.Position,
+			SignupTime:    entry.SignupTime.Format(time.RFC3339),
+		})
+	}
+
+	return leaderboard, nil
+}
+
+func (s *waitlistService) position(ctx context.Context, ideaId uuid.UUID, member *domain.AudienceMember) (*response.WaitlistPosition, error) {
+	entry, err := s.audienceRepo.GetWaitlistEntry(ctx, ideaId, member.UserID, s.cfg.PositionBoost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist position: %w", err)
+	}
+
+	size, err := s.audienceRepo.GetWaitlistSize(ctx, ideaId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist size: %w", err)
+	}
+
+	return &response.WaitlistPosition{
+		IdeaID:        ideaId,
+		ReferralCode:  member.ReferralCode,
+		ReferralLink:  fmt.Sprintf("%s/mvp/%s?ref=%s", s.cfg.AppUrl, ideaId.String(), member.ReferralCode),
+		Position:      entry.Position,
+		WaitlistSize:  size,
+		ReferralCount: entry.ReferralCount,
+	}, nil
+}
+
+func generateReferralCode() (string, error) {
+	var sb strings.Builder
+	alphabetSize := big.NewInt(int64(len(referralCodeAlphabet)))
+	for i := 0; i < referralCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		sb.WriteByte(referralCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// signupFingerprint identifies the browser of a member, anonymous members get a new user id
+// on every signup. The visitor id of the tracking script is preferred since signals are
+// usually relayed by the web app and share its address.
+func signupFingerprint(visitorId, ipAddress, userAgent string) string {
+	source := "visitor|" + visitorId
+	if visitorId == "" {
+		if ipAddress == "" && userAgent == "" {
+			return ""
+		}
+		source = "client|" + ipAddress + "|" + userAgent
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+func isRealEmail(email string) bool {
+	return email != "" && email != RegisteredUserPlaceholderEmail && email != AnonymousUserPlaceholderEmail
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"foundersignal/internal/domain"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/dto/response"
+	"foundersignal/internal/repository"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidWorkspaceRole      = errors.New("members can only be admins or members")
+	ErrWorkspaceUserNotFound     = errors.New("no account was found for this email")
+	ErrAlreadyWorkspaceMember    = errors.New("the user is already a member of this workspace")
+	ErrWorkspaceOwnerCannotLeave = errors.New("the owner cannot leave the workspace")
+	ErrWorkspaceHasSubscription  = errors.New("cancel the subscription of the workspace before deleting it")
+)
+
+type WorkspaceService interface {
+	Create(ctx context.Context, userId string, req request.CreateWorkspace) (*response.Workspace, error)
+	GetForUser(ctx context.Context, userId string) ([]response.Workspace, error)
+	GetByID(ctx context.Context, userId string, workspaceId uuid.UUID) (*response.WorkspaceDetails, error)
+	Update(ctx context.Context, userId string, workspaceId uuid.UUID, req request.UpdateWorkspace) error
+	Delete(ctx context.Context, userId string, workspaceId uuid.UUID) error
+
+	AddMember(ctx context.Context, userId string, workspaceId uuid.UUID, req request.AddWorkspaceMember) (*response.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, userId string, workspaceId uuid.UUID, memberId string, req request.UpdateWorkspaceMemberRole) error
+	RemoveMember(ctx context.Context, userId string, workspaceId uuid.UUID, memberId string) error
+
+	MoveIdea(ctx context.Context, userId string, ideaId uuid.UUID, req request.MoveIdea) error
+	// Switch checks that the user can work in the workspace selected in the
+	// workspace switcher of the dashboard.
+	Switch(ctx context.Context, userId string, workspaceId uuid.UUID) error
+}
+
+type workspaceService struct {
+	repo     repository.WorkspaceRepository
+	ideaRepo repository.IdeaRepository
+	userRepo repository.UserRepository
+	access   IdeaAuthorizer
+}
+
+func NewWorkspaceService(repo repository.WorkspaceRepository, ideaRepo repository.IdeaRepository, userRepo repository.UserRepository,
+	access IdeaAuthorizer) *workspaceService {
+	return &workspaceService{
+		repo:     repo,
+		ideaRepo: ideaRepo,
+		userRepo: userRepo,
+		access:   access,
+	}
+}
+
+// Create starts the workspace on the starter plan, it is upgraded by
+// attaching a subscription to it.
+func (s *workspaceService) Create(ctx context.Context, userId string, req request.CreateWorkspace) (*response.Workspace, error) {
+	workspace := &domain.Workspace{
+		Name:    strings.TrimSpace(req.Name),
+		OwnerID: userId,
+		Plan:    domain.StarterPlan,
+	}
+	if err := s.repo.Create(ctx, workspace); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	res := s.toWorkspaceResponse(ctx, workspace, domain.WorkspaceRoleOwner)
+	return &res, nil
+}
+
+func (s *workspaceService) GetForUser(ctx context.Context, userId string) ([]response.Workspace, error) {
+	members, err := s.repo.GetForUser(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+
+	workspaces := make([]response.Workspace, 0, len(members))
+	for _, member := range members {
+		workspaces = append(workspaces, s.toWorkspaceResponse(ctx, &member.Workspace, member.Role))
+	}
+
+	return workspaces, nil
+}
+
+func (s *workspaceService) GetByID(ctx context.Context, userId string, workspaceId uuid.UUID) (*response.WorkspaceDetails, error) {
+	member, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleMember)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.repo.GetByID(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetMembers(ctx, workspaceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace members: %w", err)
+	}
+
+	res := &response.WorkspaceDetails{
+		Workspace: s.toWorkspaceResponse(ctx, workspace, member.Role),
+		Members:   make([]response.WorkspaceMember, 0, len(members)),
+	}
+	// the billing details are only shown to the owner
+	if member.Role == domain.WorkspaceRoleOwner {
+		res.SubscriptionStatus = workspace.SubscriptionStatus
+		res.SubscriptionExpiresAt = workspace.SubscriptionExpiresAt
+	}
+	for _, m := range members {
+		res.Members = append(res.Members, toWorkspaceMember(m))
+	}
+
+	return res, nil
+}
+
+func (s *workspaceService) Update(ctx context.Context, userId string, workspaceId uuid.UUID, req request.UpdateWorkspace) error {
+	if _, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, workspaceId, &domain.Workspace{Name: strings.TrimSpace(req.Name)})
+}
+
+// Delete is done by the owner once the subscription is canceled, the ideas of
+// the workspace go back to their creators.
+func (s *workspaceService) Delete(ctx context.Context, userId string, workspaceId uuid.UUID) error {
+	if _, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+
+	workspace, err := s.repo.GetByID(ctx, workspaceId)
+	if err != nil {
+		return err
+	}
+	if workspace.HasActiveSubscription() {
+		return ErrWorkspaceHasSubscription
+	}
+
+	return s.repo.Delete(ctx, workspaceId)
+}
+
+func (s *workspaceService) AddMember(ctx context.Context, userId string, workspaceId uuid.UUID, req request.AddWorkspaceMember) (*response.WorkspaceMember, error) {
+	role := domain.WorkspaceRole(req.Role)
+	if !role.IsAssignable() {
+		return nil, ErrInvalidWorkspaceRole
+	}
+
+	if _, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrWorkspaceUserNotFound
+	}
+
+	if _, err := s.repo.GetMember(ctx, workspaceId, user.ID); err == nil {
+		return nil, ErrAlreadyWorkspaceMember
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+
+	member := &domain.WorkspaceMember{
+		WorkspaceID: workspaceId,
+		UserID:      user.ID,
+		Role:        role,
+	}
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to add workspace member: %w", err)
+	}
+	member.User = *user
+
+	res := toWorkspaceMember(*member)
+	return &res, nil
+}
+
+func (s *workspaceService) UpdateMemberRole(ctx context.Context, userId string, workspaceId uuid.UUID, memberId string, req request.UpdateWorkspaceMemberRole) error {
+	role := domain.WorkspaceRole(req.Role)
+	if !role.IsAssignable() {
+		return ErrInvalidWorkspaceRole
+	}
+
+	if _, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateMemberRole(ctx, workspaceId, memberId, role)
+}
+
+// RemoveMember is done by an admin, or by a member leaving the workspace. The
+// ideas they created stay in the workspace.
+func (s *workspaceService) RemoveMember(ctx context.Context, userId string, workspaceId uuid.UUID, memberId string) error {
+	required := domain.WorkspaceRoleAdmin
+	if memberId == userId {
+		required = domain.WorkspaceRoleMember
+	}
+
+	member, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, required)
+	if err != nil {
+		return err
+	}
+	if memberId == userId && member.Role == domain.WorkspaceRoleOwner {
+		return ErrWorkspaceOwnerCannotLeave
+	}
+
+	return s.repo.RemoveMember(ctx, workspaceId, memberId)
+}
+
+// MoveIdea moves an idea the user owns into one of their workspaces, or back
+// to their personal ideas. An active idea has to fit in the idea limit of
+// where it is moved to.
+func (s *workspaceService) MoveIdea(ctx context.Context, userId string, ideaId uuid.UUID, req request.MoveIdea) error {
+	idea, err := s.access.Authorize(ctx, userId, ideaId, domain.IdeaRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if req.WorkspaceID != nil {
+		if _, err := s.access.AuthorizeWorkspace(ctx, userId, *req.WorkspaceID, domain.WorkspaceRoleMember); err != nil {
+			return err
+		}
+	}
+
+	if sameWorkspace(idea.WorkspaceID, req.WorkspaceID) {
+		return nil
+	}
+
+	if idea.Status == string(domain.IdeaStatusActive) {
+		billing, err := s.access.Billing(ctx, idea.UserID, req.WorkspaceID)
+		if err != nil {
+			return err
+		}
+
+		spec := billing.IdeaSpec()
+		spec.Status = domain.IdeaStatusActive
+		currentCount, err := s.ideaRepo.GetCount(ctx, spec)
+		if err != nil {
+			return fmt.Errorf("failed to check idea count: %w", err)
+		}
+		if ideaLimit := billing.Limits().IdeaLimit; int(currentCount) >= ideaLimit {
+			return fmt.Errorf("you have reached your idea limit for the %s plan. please upgrade your plan or deactivate an idea to move this one", billing.Plan)
+		}
+	}
+
+	return s.repo.MoveIdea(ctx, ideaId, req.WorkspaceID)
+}
+
+func (s *workspaceService) Switch(ctx context.Context, userId string, workspaceId uuid.UUID) error {
+	_, err := s.access.AuthorizeWorkspace(ctx, userId, workspaceId, domain.WorkspaceRoleMember)
+	return err
+}
+
+func (s *workspaceService) toWorkspaceResponse(ctx context.Context, workspace *domain.Workspace, role domain.WorkspaceRole) response.Workspace {
+	limits := workspace.Limits()
+
+	activeIdeas, err := s.ideaRepo.GetCount(ctx, repository.IdeaQuerySpec{WorkspaceID: &workspace.ID, Status: domain.IdeaStatusActive})
+	if err != nil {
+		log.Printf("WARN: Failed to count active ideas of workspace %s: %v", workspace.ID, err)
+	}
+
+	return response.Workspace{
+		ID:       workspace.ID,
+		Name:     workspace.Name,
+		Role:     string(role),
+		Plan:     string(workspace.Plan),
+		IsPaying: workspace.IsPaying,
+		Usage: response.WorkspaceUsage{
+			ActiveIdeas: activeIdeas,
+			IdeaLimit:   limits.IdeaLimit,
+			MVPLimit:    limits.MVPLimit,
+			AIGenLimit:  limits.AIGenLimit,
+		},
+		CreatedAt: workspace.CreatedAt,
+	}
+}
+
+func toWorkspaceMember(member domain.WorkspaceMember) response.WorkspaceMember {
+	return response.WorkspaceMember{
+		UserID:    member.UserID,
+		Name:      displayName(&member.User),
+		Email:     member.User.Email,
+		ImageURL:  member.User.ImageURL,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+func sameWorkspace(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler interface {
+	GetTree(c *gin.Context)
+}
+
+type categoryHandler struct {
+	service service.CategoryService
+}
+
+func NewCategoryHandler(s service.CategoryService) *categoryHandler {
+	return &categoryHandler{
+		service: s,
+	}
+}
+
+func (h *categoryHandler) GetTree(c *gin.Context) {
+	categories, err := h.service.GetTree(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CollaboratorHandler interface {
+	Invite(c *gin.Context)
+	Accept(c *gin.Context)
+	GetCollaborators(c *gin.Context)
+	UpdateRole(c *gin.Context)
+	Remove(c *gin.Context)
+	RevokeInvitation(c *gin.Context)
+}
+
+type collaboratorHandler struct {
+	service service.CollaboratorService
+}
+
+func NewCollaboratorHandler(s service.CollaboratorService) *collaboratorHandler {
+	return &collaboratorHandler{
+		service: s,
+	}
+}
+
+func (h *collaboratorHandler) Invite(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.InviteCollaborator
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation, err := h.service.Invite(c.Request.Context(), userId.(string), ideaId, req)
+	if err != nil {
+		h.handleError(c, err, "Idea not found")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *collaboratorHandler) Accept(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	accepted, err := h.service.Accept(c.Request.Context(), userId.(string), c.Param("token"))
+	if err != nil {
+		h.handleError(c, err, "Invitation not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, accepted)
+}
+
+func (h *collaboratorHandler) GetCollaborators(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	collaborators, err := h.service.GetCollaborators(c.Request.Context(), userId.(string), ideaId)
+	if err != nil {
+		h.handleError(c, err, "Idea not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborators)
+}
+
+func (h *collaboratorHandler) UpdateRole(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.UpdateCollaboratorRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.UpdateRole(c.Request.Context(), userId.(string), ideaId, c.Param("memberId"), req); err != nil {
+		h.handleError(c, err, "Collaborator not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated successfully"})
+}
+
+func (h *collaboratorHandler) Remove(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	if err := h.service.Remove(c.Request.Context(), userId.(string), ideaId, c.Param("memberId")); err != nil {
+		h.handleError(c, err, "Collaborator not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Collaborator removed successfully"})
+}
+
+func (h *collaboratorHandler) RevokeInvitation(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	invitationId, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	if err := h.service.RevokeInvitation(c.Request.Context(), userId.(string), ideaId, invitationId); err != nil {
+		h.handleError(c, err, "Invitation not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+func (h *collaboratorHandler) handleError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, service.ErrInvalidIdeaRole),
+		errors.Is(err, service.ErrCannotInviteOwner),
+		errors.Is(err, service.ErrInvitationExpired),
+		errors.Is(err, service.ErrInvitationAlreadyUsed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvitationEmailMismatch):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
@@ -1,17 +1,20 @@
 package http
 
 import (
+	"errors"
 	"foundersignal/internal/service"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type DashboardHandler interface {
 	GetDashboardData(c *gin.Context)
 	GetRecentActivity(c *gin.Context)
 	GetIdea(c *gin.Context)
+	GetIdeaHistory(c *gin.Context)
 	GetAudience(c *gin.Context)
 }
 
@@ -38,9 +41,9 @@ func (h *dashboardHandler) GetDashboardData(c *gin.Context) {
 		return
 	}
 
-	data, err := h.service.GetDashboardData(c.Request.Context(), userIDStr)
+	data, err := h.service.GetDashboardData(c.Request.Context(), userIDStr, getWorkspaceId(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -76,7 +79,7 @@ func (h *dashboardHandler) GetIdea(c *gin.Context) {
 
 	data, err := h.service.GetIdea(c.Request.Context(), uuid.MustParse(ideaId), userIDStr, specs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -96,13 +99,18 @@ func (h *dashboardHandler) GetAudience(c *gin.Context) {
 		return
 	}
 
-	queryParams := getProcessedQueryParams(c)
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	queryParams.WorkspaceID = getWorkspaceId(c)
 
 	getStats := c.Query("getStats")
 
 	data, err := h.service.GetAudienceForFounder(c.Request.Context(), userIDStr, getStats == "true", queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -130,3 +138,35 @@ func (h *dashboardHandler) GetRecentActivity(c *gin.Context) {
 
 	c.JSON(http.StatusOK, activities)
 }
+
+func (h *dashboardHandler) GetIdeaHistory(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := h.service.GetIdeaHistory(c.Request.Context(), userId.(string), ideaId, queryParams)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -1,12 +1,14 @@
 package http
 
 import (
+	"errors"
 	"foundersignal/internal/dto/request"
 	"foundersignal/internal/service"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type FeedbackHandler interface {
@@ -76,7 +78,7 @@ func (h *fbHandler) Create(c *gin.Context) {
 	createdFBId, err := h.service.Add(c.Request.Context(), parsedIdeaId, parsedParentId, userIdStr, &fb)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -117,7 +119,7 @@ func (h *fbHandler) Update(c *gin.Context) {
 	err = h.service.Update(c.Request.Context(), userIdStr, parsedFeedbackId, fb.Comment)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -146,11 +148,19 @@ func (h *fbHandler) GetByIdea(c *gin.Context) {
 		}
 	}
 
-	queryParams := getProcessedQueryParams(c)
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	feedbacks, err := h.service.GetByIdea(c.Request.Context(), parsedIdeaId, userIdStr, queryParams)
+	feedbacks, err := h.service.GetByIdea(c.Request.Context(), parsedIdeaId, userIdStr, getShareToken(c), queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -184,7 +194,7 @@ func (h *fbHandler) Delete(c *gin.Context) {
 
 	err = h.service.Delete(c.Request.Context(), parsedFeedbackId, userIdStr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
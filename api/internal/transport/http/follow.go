@@ -0,0 +1,219 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FollowHandler interface {
+	Follow(c *gin.Context)
+	Unfollow(c *gin.Context)
+	GetStatus(c *gin.Context)
+	GetFollowedIdeas(c *gin.Context)
+	PostUpdate(c *gin.Context)
+	GetUpdates(c *gin.Context)
+	DeleteUpdate(c *gin.Context)
+}
+
+type followHandler struct {
+	service service.FollowService
+}
+
+func NewFollowHandler(s service.FollowService) *followHandler {
+	return &followHandler{
+		service: s,
+	}
+}
+
+func (h *followHandler) Follow(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	// the body is optional, following without one turns the email digest on
+	var req request.FollowIdea
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	status, err := h.service.Follow(c.Request.Context(), userId.(string), ideaId, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *followHandler) Unfollow(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	status, err := h.service.Unfollow(c.Request.Context(), userId.(string), ideaId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *followHandler) GetStatus(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	status, err := h.service.GetStatus(c.Request.Context(), userId.(string), ideaId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *followHandler) GetFollowedIdeas(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ideas, err := h.service.GetFollowedIdeas(c.Request.Context(), userId.(string), queryParams)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ideas)
+}
+
+func (h *followHandler) PostUpdate(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.CreateIdeaUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update, err := h.service.PostUpdate(c.Request.Context(), userId.(string), ideaId, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, update)
+}
+
+// GetUpdates is public, the updates of an idea that is not active are only
+// shown to its team.
+func (h *followHandler) GetUpdates(c *gin.Context) {
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updates, err := h.service.GetUpdates(c.Request.Context(), c.GetString("userId"), ideaId, queryParams)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updates)
+}
+
+func (h *followHandler) DeleteUpdate(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	updateId, err := uuid.Parse(c.Param("updateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid update ID"})
+		return
+	}
+
+	if err := h.service.DeleteUpdate(c.Request.Context(), userId.(string), ideaId, updateId); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *followHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, service.ErrCannotFollowOwnIdea):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
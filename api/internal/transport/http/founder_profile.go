@@ -0,0 +1,91 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type FounderProfileHandler interface {
+	GetPublicProfile(c *gin.Context)
+	GetSettings(c *gin.Context)
+	UpdateSettings(c *gin.Context)
+}
+
+type founderProfileHandler struct {
+	service service.FounderProfileService
+}
+
+func NewFounderProfileHandler(s service.FounderProfileService) *founderProfileHandler {
+	return &founderProfileHandler{
+		service: s,
+	}
+}
+
+func (h *founderProfileHandler) GetPublicProfile(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+		return
+	}
+
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.GetPublicProfile(c.Request.Context(), username, queryParams)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Founder not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *founderProfileHandler) GetSettings(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	profile, err := h.service.GetSettings(c.Request.Context(), userId.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *founderProfileHandler) UpdateSettings(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req request.UpdateProfile
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.UpdateSettings(c.Request.Context(), userId.(string), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
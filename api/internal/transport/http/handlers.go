@@ -1,14 +1,18 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"foundersignal/internal/domain"
 	"foundersignal/internal/service"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Handlers struct {
@@ -22,6 +26,18 @@ type Handlers struct {
 	Dashboard DashboardHandler
 	AI        AIHandler
 	Reddit    RedditValidationHandler
+	Waitlist  WaitlistHandler
+	Survey    SurveyHandler
+	Category  CategoryHandler
+
+	Collaborator CollaboratorHandler
+	Workspace    WorkspaceHandler
+	Lifecycle    IdeaLifecycleHandler
+	Follow       FollowHandler
+	Profile      FounderProfileHandler
+	Image        IdeaImageHandler
+	Share        IdeaShareHandler
+	Comparison   IdeaComparisonHandler
 }
 
 func NewHandlers(services *service.Services) *Handlers {
@@ -36,10 +52,60 @@ func NewHandlers(services *service.Services) *Handlers {
 		Dashboard: NewDashboardHandler(services.Dashboard),
 		AI:        NewAIHandler(services.AI),
 		Reddit:    NewRedditValidationHandler(services.Reddit),
+		Waitlist:  NewWaitlistHandler(services.Waitlist),
+		Survey:    NewSurveyHandler(services.Survey),
+		Category:  NewCategoryHandler(services.Category),
+
+		Collaborator: NewCollaboratorHandler(services.Collaborator),
+		Workspace:    NewWorkspaceHandler(services.Workspace),
+		Lifecycle:    NewIdeaLifecycleHandler(services.Lifecycle),
+		Follow:       NewFollowHandler(services.Follow),
+		Profile:      NewFounderProfileHandler(services.Profile),
+		Image:        NewIdeaImageHandler(services.Image),
+		Share:        NewIdeaShareHandler(services.Share),
+		Comparison:   NewIdeaComparisonHandler(services.Comparison),
+	}
+}
+
+// errorStatus answers 403 when the user lacks the role on the idea or the
+// workspace the action needs, every other error is unexpected.
+func errorStatus(err error) int {
+	if errors.Is(err, service.ErrIdeaAccessDenied) || errors.Is(err, service.ErrWorkspaceAccessDenied) {
+		return http.StatusForbidden
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// getWorkspaceId returns the workspace selected in the dashboard switcher,
+// nil for the personal ideas of the user
+func getWorkspaceId(c *gin.Context) *uuid.UUID {
+	value, exists := c.Get(workspaceIDContextKey)
+	if !exists {
+		return nil
+	}
+
+	workspaceId := value.(uuid.UUID)
+	return &workspaceId
+}
+
+// getShareToken returns the token of the share link the idea was opened with,
+// from the share query param of the link or the header
+func getShareToken(c *gin.Context) string {
+	if token := c.Query("share"); token != "" {
+		return token
 	}
+	return c.GetHeader(shareTokenHeader)
 }
 
-func getProcessedQueryParams(c *gin.Context) domain.QueryParams {
+// getProcessedQueryParams reads the listing params of the request, only a
+// cursor that cannot be decoded is an error.
+func getProcessedQueryParams(c *gin.Context) (domain.QueryParams, error) {
 	limitStr := c.Query("limit")
 	var limit int
 	var err error
@@ -59,6 +125,17 @@ func getProcessedQueryParams(c *gin.Context) domain.QueryParams {
 	sortBy := c.DefaultQuery("sortBy", "")
 	filterBy := c.DefaultQuery("filterBy", "")
 	search := c.DefaultQuery("search", "")
+	searchMode := c.DefaultQuery("searchMode", domain.SearchModeKeyword)
+
+	// tags can be repeated (?tag=a&tag=b) or comma separated (?tag=a,b)
+	var tags []string
+	for _, value := range c.QueryArray("tag") {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
 
 	lastCreatedAt, err := time.Parse(time.RFC3339Nano, c.Query("lastCreatedAt"))
 	if err != nil {
@@ -74,14 +151,27 @@ func getProcessedQueryParams(c *gin.Context) domain.QueryParams {
 		}
 	}
 
+	var cursor *domain.Cursor
+	if encoded := c.Query("cursor"); encoded != "" {
+		cursor, err = domain.DecodeCursor(encoded)
+		if err != nil {
+			return domain.QueryParams{}, err
+		}
+	}
+
 	return domain.QueryParams{
 		Limit:         limit,
 		Offset:        offset,
 		Order:         order,
 		SortBy:        sortBy,
 		FilterBy:      filterBy,
+		SearchMode:    searchMode,
+		Category:      c.Query("category"),
+		Tags:          tags,
+		Stage:         c.Query("stage"),
 		Search:        search,
+		Cursor:        cursor,
 		LastCreatedAt: lastCreatedAt,
 		LastId:        parsedLastId,
-	}
+	}, nil
 }
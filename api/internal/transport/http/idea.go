@@ -1,12 +1,17 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"foundersignal/internal/dto/request"
 	"foundersignal/internal/service"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type IdeaHandler interface {
@@ -16,6 +21,8 @@ type IdeaHandler interface {
 	GetIdeas(c *gin.Context)
 	GetByID(c *gin.Context)
 	GetUserIdeas(c *gin.Context)
+	Import(c *gin.Context)
+	Export(c *gin.Context)
 }
 
 type ideaHandler struct {
@@ -42,14 +49,22 @@ func (h *ideaHandler) Create(c *gin.Context) {
 		return
 	}
 
-	createdIdeaId, mvpId, err := h.service.Create(c.Request.Context(), userId.(string), &idea)
+	if idea.WorkspaceID == nil {
+		idea.WorkspaceID = getWorkspaceId(c)
+	}
+
+	created, err := h.service.Create(c.Request.Context(), userId.(string), &idea)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"id": createdIdeaId, "mvpId": mvpId, "message": "Idea created successfully"})
+	c.JSON(http.StatusCreated, gin.H{"id": created.ID, "mvpId": created.MVPID, "similarIdeas": created.SimilarIdeas, "message": "Idea created successfully"})
 }
 
 func (h *ideaHandler) Update(c *gin.Context) {
@@ -75,7 +90,11 @@ func (h *ideaHandler) Update(c *gin.Context) {
 	err := h.service.Update(c.Request.Context(), userId.(string), uuid.MustParse(ideaId), idea)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrUnknownCategory) || service.IsStatusTransitionError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -98,7 +117,7 @@ func (h *ideaHandler) Delete(c *gin.Context) {
 	err := h.service.Delete(c.Request.Context(), userId.(string), uuid.MustParse(ideaId))
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -107,9 +126,19 @@ func (h *ideaHandler) Delete(c *gin.Context) {
 
 func (h *ideaHandler) GetIdeas(c *gin.Context) {
 
-	ideas, err := h.service.GetIdeas(c.Request.Context(), getProcessedQueryParams(c))
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ideas, err := h.service.GetIdeas(c.Request.Context(), queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -128,9 +157,20 @@ func (h *ideaHandler) GetUserIdeas(c *gin.Context) {
 		getStats = "false"
 	}
 
-	ideas, err := h.service.GetUserIdeas(c.Request.Context(), userId.(string), getStats == "true", getProcessedQueryParams(c))
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	queryParams.WorkspaceID = getWorkspaceId(c)
+
+	ideas, err := h.service.GetUserIdeas(c.Request.Context(), userId.(string), getStats == "true", queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -150,11 +190,100 @@ func (h *ideaHandler) GetByID(c *gin.Context) {
 		userIdStr = userId.(string)
 	}
 
-	idea, err := h.service.GetByID(c.Request.Context(), uuid.MustParse(ideaId), userIdStr)
+	idea, err := h.service.GetByID(c.Request.Context(), uuid.MustParse(ideaId), userIdStr, getShareToken(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, idea)
 }
+
+func (h *ideaHandler) Import(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req request.ImportIdeas
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+	if fileHeader.Size > service.MaxIdeaImportBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The import file is too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, service.MaxIdeaImportBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+
+	imported, err := h.service.Import(c.Request.Context(), userId.(string), getWorkspaceId(c), req, fileHeader.Filename, data)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidImportFile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, imported)
+}
+
+// Export sends the ideas as JSON, or as a CSV file with format=csv
+func (h *ideaHandler) Export(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The format must be json or csv"})
+		return
+	}
+
+	export, err := h.service.Export(c.Request.Context(), userId.(string), getWorkspaceId(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("ideas-%s.%s", export.ExportedAt.Format(time.DateOnly), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "csv" {
+		data, err := service.IdeaExportCSV(export)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
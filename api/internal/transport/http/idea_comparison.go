@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaComparisonHandler interface {
+	Compare(c *gin.Context)
+}
+
+type ideaComparisonHandler struct {
+	service service.IdeaComparisonService
+}
+
+func NewIdeaComparisonHandler(s service.IdeaComparisonService) *ideaComparisonHandler {
+	return &ideaComparisonHandler{
+		service: s,
+	}
+}
+
+// Compare takes the ideas as ids=a,b,c or as repeated ids parameters, and
+// the window as days.
+func (h *ideaComparisonHandler) Compare(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var ideaIds []uuid.UUID
+	for _, param := range c.QueryArray("ids") {
+		for _, id := range strings.Split(param, ",") {
+			parsed, err := uuid.Parse(strings.TrimSpace(id))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+				return
+			}
+			ideaIds = append(ideaIds, parsed)
+		}
+	}
+
+	var days int
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid number of days"})
+			return
+		}
+		days = parsed
+	}
+
+	comparison, err := h.service.Compare(c.Request.Context(), userId.(string), ideaIds, days)
+	if err != nil {
+		if errors.Is(err, service.ErrComparisonSize) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
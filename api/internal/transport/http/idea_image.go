@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/pkg/imageproc"
+	"foundersignal/internal/service"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaImageHandler interface {
+	Upload(c *gin.Context)
+	Remove(c *gin.Context)
+}
+
+type ideaImageHandler struct {
+	service service.IdeaImageService
+}
+
+func NewIdeaImageHandler(s service.IdeaImageService) *ideaImageHandler {
+	return &ideaImageHandler{
+		service: s,
+	}
+}
+
+func (h *ideaImageHandler) Upload(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+	if fileHeader.Size > imageproc.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": imageproc.ErrImageTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, imageproc.MaxUploadBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+
+	image, err := h.service.Upload(c.Request.Context(), userId.(string), ideaId, data)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, image)
+}
+
+func (h *ideaImageHandler) Remove(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	if err := h.service.Remove(c.Request.Context(), userId.(string), ideaId); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ideaImageHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+	case errors.Is(err, imageproc.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, imageproc.ErrUnsupportedImage), errors.Is(err, imageproc.ErrImageDimensions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaLifecycleHandler interface {
+	GetRules(c *gin.Context)
+	UpdateRules(c *gin.Context)
+}
+
+type ideaLifecycleHandler struct {
+	service service.IdeaLifecycleService
+}
+
+func NewIdeaLifecycleHandler(s service.IdeaLifecycleService) *ideaLifecycleHandler {
+	return &ideaLifecycleHandler{
+		service: s,
+	}
+}
+
+func (h *ideaLifecycleHandler) GetRules(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	rules, err := h.service.GetRules(c.Request.Context(), userId.(string), ideaId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *ideaLifecycleHandler) UpdateRules(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.UpdateLifecycleRules
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := h.service.UpdateRules(c.Request.Context(), userId.(string), ideaId, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *ideaLifecycleHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+	case errors.Is(err, service.ErrActivationInPast):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+	}
+}
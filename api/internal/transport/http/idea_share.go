@@ -0,0 +1,113 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/dto/request"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdeaShareHandler interface {
+	Create(c *gin.Context)
+	GetLinks(c *gin.Context)
+	Revoke(c *gin.Context)
+}
+
+type ideaShareHandler struct {
+	service service.IdeaShareService
+}
+
+func NewIdeaShareHandler(s service.IdeaShareService) *ideaShareHandler {
+	return &ideaShareHandler{
+		service: s,
+	}
+}
+
+func (h *ideaShareHandler) Create(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.CreateShareLink
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, err := h.service.Create(c.Request.Context(), userId.(string), ideaId, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+func (h *ideaShareHandler) GetLinks(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	links, err := h.service.GetLinks(c.Request.Context(), userId.(string), ideaId)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+func (h *ideaShareHandler) Revoke(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	linkId, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid share link ID"})
+		return
+	}
+
+	if err := h.service.Revoke(c.Request.Context(), userId.(string), ideaId, linkId); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *ideaShareHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+		return
+	}
+	c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+}
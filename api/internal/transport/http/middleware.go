@@ -11,15 +11,23 @@ import (
 	"time"
 
 	"foundersignal/internal/pkg/auth"
+	"foundersignal/internal/service"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 const (
-	userIDContextKey = "userId"
+	userIDContextKey      = "userId"
+	workspaceIDContextKey = "workspaceId"
+
+	// workspaceHeader selects the workspace the dashboard works in
+	workspaceHeader = "X-Workspace-ID"
+	// shareTokenHeader carries the token of the share link a private idea was opened with
+	shareTokenHeader = "X-Share-Token"
 )
 
 var appLogger *zap.Logger
@@ -33,7 +41,7 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "https://www.foundersignal.app", "https://foundersignal.app"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", workspaceHeader, shareTokenHeader},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -94,6 +102,37 @@ func (ca *ClerkAuth) Middleware() gin.HandlerFunc {
 	}
 }
 
+// WorkspaceSwitcher reads the workspace selected in the dashboard from the
+// X-Workspace-ID header, or the workspace query param, and checks that the
+// user is a member of it. Requests without one work on the personal ideas.
+func WorkspaceSwitcher(workspaces service.WorkspaceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader(workspaceHeader)
+		if raw == "" {
+			raw = c.Query("workspace")
+		}
+		if raw == "" {
+			c.Next()
+			return
+		}
+
+		workspaceID, err := uuid.Parse(raw)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+
+		userID := c.GetString(userIDContextKey)
+		if err := workspaces.Switch(c.Request.Context(), userID, workspaceID); err != nil {
+			c.AbortWithStatusJSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(workspaceIDContextKey, workspaceID)
+		c.Next()
+	}
+}
+
 func (ca *ClerkAuth) verifyToken(ctx context.Context, tokenString string) (string, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
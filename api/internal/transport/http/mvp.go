@@ -3,6 +3,7 @@ package http
 import (
 	"errors"
 	"foundersignal/internal/dto/request"
+	"foundersignal/internal/pkg/bundle"
 	"foundersignal/internal/service"
 	"io"
 	"net/http"
@@ -22,6 +23,15 @@ type MVPHandler interface {
 	SetActive(c *gin.Context)
 	Delete(c *gin.Context)
 	GenerateLandingPage(c *gin.Context)
+	Audit(c *gin.Context)
+	UpdatePricingTest(c *gin.Context)
+	GetPricingAnalytics(c *gin.Context)
+	CreateLocaleVariant(c *gin.Context)
+	GetLocaleVariants(c *gin.Context)
+	DeleteLocaleVariant(c *gin.Context)
+	GetLocaleAnalytics(c *gin.Context)
+	Import(c *gin.Context)
+	UpdateSurvey(c *gin.Context)
 }
 
 type mvpHandler struct {
@@ -56,7 +66,7 @@ func (h *mvpHandler) Create(c *gin.Context) {
 
 	mvpId, err := h.service.Create(c.Request.Context(), userId.(string), ideaId, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -124,7 +134,7 @@ func (h *mvpHandler) GetByID(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -151,7 +161,7 @@ func (h *mvpHandler) GetAllByIdea(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -178,9 +188,13 @@ func (h *mvpHandler) GetByIdea(c *gin.Context) {
 		}
 	}
 
-	mvp, err := h.service.GetByIdea(c.Request.Context(), parsedIdeaId, userIDPtr)
+	mvp, err := h.service.GetByIdea(c.Request.Context(), parsedIdeaId, userIDPtr, getShareToken(c), c.Query("lang"), c.GetHeader("Accept-Language"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -221,7 +235,7 @@ func (h *mvpHandler) Update(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -256,7 +270,7 @@ func (h *mvpHandler) SetActive(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -291,7 +305,7 @@ func (h *mvpHandler) Delete(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -313,9 +327,384 @@ func (h *mvpHandler) GenerateLandingPage(c *gin.Context) {
 
 	htmlContent, err := h.service.GenerateLandingPage(c.Request.Context(), req.MVPId, req.IdeaID, userId.(string), req.Prompt)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"response": htmlContent})
 }
+
+func (h *mvpHandler) Audit(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	var req request.AuditLandingPage
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.Audit(c.Request.Context(), userId.(string), ideaId, mvpId, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *mvpHandler) UpdatePricingTest(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	var req request.UpdatePricingTest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pricing, err := h.service.UpdatePricingTest(c.Request.Context(), userId.(string), ideaId, mvpId, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+		if errors.Is(err, service.ErrPricingTiersRequired) || errors.Is(err, service.ErrExternalLandingPage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pricing)
+}
+
+func (h *mvpHandler) GetPricingAnalytics(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	analytics, err := h.service.GetPricingAnalytics(c.Request.Context(), userId.(string), ideaId, mvpId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+func (h *mvpHandler) CreateLocaleVariant(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	var req request.CreateLocaleVariant
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.service.CreateLocaleVariant(c.Request.Context(), userId.(string), ideaId, mvpId, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidLocale) || errors.Is(err, service.ErrLocaleIsOriginal) || errors.Is(err, service.ErrLandingPageNotPublic) ||
+			errors.Is(err, service.ErrExternalLandingPage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Translation has been initiated. You will receive a notification once it's ready.",
+		"variant": variant,
+	})
+}
+
+func (h *mvpHandler) GetLocaleVariants(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	variants, err := h.service.GetLocaleVariants(c.Request.Context(), userId.(string), ideaId, mvpId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, variants)
+}
+
+func (h *mvpHandler) DeleteLocaleVariant(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	err = h.service.DeleteLocaleVariant(c.Request.Context(), userId.(string), ideaId, mvpId, c.Param("locale"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Locale variant not found"})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidLocale) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *mvpHandler) GetLocaleAnalytics(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	analytics, err := h.service.GetLocaleAnalytics(c.Request.Context(), userId.(string), ideaId, mvpId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// Import accepts a multipart upload with a single HTML file or a ZIP bundle in the "file" field.
+func (h *mvpHandler) Import(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	var req request.ImportMVP
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+	if fileHeader.Size > bundle.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": bundle.ErrBundleTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, bundle.MaxUploadBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+
+	imported, err := h.service.Import(c.Request.Context(), userId.(string), ideaId, req, fileHeader.Filename, data)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+		if errors.Is(err, bundle.ErrBundleTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrInvalidLandingPage) || errors.Is(err, bundle.ErrUnsupportedFile) ||
+			errors.Is(err, bundle.ErrNoHTMLEntry) || errors.Is(err, bundle.ErrInvalidPath) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, imported)
+}
+
+func (h *mvpHandler) UpdateSurvey(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	var req request.UpdateSurvey
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	survey, err := h.service.UpdateSurvey(c.Request.Context(), userId.(string), ideaId, mvpId, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+		if errors.Is(err, service.ErrSurveyQuestionRequired) || errors.Is(err, service.ErrSurveyOptionsRequired) ||
+			errors.Is(err, service.ErrExternalLandingPage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, survey)
+}
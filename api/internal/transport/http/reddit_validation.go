@@ -49,7 +49,7 @@ func (h *redditValidationHandler) GenerateValidation(c *gin.Context) {
 
 	validationID, err := h.service.GenerateValidation(c.Request.Context(), userID.(string), ideaID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -72,7 +72,7 @@ func (h *redditValidationHandler) GetValidation(c *gin.Context) {
 
 	validation, err := h.service.GetValidation(c.Request.Context(), validationID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -86,10 +86,16 @@ func (h *redditValidationHandler) GetValidationsForUser(c *gin.Context) {
 		return
 	}
 
-	queryParams := getProcessedQueryParams(c)
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	queryParams.WorkspaceID = getWorkspaceId(c)
+
 	validations, err := h.service.GetValidationsForUser(c.Request.Context(), userID.(string), queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"foundersignal/internal/domain"
 	"foundersignal/internal/dto/request"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ReportHandler interface {
@@ -78,7 +80,7 @@ func (h *reportHandler) GenerateReport(c *gin.Context) {
 	err := h.service.GenerateReports(c.Request.Context(), userIdStr, req)
 	if err != nil {
 		fmt.Println("Error generating report:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -98,7 +100,13 @@ func (h *reportHandler) GetReportsList(c *gin.Context) {
 		return
 	}
 
-	queryParams := getProcessedQueryParams(c)
+	queryParams, err := getProcessedQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	queryParams.WorkspaceID = getWorkspaceId(c)
+
 	withStats := c.Query("getStats")
 	specs := service.ReportSpecs{
 		WithStats: withStats == "true",
@@ -107,7 +115,7 @@ func (h *reportHandler) GetReportsList(c *gin.Context) {
 	res, err := h.service.GetReportsList(c.Request.Context(), userIdStr, queryParams, specs)
 	if err != nil {
 		fmt.Println("Error getting reports list:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -127,21 +135,19 @@ func (h *reportHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	_, exists := c.Get("userId")
+	userId, exists := c.Get("userId")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 		return
 	}
 
-	// userIdStr, ok := userId.(string)
-	// if !ok {
-	// 	c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
-	// 	return
-	// }
-
-	res, err := h.service.GetByID(c.Request.Context(), parsedReportId)
+	res, err := h.service.GetByID(c.Request.Context(), userId.(string), parsedReportId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -164,7 +170,7 @@ func (h *reportHandler) SubmitContentReport(c *gin.Context) {
 
 	err := h.service.SubmitContentReport(c.Request.Context(), userId.(string), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -182,7 +188,7 @@ func (h *reportHandler) SubmitBugReport(c *gin.Context) {
 
 	err := h.service.SubmitBugReport(c.Request.Context(), userId.(string), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -200,7 +206,7 @@ func (h *reportHandler) SubmitFeatureRequest(c *gin.Context) {
 
 	err := h.service.SubmitFeatureRequest(c.Request.Context(), userId.(string), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -19,20 +19,52 @@ func RegisterRoutes(router *gin.RouterGroup, h *Handlers, envs config.Config) {
 }
 
 func registerProtectedRoutes(router *gin.RouterGroup, h *Handlers) {
+	// the dashboard works in the workspace selected in the switcher
+	router.Use(h.Workspace.Switcher())
+
 	// ideas routes
 	router.GET("/user", h.User.GetById)
+	router.GET("/profile", h.Profile.GetSettings)
+	router.PUT("/profile", h.Profile.UpdateSettings)
 
 	ideasRouter := router.Group("/ideas")
 	ideasRouter.POST("/", h.Idea.Create)
+	ideasRouter.POST("/import", h.Idea.Import)
+	ideasRouter.GET("/export", h.Idea.Export)
 	ideasRouter.PUT("/:ideaId", h.Idea.Update)
 	ideasRouter.DELETE("/:ideaId", h.Idea.Delete)
+	ideasRouter.PUT("/:ideaId/image", h.Image.Upload)
+	ideasRouter.DELETE("/:ideaId/image", h.Image.Remove)
 
 	ideasRouter.POST("/:ideaId/mvp", h.MVP.Create)
 	ideasRouter.PUT("/:ideaId/mvp/:mvpId", h.MVP.Update)
 	ideasRouter.GET("/:ideaId/mvp/:mvpId", h.MVP.GetByID)
 	ideasRouter.GET("/:ideaId/mvps", h.MVP.GetAllByIdea)
+	ideasRouter.POST("/:ideaId/mvps/import", h.MVP.Import)
+	ideasRouter.GET("/:ideaId/waitlist/leaderboard", h.Waitlist.GetLeaderboard)
 	ideasRouter.PATCH("/:ideaId/mvp/:mvpId/active", h.MVP.SetActive)
 	ideasRouter.DELETE("/:ideaId/mvp/:mvpId", h.MVP.Delete)
+	ideasRouter.POST("/:ideaId/mvp/:mvpId/audit", h.MVP.Audit)
+	ideasRouter.PUT("/:ideaId/mvp/:mvpId/pricing", h.MVP.UpdatePricingTest)
+	ideasRouter.GET("/:ideaId/mvp/:mvpId/pricing/analytics", h.MVP.GetPricingAnalytics)
+	ideasRouter.POST("/:ideaId/mvp/:mvpId/locales", h.MVP.CreateLocaleVariant)
+	ideasRouter.GET("/:ideaId/mvp/:mvpId/locales", h.MVP.GetLocaleVariants)
+	ideasRouter.GET("/:ideaId/mvp/:mvpId/locales/analytics", h.MVP.GetLocaleAnalytics)
+	ideasRouter.DELETE("/:ideaId/mvp/:mvpId/locales/:locale", h.MVP.DeleteLocaleVariant)
+	ideasRouter.PUT("/:ideaId/mvp/:mvpId/survey", h.MVP.UpdateSurvey)
+	ideasRouter.GET("/:ideaId/mvp/:mvpId/survey/results", h.Survey.GetResults)
+
+	ideasRouter.PUT("/:ideaId/workspace", h.Workspace.MoveIdea)
+
+	ideasRouter.GET("/:ideaId/collaborators", h.Collaborator.GetCollaborators)
+	ideasRouter.POST("/:ideaId/collaborators/invitations", h.Collaborator.Invite)
+	ideasRouter.DELETE("/:ideaId/collaborators/invitations/:invitationId", h.Collaborator.RevokeInvitation)
+	ideasRouter.PUT("/:ideaId/collaborators/:memberId", h.Collaborator.UpdateRole)
+	ideasRouter.DELETE("/:ideaId/collaborators/:memberId", h.Collaborator.Remove)
+
+	ideasRouter.GET("/:ideaId/share-links", h.Share.GetLinks)
+	ideasRouter.POST("/:ideaId/share-links", h.Share.Create)
+	ideasRouter.DELETE("/:ideaId/share-links/:linkId", h.Share.Revoke)
 
 	ideasRouter.POST("/:ideaId/feedback", h.Feedback.Create)
 	ideasRouter.POST("/:ideaId/feedback/:feedbackId", h.Feedback.Create)
@@ -41,9 +73,32 @@ func registerProtectedRoutes(router *gin.RouterGroup, h *Handlers) {
 
 	ideasRouter.GET("/user", h.Idea.GetUserIdeas)
 	ideasRouter.GET("/user/:ideaId", h.Dashboard.GetIdea)
+	ideasRouter.GET("/compare", h.Comparison.Compare)
+	ideasRouter.GET("/:ideaId/history", h.Dashboard.GetIdeaHistory)
+	ideasRouter.GET("/:ideaId/lifecycle", h.Lifecycle.GetRules)
+	ideasRouter.PUT("/:ideaId/lifecycle", h.Lifecycle.UpdateRules)
+
+	ideasRouter.GET("/:ideaId/follow", h.Follow.GetStatus)
+	ideasRouter.POST("/:ideaId/follow", h.Follow.Follow)
+	ideasRouter.DELETE("/:ideaId/follow", h.Follow.Unfollow)
+	ideasRouter.POST("/:ideaId/updates", h.Follow.PostUpdate)
+	ideasRouter.DELETE("/:ideaId/updates/:updateId", h.Follow.DeleteUpdate)
+
+	router.POST("/invitations/:token/accept", h.Collaborator.Accept)
+
+	workspacesRouter := router.Group("/workspaces")
+	workspacesRouter.GET("/", h.Workspace.GetForUser)
+	workspacesRouter.POST("/", h.Workspace.Create)
+	workspacesRouter.GET("/:workspaceId", h.Workspace.GetByID)
+	workspacesRouter.PUT("/:workspaceId", h.Workspace.Update)
+	workspacesRouter.DELETE("/:workspaceId", h.Workspace.Delete)
+	workspacesRouter.POST("/:workspaceId/members", h.Workspace.AddMember)
+	workspacesRouter.PUT("/:workspaceId/members/:memberId", h.Workspace.UpdateMemberRole)
+	workspacesRouter.DELETE("/:workspaceId/members/:memberId", h.Workspace.RemoveMember)
 
 	router.GET("/", h.Dashboard.GetDashboardData)
 	router.GET("/recent-activity", h.Dashboard.GetRecentActivity)
+	router.GET("/following", h.Follow.GetFollowedIdeas)
 
 	router.GET("/audience", h.Dashboard.GetAudience)
 
@@ -70,8 +125,14 @@ func registerPublicRoutes(router *gin.RouterGroup, h *Handlers) {
 	ideasRouter.GET("/", h.Idea.GetIdeas)
 	ideasRouter.GET("/:ideaId", h.Idea.GetByID)
 	ideasRouter.GET("/:ideaId/feedback", h.Feedback.GetByIdea)
+	ideasRouter.GET("/:ideaId/updates", h.Follow.GetUpdates)
 	ideasRouter.GET("/:ideaId/mvp", h.MVP.GetByIdea)
 	ideasRouter.POST("/:ideaId/mvp/:mvpId/signals", h.Signal.RecordSignal)
+	ideasRouter.GET("/:ideaId/waitlist/:referralCode", h.Waitlist.GetPosition)
+
+	router.GET("/categories", h.Category.GetTree)
+
+	router.GET("/founders/:username", h.Profile.GetPublicProfile)
 
 	router.POST("/reports/submit", h.Report.SubmitContentReport)
 	router.POST("/reports/feature", h.Report.SubmitFeatureRequest)
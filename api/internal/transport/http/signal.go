@@ -51,12 +51,17 @@ func (h *signalHandler) RecordSignal(c *gin.Context) {
 	ipAddress := c.ClientIP()
 	userAgent := c.Request.UserAgent()
 
-	err = h.ideaService.RecordSignal(c.Request.Context(), ideaID, mvpId, userId, req.EventType, ipAddress, userAgent, req.Metadata)
+	waitlist, err := h.ideaService.RecordSignal(c.Request.Context(), ideaID, mvpId, userId, getShareToken(c), req.EventType, ipAddress, userAgent, req.Metadata)
 	if err != nil {
 		log.Printf("Error recording signal for idea %s: %v", ideaIDStr, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record signal"})
 		return
 	}
 
+	if waitlist != nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Signal recorded", "waitlist": waitlist})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Signal recorded"})
 }
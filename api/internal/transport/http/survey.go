@@ -0,0 +1,58 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyHandler interface {
+	GetResults(c *gin.Context)
+}
+
+type surveyHandler struct {
+	service service.SurveyService
+}
+
+func NewSurveyHandler(s service.SurveyService) *surveyHandler {
+	return &surveyHandler{
+		service: s,
+	}
+}
+
+func (h *surveyHandler) GetResults(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	mvpId, err := uuid.Parse(c.Param("mvpId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MVP ID"})
+		return
+	}
+
+	results, err := h.service.GetResults(c.Request.Context(), userId.(string), ideaId, mvpId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "MVP not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"foundersignal/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WaitlistHandler interface {
+	GetPosition(c *gin.Context)
+	GetLeaderboard(c *gin.Context)
+}
+
+type waitlistHandler struct {
+	service service.WaitlistService
+}
+
+func NewWaitlistHandler(s service.WaitlistService) *waitlistHandler {
+	return &waitlistHandler{
+		service: s,
+	}
+}
+
+func (h *waitlistHandler) GetPosition(c *gin.Context) {
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	userId := c.GetString("userId")
+
+	position, err := h.service.GetPosition(c.Request.Context(), ideaId, userId, getShareToken(c), c.Param("referralCode"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Referral code not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, position)
+}
+
+func (h *waitlistHandler) GetLeaderboard(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	ideaId, err := uuid.Parse(c.Param("ideaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid idea ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	leaderboard, err := h.service.GetLeaderboard(c.Request.Context(), userId.(string), ideaId, limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Idea not found"})
+			return
+		}
+
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
@@ -17,6 +17,8 @@ type ActivityBroadcaster interface {
 	FormatAndBroadcastComment(userID string, comment domain.Feedback, ideaTitle string)
 	FormatAndBroadcastReaction(userID string, reaction domain.IdeaReaction, ideaTitle string)
 	FormatAndBroadcastContentReport(userID string, activity domain.Activity, ideaTitle string)
+	FormatAndBroadcastStatusChange(userID string, activity domain.Activity, ideaTitle string)
+	FormatAndBroadcastIdeaUpdate(userID string, activity domain.Activity, ideaTitle string)
 }
 
 type hubBroadcaster struct {
@@ -129,3 +131,27 @@ func (b *hubBroadcaster) FormatAndBroadcastContentReport(userID string, activity
 	}
 	b.BroadcastActivity(userID, activityItem)
 }
+
+func (b *hubBroadcaster) FormatAndBroadcastStatusChange(userID string, activity domain.Activity, ideaTitle string) {
+	activityItem := &response.ActivityItem{
+		ID:        activity.ID.String(),
+		Type:      string(activity.Type),
+		IdeaID:    activity.IdeaID.String(),
+		IdeaTitle: ideaTitle,
+		Message:   activity.Message,
+		Timestamp: activity.CreatedAt,
+	}
+	b.BroadcastActivity(userID, activityItem)
+}
+
+func (b *hubBroadcaster) FormatAndBroadcastIdeaUpdate(userID string, activity domain.Activity, ideaTitle string) {
+	activityItem := &response.ActivityItem{
+		ID:        activity.ID.String(),
+		Type:      string(activity.Type),
+		IdeaID:    activity.IdeaID.String(),
+		IdeaTitle: ideaTitle,
+		Message:   activity.Message,
+		Timestamp: activity.CreatedAt,
+	}
+	b.BroadcastActivity(userID, activityItem)
+}
@@ -28,8 +28,22 @@ func Connect(dbConfig domain.DBConfig) error {
 	err = DB.AutoMigrate(
 		&domain.User{},
 		&domain.PaddleProcessedEvent{},
+		&domain.Workspace{},
+		&domain.WorkspaceMember{},
+		&domain.Category{},
 		&domain.Idea{},
+		&domain.IdeaEmbedding{},
+		&domain.IdeaMember{},
+		&domain.IdeaInvitation{},
+		&domain.IdeaRevision{},
+		&domain.IdeaLifecycleRules{},
+		&domain.IdeaFollower{},
+		&domain.IdeaUpdate{},
+		&domain.FounderProfile{},
+		&domain.IdeaShareLink{},
 		&domain.MVPSimulator{},
+		&domain.MVPLocale{},
+		&domain.SurveyAnswer{},
 		&domain.Signal{},
 		&domain.Feedback{},
 		&domain.FeedbackReaction{},
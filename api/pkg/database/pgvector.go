@@ -0,0 +1,28 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EnablePgvector installs the pgvector extension and adds the native vector
+// column of the idea embeddings, it must run after the auto migration.
+func EnablePgvector(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS vector").Error; err != nil {
+		return fmt.Errorf("failed to create the vector extension: %w", err)
+	}
+
+	// untyped so that switching the embedding model does not need a migration
+	if err := db.Exec("ALTER TABLE idea_embeddings ADD COLUMN IF NOT EXISTS embedding vector").Error; err != nil {
+		return fmt.Errorf("failed to add the embedding column: %w", err)
+	}
+
+	// embeddings stored while the memory backend was used only have the jsonb
+	// vector, the text of a jsonb array is also a valid vector literal
+	if err := db.Exec("UPDATE idea_embeddings SET embedding = vector::text::vector WHERE embedding IS DISTINCT FROM vector::text::vector").Error; err != nil {
+		return fmt.Errorf("failed to backfill the embedding column: %w", err)
+	}
+
+	return nil
+}
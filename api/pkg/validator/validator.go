@@ -2,15 +2,30 @@ package validator
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+// requestValidate checks the binding tags of the request DTOs, the rules gin
+// applies to the bodies it binds
+var requestValidate *validator.Validate
+
 func init() {
 	validate = validator.New()
 
+	requestValidate = validator.New()
+	requestValidate.SetTagName("binding")
+	requestValidate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
 }
 
 func Validate(s interface{}) error {
@@ -21,6 +36,17 @@ func Validate(s interface{}) error {
 	return nil
 }
 
+// ValidateRequest checks a request DTO that was not bound by gin, a row of an
+// import for example. It returns the failed rule of each invalid field, keyed
+// by its JSON name, and nil when the request is valid.
+func ValidateRequest(s interface{}) map[string]string {
+	if err := requestValidate.Struct(s); err != nil {
+		return translateValidationErrors(err)
+	}
+
+	return nil
+}
+
 func translateValidationErrors(err error) map[string]string {
 	errors := make(map[string]string)
 
@@ -9,11 +9,13 @@ import { getComments } from "@/components/comments/get-comments";
 export const CommentsSection = async ({
   ideaId,
   ideaCreatorId,
+  shareToken,
 }: {
   ideaId: string;
   ideaCreatorId: string;
+  shareToken?: string;
 }) => {
-  const data = await getComments(ideaId);
+  const data = await getComments(ideaId, undefined, shareToken);
   const comments = data?.comments || [];
   const { userId } = await auth();
 
@@ -2,7 +2,7 @@
 
 import { cache } from "react";
 
-import { api } from "@/lib/api";
+import { api, shareHeaders } from "@/lib/api";
 import { Idea } from "@/types/idea";
 
 type IdeaExtended = Idea & {
@@ -19,14 +19,14 @@ type IdeaExtended = Idea & {
   feedbackHighlights: string[];
 };
 
-export const getIdea = cache(async (id: string) => {
+export const getIdea = cache(async (id: string, shareToken?: string) => {
   try {
-    const response = await api.get(`/ideas/${id}`, {
-      next: {
-        revalidate: 3600,
-        tags: [`idea-${id}`],
-      },
-    });
+    // a private idea opened with a share link must not land in the shared cache
+    const options: RequestInit = shareToken
+      ? { headers: shareHeaders(shareToken), cache: "no-store" }
+      : { next: { revalidate: 3600, tags: [`idea-${id}`] } };
+
+    const response = await api.get(`/ideas/${id}`, options);
 
     if (!response.ok) {
       console.error(
@@ -29,11 +29,16 @@ export const revalidate = 7200; // 2 hours
 
 type Props = {
   params: Promise<{ id: string }>;
+  searchParams: Promise<{ share?: string }>;
 };
 
-export async function generateMetadata({ params }: Props): Promise<Metadata> {
+export async function generateMetadata({
+  params,
+  searchParams,
+}: Props): Promise<Metadata> {
   const { id: ideaId } = await params;
-  const data = await getIdea(ideaId);
+  const { share } = await searchParams;
+  const data = await getIdea(ideaId, share);
 
   if (!data?.idea) {
     return {
@@ -52,18 +57,15 @@ export async function generateMetadata({ params }: Props): Promise<Metadata> {
   return createMetadata({
     title,
     description,
-    image: idea.imageUrl,
+    image: idea.shareImageUrl || idea.imageUrl,
     urlPath: `explore/${idea.id}`,
   });
 }
 
-export default async function IdeaPage({
-  params,
-}: {
-  params: Promise<{ id: string }>;
-}) {
+export default async function IdeaPage({ params, searchParams }: Props) {
   const { id: ideaId } = await params;
-  const res = await getIdea(ideaId);
+  const { share } = await searchParams;
+  const res = await getIdea(ideaId, share);
 
   if (!res || !res.idea) {
     notFound();
@@ -126,7 +128,11 @@ export default async function IdeaPage({
 
                 <div className="flex items-center gap-2">
                   <CustomLink
-                    href={`/mvp/${ideaId}`}
+                    href={
+                      share
+                        ? `/mvp/${ideaId}?share=${encodeURIComponent(share)}`
+                        : `/mvp/${ideaId}`
+                    }
                     target="_blank"
                     variant="outline"
                     className="bg-transparent border border-primary text-primary rounded-lg hover:bg-primary/5 transition-colors"
@@ -269,7 +275,11 @@ export default async function IdeaPage({
               </div>
             }
           >
-            <CommentsSection ideaId={idea.id} ideaCreatorId={idea.userId} />
+            <CommentsSection
+              ideaId={idea.id}
+              ideaCreatorId={idea.userId}
+              shareToken={share}
+            />
           </Suspense>
 
           {/* CTA for visitors */}
@@ -24,6 +24,10 @@ const sortOptions = [
     label: "Newest First",
     value: "newest",
   },
+  {
+    label: "Trending",
+    value: "trending",
+  },
   {
     label: "Oldest First",
     value: "oldest",
@@ -92,6 +96,7 @@ export default function Ideas({
         offset,
         sortBy,
         search: searchQuery,
+        searchMode: searchQuery ? "hybrid" : undefined,
       });
 
       if (result && "ideas" in result && Array.isArray(result.ideas)) {
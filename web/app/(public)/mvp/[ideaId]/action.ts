@@ -1,19 +1,22 @@
 "use server";
 
+import { headers } from "next/headers";
 import { cache } from "react";
 
-import { api } from "@/lib/api";
+import { api, shareHeaders } from "@/lib/api";
 
 export async function sendSignal(
   ideaId: string,
   mvpId: string | null | undefined,
   eventType: string,
-  metadata?: { [key: string]: unknown }
+  metadata?: { [key: string]: unknown },
+  shareToken?: string | null
 ): Promise<void> {
   try {
     await api.post(
       `/ideas/${ideaId}/mvp/${mvpId}/signals`,
-      JSON.stringify({ eventType, metadata })
+      JSON.stringify({ eventType, metadata }),
+      { headers: shareHeaders(shareToken) }
     );
     console.log(`Signal '${eventType}' sent for idea ${ideaId}`, metadata);
   } catch (error) {
@@ -21,58 +24,78 @@ export async function sendSignal(
   }
 }
 
-export const getMVP = cache(async (ideaId: string, mvpId?: string | null) => {
-  try {
-    let url = `/ideas/${ideaId}/mvp`;
+export const getMVP = cache(
+  async (
+    ideaId: string,
+    mvpId?: string | null,
+    lang?: string | null,
+    shareToken?: string | null
+  ) => {
+    try {
+      let url = `/ideas/${ideaId}/mvp`;
+      // the visitor's language picks the translated variant of the page
+      const requestHeaders: Record<string, string> = shareHeaders(shareToken);
 
-    if (mvpId) {
-      url = `/dashboard/ideas/${ideaId}/mvp/${mvpId}`;
-    }
+      if (mvpId) {
+        url = `/dashboard/ideas/${ideaId}/mvp/${mvpId}`;
+      } else {
+        if (lang) {
+          url += `?lang=${encodeURIComponent(lang)}`;
+        }
 
-    const response = await api.get(url, {
-      next: {
-        tags: [`mvp-${ideaId}`],
-      },
-    });
+        const acceptLanguage = (await headers()).get("accept-language");
+        if (acceptLanguage) {
+          requestHeaders["Accept-Language"] = acceptLanguage;
+        }
+      }
 
-    if (!response.ok) {
-      console.error(
-        "API error fetching mvp:",
-        response.status,
-        response.statusText
-      );
+      // the page of a private idea opened with a share link is not cached
+      const response = await api.get(url, {
+        headers: requestHeaders,
+        ...(shareToken
+          ? { cache: "no-store" as const }
+          : { next: { tags: [`mvp-${ideaId}`] } }),
+      });
 
-      return null;
-    }
+      if (!response.ok) {
+        console.error(
+          "API error fetching mvp:",
+          response.status,
+          response.statusText
+        );
 
-    const data = await response.json();
+        return null;
+      }
 
-    if (data?.htmlUrl) {
-      try {
-        const response = await fetch(data.htmlUrl, {
-          headers: {
-            "Content-Type": "text/html",
-          },
-          next: {
-            tags: [`mvp-${ideaId}`], // Cache tag for revalidation
-          },
-        });
-        if (!response.ok) {
-          throw new Error(`HTTP error! status: ${response.status}`);
-        }
+      const data = await response.json();
+
+      if (data?.htmlUrl) {
+        try {
+          const response = await fetch(data.htmlUrl, {
+            headers: {
+              "Content-Type": "text/html",
+            },
+            next: {
+              tags: [`mvp-${ideaId}`], // Cache tag for revalidation
+            },
+          });
+          if (!response.ok) {
+            throw new Error(`HTTP error! status: ${response.status}`);
+          }
 
-        const content = await response.text();
+          const content = await response.text();
 
-        data.htmlContent = content;
-      } catch (error) {
-        console.error("Failed to fetch HTML content:", error);
-        data.htmlContent = undefined;
+          data.htmlContent = content;
+        } catch (error) {
+          console.error("Failed to fetch HTML content:", error);
+          data.htmlContent = undefined;
+        }
       }
-    }
 
-    return data;
-  } catch (error) {
-    console.error("Error in getMVP:", error);
-    return null;
+      return data;
+    } catch (error) {
+      console.error("Error in getMVP:", error);
+      return null;
+    }
   }
-});
+);
@@ -8,18 +8,27 @@ interface MVPProps {
   htmlContent: string;
   ideaId: string;
   mvpId?: string | null;
+  shareToken?: string | null;
 }
 
-export const MVP = ({ htmlContent, ideaId, mvpId }: MVPProps) => {
+export const MVP = ({ htmlContent, ideaId, mvpId, shareToken }: MVPProps) => {
   const handleSignal = useCallback(
     async (eventType: string, metadata?: { [key: string]: unknown }) => {
       try {
-        await sendSignal(ideaId, mvpId, eventType, metadata);
+        // forward the waitlist referral code of a shared link
+        const ref = new URLSearchParams(window.location.search).get("ref");
+        await sendSignal(
+          ideaId,
+          mvpId,
+          eventType,
+          ref ? { ...metadata, ref } : metadata,
+          shareToken
+        );
       } catch (error) {
         console.error("Error sending signal:", error);
       }
     },
-    [ideaId, mvpId]
+    [ideaId, mvpId, shareToken]
   );
 
   useEffect(() => {
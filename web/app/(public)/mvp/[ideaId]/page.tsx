@@ -17,8 +17,12 @@ export async function generateMetadata({
   searchParams,
 }: Props): Promise<Metadata> {
   const { ideaId } = await params;
-  const { mvpId } = (await searchParams) as { mvpId?: string };
-  const data = await getMVP(ideaId, mvpId);
+  const { mvpId, lang, share } = (await searchParams) as {
+    mvpId?: string;
+    lang?: string;
+    share?: string;
+  };
+  const data = await getMVP(ideaId, mvpId, lang, share);
 
   if (!data?.idea) {
     return {
@@ -33,15 +37,19 @@ export async function generateMetadata({
   return createMetadata({
     title,
     description,
-    image: idea.imageUrl,
+    image: data.shareImageUrl || idea.shareImageUrl || idea.imageUrl,
     urlPath: `mvp/${ideaId}`,
   });
 }
 
 export default async function MVPPage({ params, searchParams }: Props) {
   const { ideaId } = await params;
-  const { mvpId } = (await searchParams) as { mvpId?: string };
-  const mvp = await getMVP(ideaId, mvpId);
+  const { mvpId, lang, share } = (await searchParams) as {
+    mvpId?: string;
+    lang?: string;
+    share?: string;
+  };
+  const mvp = await getMVP(ideaId, mvpId, lang, share);
 
   if (!mvp || !mvp.htmlContent) {
     // You could redirect or show a more user-friendly error page
@@ -68,7 +76,12 @@ export default async function MVPPage({ params, searchParams }: Props) {
 
   return (
     <Suspense fallback={<div>Loading...</div>}>
-      <MVP htmlContent={mvp.htmlContent} ideaId={ideaId} mvpId={mvpId} />
+      <MVP
+        htmlContent={mvp.htmlContent}
+        ideaId={ideaId}
+        mvpId={mvpId}
+        shareToken={share}
+      />
     </Suspense>
   );
 }
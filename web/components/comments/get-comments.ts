@@ -2,22 +2,22 @@
 
 import { User } from "@clerk/nextjs/server";
 
-import { api, QueryParams } from "@/lib/api";
+import { api, QueryParams, shareHeaders } from "@/lib/api";
 import { getUsers } from "@/lib/auth";
 import { constructNewPath, getName } from "@/lib/utils";
 import { Comment, CommentExtended } from "@/types/comment";
 import { cache } from "react";
 
-export const getComments = cache(async (ideaId: string, qs?: QueryParams) => {
+export const getComments = cache(async (ideaId: string, qs?: QueryParams, shareToken?: string) => {
   try {
     const url = constructNewPath(`/ideas/${ideaId}/feedback`, qs);
 
-    const response = await api.get(url, {
-      next: {
-        revalidate: 3600,
-        tags: [`comments-${ideaId}`],
-      },
-    });
+    // like the idea, the comments seen through a share link are not cached
+    const options: RequestInit = shareToken
+      ? { headers: shareHeaders(shareToken), cache: "no-store" }
+      : { next: { revalidate: 3600, tags: [`comments-${ideaId}`] } };
+
+    const response = await api.get(url, options);
 
     if (!response.ok) {
       console.error(
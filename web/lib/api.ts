@@ -21,8 +21,8 @@ export async function customFetch(path: string, options: RequestInit = {}) {
 export const api = {
   get: (path: string, options?: RequestInit) =>
     customFetch(path, { method: "GET", ...options }),
-  post: (path: string, body?: BodyInit | null) =>
-    customFetch(path, { method: "POST", body }),
+  post: (path: string, body?: BodyInit | null, options?: RequestInit) =>
+    customFetch(path, { method: "POST", body, ...options }),
   put: (path: string, body?: BodyInit | null) =>
     customFetch(path, { method: "PUT", body }),
   patch: (path: string, body?: BodyInit | null) =>
@@ -30,12 +30,21 @@ export const api = {
   delete: (path: string) => customFetch(path, { method: "DELETE" }),
 };
 
+// shareHeaders forwards the token of the share link a private idea was opened
+// with, the API only shows the idea to visitors holding it
+export const shareHeaders = (
+  shareToken?: string | null
+): Record<string, string> =>
+  shareToken ? { "X-Share-Token": shareToken } : {};
+
 export type QueryParams = {
   sortBy?: string;
   limit?: number;
   offset?: number;
   filterBy?: string;
   search?: string;
+  searchMode?: "keyword" | "hybrid";
+  cursor?: string;
 
   lastCreatedAt?: string;
   lastId?: string;
@@ -19,6 +19,7 @@ export interface Idea {
   createdAt: string;
   updatedAt: string;
   imageUrl: string;
+  shareImageUrl?: string;
 }
 
 export interface LandingPage {
@@ -28,6 +29,7 @@ export interface LandingPage {
   isActive: boolean;
   htmlContent: string;
   htmlUrl: string;
+  shareImageUrl?: string;
   views: number;
   signups: number;
   createdAt: string;